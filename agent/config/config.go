@@ -0,0 +1,353 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config loads agents from YAML files.
+//
+// A config file describes a single agent. The agent_class field selects the
+// kind of agent and defaults to LlmAgent:
+//
+//	agent_class: LlmAgent
+//	name: assistant
+//	model: gemini-2.5-flash
+//	instruction: You are a helpful assistant.
+//	output_key: answer
+//	generate_content_config:
+//	  temperature: 0.2
+//	tools:
+//	  - name: google_search
+//	sub_agents:
+//	  - config_path: researcher.yaml
+//	  - name: inline_agent
+//	    model: gemini-2.5-flash
+//
+// Sub-agents are either defined inline or loaded from another file, with a
+// path relative to the referencing file. Models, tools and toolsets are Go
+// values resolved by name through a Registry. The generate_content_config,
+// input_schema and output_schema fields use the JSON field names of the
+// corresponding genai types.
+//
+// Workflow agents are described with the SequentialAgent, ParallelAgent,
+// LoopAgent, RouterAgent, GraphAgent and MapAgent classes. Options which take
+// Go functions, such as route or edge conditions, are not available in config
+// files.
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/genai"
+	"gopkg.in/yaml.v3"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/agent/workflowagents/graphagent"
+	"google.golang.org/adk/agent/workflowagents/loopagent"
+	"google.golang.org/adk/agent/workflowagents/mapagent"
+	"google.golang.org/adk/agent/workflowagents/parallelagent"
+	"google.golang.org/adk/agent/workflowagents/routeragent"
+	"google.golang.org/adk/agent/workflowagents/sequentialagent"
+)
+
+// Load creates the agent described by the config file, including its
+// sub-agents. Invalid config files are reported with an *Error.
+func Load(ctx context.Context, path string, registry *Registry) (agent.Agent, error) {
+	l := &loader{ctx: ctx, registry: registry, loading: make(map[string]bool)}
+	return l.loadFile(path)
+}
+
+// NewLoader loads the agents described by the config files and returns an
+// agent.Loader serving them, e.g. for the web and console launchers. The
+// first file describes the root agent.
+func NewLoader(ctx context.Context, registry *Registry, paths ...string) (agent.Loader, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("at least one config file is required")
+	}
+	agents := make([]agent.Agent, 0, len(paths))
+	for _, path := range paths {
+		a, err := Load(ctx, path, registry)
+		if err != nil {
+			return nil, err
+		}
+		agents = append(agents, a)
+	}
+	return agent.NewMultiLoader(agents[0], agents[1:]...)
+}
+
+type loader struct {
+	ctx      context.Context
+	registry *Registry
+	// loading holds the files being loaded, to detect reference cycles.
+	loading map[string]bool
+}
+
+func (l *loader) loadFile(path string) (agent.Agent, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, &Error{File: path, Err: fmt.Errorf("failed to resolve path: %w", err)}
+	}
+	if l.loading[absPath] {
+		return nil, &Error{File: path, Err: fmt.Errorf("config file references itself")}
+	}
+	l.loading[absPath] = true
+	defer delete(l.loading, absPath)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &Error{File: path, Err: fmt.Errorf("failed to read config file: %w", err)}
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, yamlError(path, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, &Error{File: path, Err: fmt.Errorf("config file is empty")}
+	}
+	return l.buildAgent(path, doc.Content[0])
+}
+
+func (l *loader) buildAgent(file string, n *yaml.Node) (agent.Agent, error) {
+	if n.Kind != yaml.MappingNode {
+		return nil, errorf(file, n, "agent config must be a mapping")
+	}
+
+	var class string
+	if classNode := fieldNode(n, "agent_class"); classNode != nil {
+		class = classNode.Value
+	}
+
+	switch class {
+	case "", ClassLLMAgent:
+		return l.buildLLMAgent(file, n)
+	case ClassSequentialAgent:
+		var spec sequentialAgentSpec
+		return l.buildWorkflowAgent(file, n, &spec, &spec.Base, func(cfg agent.Config) (agent.Agent, error) {
+			return sequentialagent.New(sequentialagent.Config{AgentConfig: cfg})
+		})
+	case ClassParallelAgent:
+		var spec parallelAgentSpec
+		return l.buildWorkflowAgent(file, n, &spec, &spec.Base, func(cfg agent.Config) (agent.Agent, error) {
+			return parallelagent.New(parallelagent.Config{
+				AgentConfig:    cfg,
+				MaxConcurrency: spec.MaxConcurrency,
+				Timeout:        spec.Timeout,
+				Timeouts:       spec.Timeouts,
+				ErrorPolicy:    parallelagent.ErrorPolicy(spec.ErrorPolicy),
+				ErrorsKey:      spec.ErrorsKey,
+				OutputKey:      spec.OutputKey,
+			})
+		})
+	case ClassLoopAgent:
+		var spec loopAgentSpec
+		return l.buildWorkflowAgent(file, n, &spec, &spec.Base, func(cfg agent.Config) (agent.Agent, error) {
+			return loopagent.New(loopagent.Config{
+				AgentConfig:         cfg,
+				MaxIterations:       spec.MaxIterations,
+				IterationKey:        spec.IterationKey,
+				EmitIterationEvents: spec.EmitIterationEvents,
+			})
+		})
+	case ClassRouterAgent:
+		var spec routerAgentSpec
+		return l.buildWorkflowAgent(file, n, &spec, &spec.Base, func(cfg agent.Config) (agent.Agent, error) {
+			routerCfg := routeragent.Config{
+				AgentConfig:           cfg,
+				ClassifierInstruction: spec.ClassifierInstruction,
+				DefaultAgent:          spec.DefaultAgent,
+			}
+			if spec.ClassifierModel != "" {
+				m, err := l.registry.model(l.ctx, spec.ClassifierModel)
+				if err != nil {
+					return nil, err
+				}
+				routerCfg.Classifier = m
+			}
+			return routeragent.New(routerCfg)
+		})
+	case ClassGraphAgent:
+		var spec graphAgentSpec
+		return l.buildWorkflowAgent(file, n, &spec, &spec.Base, func(cfg agent.Config) (agent.Agent, error) {
+			edges := make([]graphagent.Edge, 0, len(spec.Edges))
+			for _, e := range spec.Edges {
+				edges = append(edges, graphagent.Edge{From: e.From, To: e.To})
+			}
+			return graphagent.New(graphagent.Config{AgentConfig: cfg, Edges: edges})
+		})
+	case ClassMapAgent:
+		var spec mapAgentSpec
+		return l.buildWorkflowAgent(file, n, &spec, &spec.Base, func(cfg agent.Config) (agent.Agent, error) {
+			return mapagent.New(mapagent.Config{
+				AgentConfig:    cfg,
+				InputKey:       spec.InputKey,
+				ItemKey:        spec.ItemKey,
+				ResultKey:      spec.ResultKey,
+				OutputKey:      spec.OutputKey,
+				MaxConcurrency: spec.MaxConcurrency,
+				ErrorPolicy:    mapagent.ErrorPolicy(spec.ErrorPolicy),
+				ErrorsKey:      spec.ErrorsKey,
+			})
+		})
+	default:
+		return nil, errorf(file, fieldNode(n, "agent_class"), "unknown agent_class %q", class)
+	}
+}
+
+// buildWorkflowAgent decodes the spec, builds the sub-agents and calls
+// newAgent with the resulting agent.Config.
+func (l *loader) buildWorkflowAgent(file string, n *yaml.Node, spec any, base *baseSpec, newAgent func(agent.Config) (agent.Agent, error)) (agent.Agent, error) {
+	if err := decode(file, n, spec); err != nil {
+		return nil, err
+	}
+	cfg, err := l.agentConfig(file, n, base)
+	if err != nil {
+		return nil, err
+	}
+	a, err := newAgent(cfg)
+	if err != nil {
+		return nil, errorf(file, n, "failed to create %s %q: %w", base.AgentClass, base.Name, err)
+	}
+	return a, nil
+}
+
+func (l *loader) buildLLMAgent(file string, n *yaml.Node) (agent.Agent, error) {
+	var spec llmAgentSpec
+	if err := decode(file, n, &spec); err != nil {
+		return nil, err
+	}
+	base, err := l.agentConfig(file, n, &spec.Base)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := llmagent.Config{
+		Name:                     base.Name,
+		Description:              base.Description,
+		SubAgents:                base.SubAgents,
+		Instruction:              spec.Instruction,
+		GlobalInstruction:        spec.GlobalInstruction,
+		OutputKey:                spec.OutputKey,
+		DisallowTransferToParent: spec.DisallowTransferToParent,
+		DisallowTransferToPeers:  spec.DisallowTransferToPeers,
+	}
+
+	if spec.Model == "" {
+		return nil, errorf(file, n, "model is required")
+	}
+	if cfg.Model, err = l.registry.model(l.ctx, spec.Model); err != nil {
+		return nil, errorf(file, fieldNode(n, "model"), "%w", err)
+	}
+
+	switch llmagent.IncludeContents(spec.IncludeContents) {
+	case "", llmagent.IncludeContentsDefault, llmagent.IncludeContentsNone:
+		cfg.IncludeContents = llmagent.IncludeContents(spec.IncludeContents)
+	default:
+		return nil, errorf(file, fieldNode(n, "include_contents"), "unknown include_contents %q", spec.IncludeContents)
+	}
+
+	if spec.GenerateContentConfig.Kind != 0 {
+		cfg.GenerateContentConfig = &genai.GenerateContentConfig{}
+		if err := decodeJSON(file, &spec.GenerateContentConfig, cfg.GenerateContentConfig); err != nil {
+			return nil, err
+		}
+	}
+	if spec.InputSchema.Kind != 0 {
+		cfg.InputSchema = &genai.Schema{}
+		if err := decodeJSON(file, &spec.InputSchema, cfg.InputSchema); err != nil {
+			return nil, err
+		}
+	}
+	if spec.OutputSchema.Kind != 0 {
+		cfg.OutputSchema = &genai.Schema{}
+		if err := decodeJSON(file, &spec.OutputSchema, cfg.OutputSchema); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range spec.Tools {
+		toolNode := &spec.Tools[i]
+		var ts toolSpec
+		if err := decode(file, toolNode, &ts); err != nil {
+			return nil, err
+		}
+		t, toolset, ok := l.registry.lookupTool(ts.Name)
+		if !ok {
+			return nil, errorf(file, toolNode, "tool %q is not registered", ts.Name)
+		}
+		if t != nil {
+			cfg.Tools = append(cfg.Tools, t)
+		} else {
+			cfg.Toolsets = append(cfg.Toolsets, toolset)
+		}
+	}
+
+	a, err := llmagent.New(cfg)
+	if err != nil {
+		return nil, errorf(file, n, "failed to create %s %q: %w", ClassLLMAgent, cfg.Name, err)
+	}
+	return a, nil
+}
+
+// agentConfig validates the common fields and builds the sub-agents.
+func (l *loader) agentConfig(file string, n *yaml.Node, spec *baseSpec) (agent.Config, error) {
+	if spec.Name == "" {
+		return agent.Config{}, errorf(file, n, "name is required")
+	}
+	cfg := agent.Config{
+		Name:        spec.Name,
+		Description: spec.Description,
+	}
+	for i := range spec.SubAgents {
+		sa, err := l.buildSubAgent(file, &spec.SubAgents[i])
+		if err != nil {
+			return agent.Config{}, err
+		}
+		cfg.SubAgents = append(cfg.SubAgents, sa)
+	}
+	return cfg, nil
+}
+
+func (l *loader) buildSubAgent(file string, n *yaml.Node) (agent.Agent, error) {
+	if fieldNode(n, "config_path") == nil {
+		return l.buildAgent(file, n)
+	}
+	var ref subAgentRefSpec
+	if err := decode(file, n, &ref); err != nil {
+		return nil, err
+	}
+	path := ref.ConfigPath
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(file), path)
+	}
+	a, err := l.loadFile(path)
+	if err != nil {
+		return nil, &Error{File: file, Line: n.Line, Err: err}
+	}
+	return a, nil
+}
+
+// fieldNode returns the value node of the mapping's key, or nil if the key
+// doesn't exist.
+func fieldNode(n *yaml.Node, key string) *yaml.Node {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
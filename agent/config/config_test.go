@@ -0,0 +1,298 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/config"
+	"google.golang.org/adk/agent/workflowagents/loopagent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestLoad(t *testing.T) {
+	llm := &recordingLLM{}
+	registry := newRegistry(t, llm)
+
+	root, err := config.Load(t.Context(), "testdata/root.yaml", registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := root.Name(), "pipeline"; got != want {
+		t.Errorf("root.Name() = %q, want %q", got, want)
+	}
+	var subAgents []string
+	for _, sa := range root.SubAgents() {
+		subAgents = append(subAgents, sa.Name())
+	}
+	if diff := cmp.Diff([]string{"writer", "reviewer"}, subAgents); diff != "" {
+		t.Errorf("sub-agents mismatch (-want +got):\n%s", diff)
+	}
+
+	runAgent(t, root)
+
+	if len(llm.requests) != 2 {
+		t.Fatalf("got %d model requests, want 2", len(llm.requests))
+	}
+	writerReq, reviewerReq := llm.requests[0], llm.requests[1]
+	if got := writerReq.Config.Temperature; got == nil || *got != 0.5 {
+		t.Errorf("writer temperature = %v, want 0.5", got)
+	}
+	if got, want := writerReq.Config.MaxOutputTokens, int32(100); got != want {
+		t.Errorf("writer max output tokens = %d, want %d", got, want)
+	}
+	if _, ok := reviewerReq.Tools["get_weather"]; !ok {
+		t.Errorf("reviewer tools = %v, want get_weather", reviewerReq.Tools)
+	}
+	if got, want := reviewerReq.Config.SystemInstruction.Parts[0].Text, "Review the poem: a poem"; !strings.Contains(got, want) {
+		t.Errorf("reviewer instruction = %q, want it to contain %q", got, want)
+	}
+}
+
+func TestNewLoader(t *testing.T) {
+	registry := newRegistry(t, &recordingLLM{})
+
+	loader, err := config.NewLoader(t.Context(), registry, "testdata/root.yaml", "testdata/writer.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := loader.RootAgent().Name(), "pipeline"; got != want {
+		t.Errorf("RootAgent().Name() = %q, want %q", got, want)
+	}
+	if _, err := loader.LoadAgent("writer"); err != nil {
+		t.Errorf("LoadAgent(%q) error = %v", "writer", err)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			yaml:    "name: a\nmodel: fake\ninstructions: hi\n",
+			wantErr: `agent.yaml:3: unknown field "instructions"`,
+		},
+		{
+			name:    "unknown agent class",
+			yaml:    "name: a\nagent_class: FooAgent\n",
+			wantErr: `agent.yaml:2: unknown agent_class "FooAgent"`,
+		},
+		{
+			name:    "missing name",
+			yaml:    "model: fake\n",
+			wantErr: "agent.yaml:1: name is required",
+		},
+		{
+			name:    "unknown model",
+			yaml:    "name: a\nmodel: other\n",
+			wantErr: `agent.yaml:2: model "other" is not registered`,
+		},
+		{
+			name:    "unknown tool",
+			yaml:    "name: a\nmodel: fake\ntools:\n  - name: get_weather\n  - name: get_time\n",
+			wantErr: `agent.yaml:5: tool "get_time" is not registered`,
+		},
+		{
+			name:    "wrong type",
+			yaml:    "agent_class: LoopAgent\nname: a\nmax_iterations: many\n",
+			wantErr: "agent.yaml:3: cannot unmarshal !!str `many` into uint",
+		},
+		{
+			name:    "invalid inline sub-agent",
+			yaml:    "agent_class: SequentialAgent\nname: a\nsub_agents:\n  - name: b\n    model: fake\n    output: c\n",
+			wantErr: `agent.yaml:6: unknown field "output"`,
+		},
+		{
+			name:    "invalid generate content config",
+			yaml:    "name: a\nmodel: fake\ngenerate_content_config:\n  temprature: 1\n",
+			wantErr: `agent.yaml:4: json: unknown field "temprature"`,
+		},
+		{
+			name:    "invalid workflow config",
+			yaml:    "agent_class: GraphAgent\nname: a\nedges:\n  - from: b\n    to: c\n",
+			wantErr: `agent.yaml:1: failed to create GraphAgent "a"`,
+		},
+		{
+			name:    "syntax error",
+			yaml:    "name: a\n  model: fake\n",
+			wantErr: "agent.yaml:2: mapping values are not allowed in this context",
+		},
+		{
+			name:    "missing sub-agent file",
+			yaml:    "agent_class: SequentialAgent\nname: a\nsub_agents:\n  - config_path: missing.yaml\n",
+			wantErr: "agent.yaml:4: ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "agent.yaml")
+			if err := os.WriteFile(path, []byte(tt.yaml), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := config.Load(t.Context(), path, newRegistry(t, &recordingLLM{}))
+			var configErr *config.Error
+			if !errors.As(err, &configErr) {
+				t.Fatalf("Load() error = %v, want *config.Error", err)
+			}
+			if got := strings.TrimPrefix(err.Error(), filepath.Dir(path)+string(filepath.Separator)); !strings.HasPrefix(got, tt.wantErr) {
+				t.Errorf("Load() error = %q, want prefix %q", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_LoopAgent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "loop.yaml")
+	yaml := "agent_class: LoopAgent\nname: loop\nmax_iterations: 2\nemit_iteration_events: true\nsub_agents:\n  - name: writer\n    model: fake\n"
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	loop, err := config.Load(t.Context(), path, newRegistry(t, &recordingLLM{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var iterations []any
+	for _, event := range runAgent(t, loop) {
+		if i, ok := event.CustomMetadata[loopagent.MetadataKeyIteration]; ok {
+			iterations = append(iterations, i)
+		}
+	}
+	if len(iterations) != 2 {
+		t.Errorf("got iteration events %v, want 2", iterations)
+	}
+}
+
+func TestLoad_Cycle(t *testing.T) {
+	_, err := config.Load(t.Context(), "testdata/cycle.yaml", newRegistry(t, &recordingLLM{}))
+	if err == nil || !strings.Contains(err.Error(), "config file references itself") {
+		t.Errorf("Load() error = %v, want reference cycle", err)
+	}
+}
+
+func TestRegistry_Duplicates(t *testing.T) {
+	registry := newRegistry(t, &recordingLLM{})
+	if err := registry.RegisterTool(newWeatherTool(t)); err == nil {
+		t.Error("RegisterTool() succeeded for a duplicate tool, want error")
+	}
+	if err := registry.RegisterModel("fake", &recordingLLM{}); err == nil {
+		t.Error("RegisterModel() succeeded for a duplicate model, want error")
+	}
+}
+
+func newRegistry(t *testing.T, llm model.LLM) *config.Registry {
+	t.Helper()
+
+	registry := config.NewRegistry()
+	if err := registry.RegisterModel("fake", llm); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.RegisterTool(newWeatherTool(t)); err != nil {
+		t.Fatal(err)
+	}
+	return registry
+}
+
+func newWeatherTool(t *testing.T) tool.Tool {
+	t.Helper()
+
+	type args struct {
+		City string `json:"city"`
+	}
+	weatherTool, err := functiontool.New(functiontool.Config{
+		Name:        "get_weather",
+		Description: "Returns the weather in a city.",
+	}, func(tool.Context, args) (string, error) {
+		return "sunny", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return weatherTool
+}
+
+func runAgent(t *testing.T, a agent.Agent) []*session.Event {
+	t.Helper()
+	ctx := t.Context()
+
+	sessionService := session.InMemoryService()
+	agentRunner, err := runner.New(runner.Config{
+		AppName:        "test_app",
+		Agent:          a,
+		SessionService: sessionService,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = sessionService.Create(ctx, &session.CreateRequest{
+		AppName:   "test_app",
+		UserID:    "user_id",
+		SessionID: "session_id",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events []*session.Event
+	for event, err := range agentRunner.Run(ctx, "user_id", "session_id", genai.NewContentFromText("user input", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("got unexpected error: %v", err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// recordingLLM records the requests and replies with "a poem".
+type recordingLLM struct {
+	mu       sync.Mutex
+	requests []*model.LLMRequest
+}
+
+func (m *recordingLLM) Name() string {
+	return "fake"
+}
+
+func (m *recordingLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		m.mu.Lock()
+		m.requests = append(m.requests, req)
+		m.mu.Unlock()
+
+		yield(&model.LLMResponse{
+			Content: genai.NewContentFromText("a poem", genai.RoleModel),
+		}, nil)
+	}
+}
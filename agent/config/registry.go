@@ -0,0 +1,132 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// Registry holds the Go values which agent config files reference by name:
+// tools, toolsets and models.
+type Registry struct {
+	// NewModel is optional. It's called for model names which weren't
+	// registered with RegisterModel, e.g. to create Gemini models on demand.
+	// The created models are reused for all agents referencing the same name.
+	NewModel func(ctx context.Context, name string) (model.LLM, error)
+
+	mu       sync.Mutex
+	tools    map[string]tool.Tool
+	toolsets map[string]tool.Toolset
+	models   map[string]model.LLM
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		tools:    make(map[string]tool.Tool),
+		toolsets: make(map[string]tool.Toolset),
+		models:   make(map[string]model.LLM),
+	}
+}
+
+// RegisterTool makes the tool available to config files under its name.
+// Tools and toolsets share the same namespace.
+func (r *Registry) RegisterTool(t tool.Tool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkToolName(t.Name()); err != nil {
+		return err
+	}
+	r.tools[t.Name()] = t
+	return nil
+}
+
+// RegisterToolset makes the toolset available to config files under its
+// name. Tools and toolsets share the same namespace.
+func (r *Registry) RegisterToolset(ts tool.Toolset) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkToolName(ts.Name()); err != nil {
+		return err
+	}
+	r.toolsets[ts.Name()] = ts
+	return nil
+}
+
+// RegisterModel makes the model available to config files under the given
+// name.
+func (r *Registry) RegisterModel(name string, m model.LLM) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.models[name]; ok {
+		return fmt.Errorf("model %q is already registered", name)
+	}
+	r.models[name] = m
+	return nil
+}
+
+func (r *Registry) checkToolName(name string) error {
+	if name == "" {
+		return fmt.Errorf("tool name must not be empty")
+	}
+	if _, ok := r.tools[name]; ok {
+		return fmt.Errorf("tool %q is already registered", name)
+	}
+	if _, ok := r.toolsets[name]; ok {
+		return fmt.Errorf("toolset %q is already registered", name)
+	}
+	return nil
+}
+
+// lookupTool returns either the tool or the toolset registered under the
+// name.
+func (r *Registry) lookupTool(name string) (tool.Tool, tool.Toolset, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if t, ok := r.tools[name]; ok {
+		return t, nil, true
+	}
+	if ts, ok := r.toolsets[name]; ok {
+		return nil, ts, true
+	}
+	return nil, nil, false
+}
+
+func (r *Registry) model(ctx context.Context, name string) (model.LLM, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if m, ok := r.models[name]; ok {
+		return m, nil
+	}
+	if r.NewModel == nil {
+		return nil, fmt.Errorf("model %q is not registered", name)
+	}
+	m, err := r.NewModel(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create model %q: %w", name, err)
+	}
+	r.models[name] = m
+	return m, nil
+}
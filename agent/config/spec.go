@@ -0,0 +1,222 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Agent classes supported in the agent_class field.
+const (
+	ClassLLMAgent        = "LlmAgent"
+	ClassSequentialAgent = "SequentialAgent"
+	ClassParallelAgent   = "ParallelAgent"
+	ClassLoopAgent       = "LoopAgent"
+	ClassRouterAgent     = "RouterAgent"
+	ClassGraphAgent      = "GraphAgent"
+	ClassMapAgent        = "MapAgent"
+)
+
+// baseSpec holds the fields shared by all agent classes.
+type baseSpec struct {
+	AgentClass  string      `yaml:"agent_class"`
+	Name        string      `yaml:"name"`
+	Description string      `yaml:"description"`
+	SubAgents   []yaml.Node `yaml:"sub_agents"`
+}
+
+type llmAgentSpec struct {
+	Base                     baseSpec    `yaml:",inline"`
+	Model                    string      `yaml:"model"`
+	Instruction              string      `yaml:"instruction"`
+	GlobalInstruction        string      `yaml:"global_instruction"`
+	OutputKey                string      `yaml:"output_key"`
+	IncludeContents          string      `yaml:"include_contents"`
+	DisallowTransferToParent bool        `yaml:"disallow_transfer_to_parent"`
+	DisallowTransferToPeers  bool        `yaml:"disallow_transfer_to_peers"`
+	GenerateContentConfig    yaml.Node   `yaml:"generate_content_config"`
+	InputSchema              yaml.Node   `yaml:"input_schema"`
+	OutputSchema             yaml.Node   `yaml:"output_schema"`
+	Tools                    []yaml.Node `yaml:"tools"`
+}
+
+type sequentialAgentSpec struct {
+	Base baseSpec `yaml:",inline"`
+}
+
+type parallelAgentSpec struct {
+	Base           baseSpec                 `yaml:",inline"`
+	MaxConcurrency int                      `yaml:"max_concurrency"`
+	Timeout        time.Duration            `yaml:"timeout"`
+	Timeouts       map[string]time.Duration `yaml:"timeouts"`
+	ErrorPolicy    string                   `yaml:"error_policy"`
+	ErrorsKey      string                   `yaml:"errors_key"`
+	OutputKey      string                   `yaml:"output_key"`
+}
+
+type loopAgentSpec struct {
+	Base                baseSpec `yaml:",inline"`
+	MaxIterations       uint     `yaml:"max_iterations"`
+	IterationKey        string   `yaml:"iteration_key"`
+	EmitIterationEvents bool     `yaml:"emit_iteration_events"`
+}
+
+type routerAgentSpec struct {
+	Base                  baseSpec `yaml:",inline"`
+	ClassifierModel       string   `yaml:"classifier_model"`
+	ClassifierInstruction string   `yaml:"classifier_instruction"`
+	DefaultAgent          string   `yaml:"default_agent"`
+}
+
+type graphAgentSpec struct {
+	Base  baseSpec   `yaml:",inline"`
+	Edges []edgeSpec `yaml:"edges"`
+}
+
+type edgeSpec struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
+type mapAgentSpec struct {
+	Base           baseSpec `yaml:",inline"`
+	InputKey       string   `yaml:"input_key"`
+	ItemKey        string   `yaml:"item_key"`
+	ResultKey      string   `yaml:"result_key"`
+	OutputKey      string   `yaml:"output_key"`
+	MaxConcurrency int      `yaml:"max_concurrency"`
+	ErrorPolicy    string   `yaml:"error_policy"`
+	ErrorsKey      string   `yaml:"errors_key"`
+}
+
+// toolSpec references a tool or toolset from the Registry.
+type toolSpec struct {
+	Name string `yaml:"name"`
+}
+
+// subAgentRefSpec references an agent defined in another file. The path is
+// relative to the referencing file.
+type subAgentRefSpec struct {
+	ConfigPath string `yaml:"config_path"`
+}
+
+// decode decodes the mapping node into v, rejecting keys which don't match
+// any field of v.
+func decode(file string, n *yaml.Node, v any) error {
+	if n.Kind != yaml.MappingNode {
+		return errorf(file, n, "expected a mapping")
+	}
+	allowed := yamlKeys(reflect.TypeOf(v).Elem())
+	for i := 0; i < len(n.Content); i += 2 {
+		key := n.Content[i]
+		if !slices.Contains(allowed, key.Value) {
+			return errorf(file, key, "unknown field %q", key.Value)
+		}
+	}
+	if err := n.Decode(v); err != nil {
+		return yamlError(file, err)
+	}
+	return nil
+}
+
+// decodeJSON converts the node to JSON and decodes it into v. It's used for
+// genai types, which only define JSON field names.
+func decodeJSON(file string, n *yaml.Node, v any) error {
+	var raw any
+	if err := n.Decode(&raw); err != nil {
+		return yamlError(file, err)
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return errorf(file, n, "%v", err)
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	if err := d.Decode(v); err != nil {
+		return errorf(file, n, "%v", err)
+	}
+	return nil
+}
+
+// yamlKeys returns the YAML keys of the struct fields, including the fields
+// of inlined structs.
+func yamlKeys(t reflect.Type) []string {
+	var keys []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if opts == "inline" {
+			keys = append(keys, yamlKeys(f.Type)...)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		keys = append(keys, name)
+	}
+	return keys
+}
+
+// yamlError converts errors reported by the yaml package, which mention the
+// line in their message, to *Error.
+func yamlError(file string, err error) error {
+	msg := err.Error()
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		msg = typeErr.Errors[0]
+	}
+	msg = strings.TrimPrefix(msg, "yaml: ")
+	if rest, ok := strings.CutPrefix(msg, "line "); ok {
+		lineStr, text, ok := strings.Cut(rest, ": ")
+		if line, err := strconv.Atoi(lineStr); ok && err == nil {
+			return &Error{File: file, Line: line, Err: errors.New(text)}
+		}
+	}
+	return &Error{File: file, Err: errors.New(msg)}
+}
+
+// Error is returned for invalid agent config files. It points to the
+// location of the problem.
+type Error struct {
+	File string
+	// Line is 1-based. It's 0 if the location within the file is unknown.
+	Line int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %v", e.File, e.Err)
+	}
+	return fmt.Sprintf("%s:%d: %v", e.File, e.Line, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func errorf(file string, n *yaml.Node, format string, args ...any) error {
+	return &Error{File: file, Line: n.Line, Err: fmt.Errorf(format, args...)}
+}
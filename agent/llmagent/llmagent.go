@@ -17,6 +17,7 @@ package llmagent
 import (
 	"fmt"
 	"iter"
+	"slices"
 	"strings"
 
 	"google.golang.org/genai"
@@ -28,6 +29,7 @@ import (
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/session"
 	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/toolresulttool"
 )
 
 // New is a constructor for LLMAgent.
@@ -52,6 +54,11 @@ func New(cfg Config) (agent.Agent, error) {
 		afterToolCallbacks = append(afterToolCallbacks, llminternal.AfterToolCallback(c))
 	}
 
+	tools := cfg.Tools
+	if cfg.MaxToolResultSize > 0 && !slices.ContainsFunc(tools, func(t tool.Tool) bool { return t.Name() == toolresulttool.Name }) {
+		tools = append(slices.Clip(tools), toolresulttool.New())
+	}
+
 	a := &llmAgent{
 		beforeModelCallbacks: beforeModelCallbacks,
 		model:                cfg.Model,
@@ -65,7 +72,7 @@ func New(cfg Config) (agent.Agent, error) {
 		State: llminternal.State{
 			Model:                    cfg.Model,
 			GenerateContentConfig:    cfg.GenerateContentConfig,
-			Tools:                    cfg.Tools,
+			Tools:                    tools,
 			Toolsets:                 cfg.Toolsets,
 			DisallowTransferToParent: cfg.DisallowTransferToParent,
 			DisallowTransferToPeers:  cfg.DisallowTransferToPeers,
@@ -78,6 +85,9 @@ func New(cfg Config) (agent.Agent, error) {
 			GlobalInstruction:         cfg.GlobalInstruction,
 			GlobalInstructionProvider: llminternal.InstructionProvider(cfg.GlobalInstructionProvider),
 			OutputKey:                 cfg.OutputKey,
+			MaxToolResultSize:         cfg.MaxToolResultSize,
+			BeforeToolCallbacks:       beforeToolCallbacks,
+			AfterToolCallbacks:        afterToolCallbacks,
 		},
 	}
 
@@ -243,6 +253,14 @@ type Config struct {
 	// - Extracts agent reply for later use, such as in tools, callbacks, etc.
 	// - Connects agents to coordinate with each other.
 	OutputKey string
+
+	// MaxToolResultSize is the maximum size in bytes of the JSON encoding of
+	// a tool result sent to the model. Larger results are saved as artifacts
+	// and the model receives a preview and the name of the artifact, which it
+	// can read page by page with the read_tool_result tool added to the
+	// agent. Requires an artifact service, without which large results are
+	// sent in full and a warning is logged. Zero means no limit.
+	MaxToolResultSize int
 }
 
 // BeforeModelCallback that is called before sending a request to the model.
@@ -343,7 +361,7 @@ func (a *llmAgent) maybeSaveOutputToState(event *session.Event) {
 	if event == nil {
 		return
 	}
-	if event.Author != a.Name() {
+	if event.Author != a.Name() || event.Forwarded {
 		// TODO: log "Skipping output save for agent %s: event authored by %s"
 		return
 	}
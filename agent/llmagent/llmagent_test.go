@@ -29,12 +29,14 @@ import (
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
 	"google.golang.org/adk/internal/httprr"
+	"google.golang.org/adk/internal/llminternal/googlellm"
 	"google.golang.org/adk/internal/testutil"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/model/gemini"
 	"google.golang.org/adk/session"
 	"google.golang.org/adk/tool"
 	"google.golang.org/adk/tool/functiontool"
+	"google.golang.org/adk/tool/geminitool"
 )
 
 const modelName = "gemini-2.0-flash"
@@ -738,6 +740,80 @@ func TestFunctionTool(t *testing.T) {
 	}
 }
 
+func TestToolCompatibility(t *testing.T) {
+	sum, err := functiontool.New(functiontool.Config{Name: "sum", Description: "computes the sum of two numbers"},
+		func(_ tool.Context, args struct{ A, B int }) (int, error) {
+			return args.A + args.B, nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	computerUse := geminitool.New("computer_use", &genai.Tool{ComputerUse: &genai.ComputerUse{Environment: genai.EnvironmentBrowser}})
+	done := testutil.MockModel{Responses: []*genai.Content{genai.NewContentFromText("done", genai.RoleModel)}}
+
+	tests := []struct {
+		name    string
+		model   model.LLM
+		tools   []tool.Tool
+		wantErr bool
+	}{
+		{
+			name:    "gemini 2 search with functions",
+			model:   &googleModel{MockModel: done, name: "gemini-2.5-flash", variant: googlellm.GoogleLLMVariantGeminiAPI},
+			tools:   []tool.Tool{geminitool.GoogleSearch{}, sum},
+			wantErr: true,
+		},
+		{
+			name:  "gemini 2 computer use with functions",
+			model: &googleModel{MockModel: done, name: "gemini-2.5-computer-use-preview-10-2025", variant: googlellm.GoogleLLMVariantGeminiAPI},
+			tools: []tool.Tool{computerUse, sum},
+		},
+		{
+			name:    "vertex ai only tool on gemini api",
+			model:   &googleModel{MockModel: done, name: "gemini-2.5-flash", variant: googlellm.GoogleLLMVariantGeminiAPI},
+			tools:   []tool.Tool{geminitool.EnterpriseWebSearch{}},
+			wantErr: true,
+		},
+		{
+			name:  "vertex ai only tool on vertex ai",
+			model: &googleModel{MockModel: done, name: "gemini-2.5-flash", variant: googlellm.GoogleLLMVariantVertexAI},
+			tools: []tool.Tool{geminitool.EnterpriseWebSearch{}},
+		},
+		{
+			name:  "other model",
+			model: &done,
+			tools: []tool.Tool{geminitool.EnterpriseWebSearch{}, sum},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := llmagent.New(llmagent.Config{Name: "agent", Model: tt.model, Tools: tt.tools})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = testutil.CollectEvents(testutil.NewTestAgentRunner(t, a).Run(t, "session", "hi"))
+			if gotErr := err != nil && strings.Contains(err.Error(), "incompatible tools"); gotErr != tt.wantErr {
+				t.Errorf("run error = %v, want incompatible tools error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// googleModel is a mock of a Google LLM using the given variant.
+type googleModel struct {
+	testutil.MockModel
+	name    string
+	variant string
+}
+
+func (m *googleModel) Name() string {
+	return m.name
+}
+
+func (m *googleModel) GetGoogleLLMVariant() string {
+	return m.variant
+}
+
 func TestAgentTransfer(t *testing.T) {
 	// Helpers to create genai.Content conveniently.
 	transferCall := func(agentName string) *genai.Content {
@@ -67,10 +67,17 @@ func (s *singleLoader) RootAgent() Agent {
 
 // NewMultiLoader returns a new AgentLoader with the given root Agent and other agents.
 // Returns an error if more than one agent (including root) shares the same name
+// or if any of the agent trees is invalid, see ValidateTree.
 func NewMultiLoader(root Agent, agents ...Agent) (Loader, error) {
+	if err := ValidateTree(root); err != nil {
+		return nil, fmt.Errorf("invalid agent tree: %w", err)
+	}
 	m := make(map[string]Agent)
 	m[root.Name()] = root
 	for _, a := range agents {
+		if err := ValidateTree(a); err != nil {
+			return nil, fmt.Errorf("invalid agent tree: %w", err)
+		}
 		if _, ok := m[a.Name()]; ok {
 			// duplicate name
 			return nil, fmt.Errorf("duplicate agent name: %s", a.Name())
@@ -40,7 +40,7 @@ func (a *testAgent) Run(InvocationContext) iter.Seq2[*session.Event, error] {
 }
 
 func (a *testAgent) SubAgents() []Agent {
-	panic("not implemented")
+	return nil
 }
 
 func (a *testAgent) internal() *agent {
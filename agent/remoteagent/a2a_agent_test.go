@@ -111,6 +111,7 @@ func toLLMResponses(events []*session.Event) []model.LLMResponse {
 func newADKEventReplay(t *testing.T, events []*session.Event) a2asrv.AgentExecutor {
 	t.Helper()
 	agnt, err := agent.New(agent.Config{
+		Name: "replay",
 		Run: func(ic agent.InvocationContext) iter.Seq2[*session.Event, error] {
 			return func(yield func(*session.Event, error) bool) {
 				for _, ev := range events {
@@ -0,0 +1,405 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteagent
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/session"
+)
+
+// RESTSessionMapper returns the user and session IDs of the remote session used
+// for the invocation.
+type RESTSessionMapper func(ctx agent.ReadonlyContext) (userID, sessionID string)
+
+// RESTConfig is used to describe and configure a remote agent served by an ADK
+// REST API server.
+type RESTConfig struct {
+	Name        string
+	Description string
+
+	// BaseURL is the URL the ADK REST API is served at, e.g.
+	// "http://localhost:8080/api".
+	BaseURL string
+	// AppName is the name of the remote app to run.
+	AppName string
+	// HTTPClient is used for requests to the remote server. Defaults to
+	// http.DefaultClient. Use a custom client to add authentication.
+	HTTPClient *http.Client
+
+	// SessionMapper maps the local session to a remote session. If the remote
+	// session does not exist, it is created. By default, the remote session
+	// has the same user and session IDs as the local session.
+	SessionMapper RESTSessionMapper
+
+	// SendState sends the local session state to the remote session before
+	// each run. Only the keys whose values differ from the remote state are
+	// sent. Keys with the "temp:" prefix are never sent.
+	SendState bool
+	// ReceiveState applies the state deltas of remote events to the local
+	// session. Otherwise, state deltas of remote events are dropped.
+	ReceiveState bool
+
+	// BeforeAgentCallbacks is a list of callbacks that are called sequentially
+	// before the agent starts its run.
+	//
+	// If any callback returns non-nil content or error, then the agent run and
+	// the remaining callbacks will be skipped, and a new event will be created
+	// from the content or error of that callback.
+	BeforeAgentCallbacks []agent.BeforeAgentCallback
+	// AfterAgentCallbacks is a list of callbacks that are called sequentially
+	// after the agent has completed its run.
+	//
+	// If any callback returns non-nil content or error, then a new event will be
+	// created from the content or error of that callback and the remaining
+	// callbacks will be skipped.
+	AfterAgentCallbacks []agent.AfterAgentCallback
+}
+
+// NewREST creates a remote agent which runs an app on an ADK REST API server
+// using the /run_sse endpoint.
+//
+// The events of the remote app are streamed back as events authored by the
+// remote agent on the branch of the invocation. Artifact deltas of remote
+// events are dropped, as they refer to the artifacts of the remote server.
+func NewREST(cfg RESTConfig) (agent.Agent, error) {
+	if cfg.BaseURL == "" {
+		return nil, fmt.Errorf("BaseURL must be provided")
+	}
+	if cfg.AppName == "" {
+		return nil, fmt.Errorf("AppName must be provided")
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	if cfg.SessionMapper == nil {
+		cfg.SessionMapper = func(ctx agent.ReadonlyContext) (string, string) {
+			return ctx.UserID(), ctx.SessionID()
+		}
+	}
+
+	remoteAgent := &restAgent{cfg: cfg, baseURL: strings.TrimSuffix(cfg.BaseURL, "/")}
+	return agent.New(agent.Config{
+		Name:                 cfg.Name,
+		Description:          cfg.Description,
+		BeforeAgentCallbacks: cfg.BeforeAgentCallbacks,
+		AfterAgentCallbacks:  cfg.AfterAgentCallbacks,
+		Run:                  remoteAgent.run,
+	})
+}
+
+type restAgent struct {
+	cfg     RESTConfig
+	baseURL string
+}
+
+// restSession mirrors the session model of the ADK REST API.
+type restSession struct {
+	ID    string         `json:"id"`
+	State map[string]any `json:"state"`
+}
+
+// restRunRequest mirrors the run request model of the ADK REST API.
+type restRunRequest struct {
+	AppName    string          `json:"appName"`
+	UserID     string          `json:"userId"`
+	SessionID  string          `json:"sessionId"`
+	NewMessage *genai.Content  `json:"newMessage"`
+	Streaming  bool            `json:"streaming,omitempty"`
+	StateDelta *map[string]any `json:"stateDelta,omitempty"`
+}
+
+// restEvent mirrors the event model of the ADK REST API.
+type restEvent struct {
+	Branch             string                   `json:"branch"`
+	Author             string                   `json:"author"`
+	Partial            bool                     `json:"partial"`
+	LongRunningToolIDs []string                 `json:"longRunningToolIds"`
+	Content            *genai.Content           `json:"content"`
+	GroundingMetadata  *genai.GroundingMetadata `json:"groundingMetadata"`
+	TurnComplete       bool                     `json:"turnComplete"`
+	Interrupted        bool                     `json:"interrupted"`
+	ErrorCode          string                   `json:"errorCode"`
+	ErrorMessage       string                   `json:"errorMessage"`
+	Actions            struct {
+		StateDelta map[string]any `json:"stateDelta"`
+	} `json:"actions"`
+}
+
+// sseErrorPrefix starts the lines the ADK REST API writes to the /run_sse
+// stream when the agent fails.
+const sseErrorPrefix = "Error while running agent: "
+
+func (a *restAgent) run(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+	return func(yield func(*session.Event, error) bool) {
+		userID, sessionID := a.cfg.SessionMapper(icontext.NewReadonlyContext(ctx))
+
+		remoteSession, created, err := a.getOrCreateSession(ctx, userID, sessionID)
+		if err != nil {
+			yield(toRESTErrorEvent(ctx, fmt.Errorf("remote session setup failed: %w", err)), nil)
+			return
+		}
+
+		req := &restRunRequest{
+			AppName:    a.cfg.AppName,
+			UserID:     userID,
+			SessionID:  remoteSession.ID,
+			NewMessage: toMissingRemoteSessionContent(ctx, created),
+			Streaming:  ctx.RunConfig() != nil && ctx.RunConfig().StreamingMode == agent.StreamingModeSSE,
+		}
+		if len(req.NewMessage.Parts) == 0 {
+			yield(toRESTEvent(ctx), nil)
+			return
+		}
+		if a.cfg.SendState && !created {
+			delta, err := stateDelta(ctx.Session().State(), remoteSession.State)
+			if err != nil {
+				yield(toRESTErrorEvent(ctx, err), nil)
+				return
+			}
+			if len(delta) > 0 {
+				req.StateDelta = &delta
+			}
+		}
+
+		resp, err := a.post(ctx, "/run_sse", req)
+		if err != nil {
+			yield(toRESTErrorEvent(ctx, fmt.Errorf("remote run failed: %w", err)), nil)
+			return
+		}
+		defer resp.Body.Close()
+
+		for event, err := range a.readEvents(ctx, resp.Body) {
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+// getOrCreateSession returns the remote session and whether it was created
+// by this call. The session is only created if the server reports it's not
+// found. New sessions start with the local state if SendState is set.
+func (a *restAgent) getOrCreateSession(ctx agent.InvocationContext, userID, sessionID string) (*restSession, bool, error) {
+	path := fmt.Sprintf("/apps/%s/users/%s/sessions/%s", url.PathEscape(a.cfg.AppName), url.PathEscape(userID), url.PathEscape(sessionID))
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+path, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	resp, err := a.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var result restSession
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return nil, false, fmt.Errorf("failed to decode remote session: %w", err)
+		}
+		return &result, false, nil
+	case http.StatusNotFound:
+	default:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, false, fmt.Errorf("failed to get remote session: unexpected status %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	createReq := map[string]any{}
+	if a.cfg.SendState {
+		state, err := stateDelta(ctx.Session().State(), nil)
+		if err != nil {
+			return nil, false, err
+		}
+		createReq["state"] = state
+	}
+	createResp, err := a.post(ctx, path, createReq)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create remote session: %w", err)
+	}
+	defer createResp.Body.Close()
+	var result restSession
+	if err := json.NewDecoder(createResp.Body).Decode(&result); err != nil {
+		return nil, false, fmt.Errorf("failed to decode remote session: %w", err)
+	}
+	return &result, true, nil
+}
+
+// post sends body as JSON to the path and returns the response if its status
+// is 200 OK.
+func (a *restAgent) post(ctx context.Context, path string, body any) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := a.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+// readEvents converts the /run_sse stream to session events.
+func (a *restAgent) readEvents(ctx agent.InvocationContext, body io.Reader) iter.Seq2[*session.Event, error] {
+	return func(yield func(*session.Event, error) bool) {
+		scanner := bufio.NewScanner(body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			var event *session.Event
+			switch {
+			case strings.HasPrefix(line, "data: "):
+				var remoteEvent restEvent
+				if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &remoteEvent); err != nil {
+					yield(toRESTErrorEvent(ctx, fmt.Errorf("failed to decode remote event: %w", err)), nil)
+					return
+				}
+				event = a.toSessionEvent(ctx, &remoteEvent)
+			case strings.HasPrefix(line, sseErrorPrefix):
+				event = toRESTErrorEvent(ctx, fmt.Errorf("remote agent failed: %s", strings.TrimPrefix(line, sseErrorPrefix)))
+			default:
+				continue
+			}
+			if !yield(event, nil) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(toRESTErrorEvent(ctx, fmt.Errorf("failed to read remote events: %w", err)), nil)
+		}
+	}
+}
+
+func (a *restAgent) toSessionEvent(ctx agent.InvocationContext, remoteEvent *restEvent) *session.Event {
+	event := toRESTEvent(ctx)
+	event.LongRunningToolIDs = remoteEvent.LongRunningToolIDs
+	event.Content = remoteEvent.Content
+	event.GroundingMetadata = remoteEvent.GroundingMetadata
+	event.Partial = remoteEvent.Partial
+	event.TurnComplete = remoteEvent.TurnComplete
+	event.Interrupted = remoteEvent.Interrupted
+	event.ErrorCode = remoteEvent.ErrorCode
+	event.ErrorMessage = remoteEvent.ErrorMessage
+	if a.cfg.ReceiveState {
+		for k, v := range remoteEvent.Actions.StateDelta {
+			if !strings.HasPrefix(k, session.KeyPrefixTemp) {
+				event.Actions.StateDelta[k] = v
+			}
+		}
+	}
+	return event
+}
+
+// stateDelta returns the entries of the local state, except for temporary
+// ones, whose JSON representation differs from the remote state.
+func stateDelta(local session.State, remote map[string]any) (map[string]any, error) {
+	delta := make(map[string]any)
+	for k, v := range local.All() {
+		if strings.HasPrefix(k, session.KeyPrefixTemp) {
+			continue
+		}
+		value, err := toJSONValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode state key %q: %w", k, err)
+		}
+		if remoteValue, ok := remote[k]; ok && reflect.DeepEqual(value, remoteValue) {
+			continue
+		}
+		delta[k] = value
+	}
+	return delta, nil
+}
+
+func toJSONValue(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var result any
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// toMissingRemoteSessionContent returns the content of the session events the
+// remote session hasn't seen, i.e. the events after the last event of this
+// agent, or all events if the remote session was just created. Events of other
+// agents are presented as user messages.
+func toMissingRemoteSessionContent(ctx agent.InvocationContext, all bool) *genai.Content {
+	events := ctx.Session().Events()
+	start := 0
+	if !all {
+		for i := events.Len() - 1; i >= 0; i-- {
+			if events.At(i).Author == ctx.Agent().Name() {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	var parts []*genai.Part
+	for i := start; i < events.Len(); i++ {
+		event := events.At(i)
+		if event.Author != "user" {
+			if event.Author == ctx.Agent().Name() {
+				continue
+			}
+			event = presentAsUserMessage(ctx, event)
+		}
+		if event.Content == nil {
+			continue
+		}
+		parts = append(parts, event.Content.Parts...)
+	}
+	return genai.NewContentFromParts(parts, genai.RoleUser)
+}
+
+func toRESTEvent(ctx agent.InvocationContext) *session.Event {
+	event := session.NewEvent(ctx.InvocationID())
+	event.Author = ctx.Agent().Name()
+	event.Branch = ctx.Branch()
+	return event
+}
+
+func toRESTErrorEvent(ctx agent.InvocationContext, err error) *session.Event {
+	event := toRESTEvent(ctx)
+	event.ErrorMessage = err.Error()
+	return event
+}
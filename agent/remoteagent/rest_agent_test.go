@@ -0,0 +1,271 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteagent
+
+import (
+	"fmt"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/cmd/launcher"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/server/adkrest"
+	"google.golang.org/adk/session"
+)
+
+// restEventSummary holds the fields of an event relevant for the tests.
+type restEventSummary struct {
+	Author       string
+	Text         string
+	ErrorMessage string
+}
+
+// forecaster is the agent served by the remote server. It replies with the
+// forecast for the city in its session state and records its inputs.
+type forecaster struct {
+	inputs []string
+}
+
+func (f *forecaster) run(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+	return func(yield func(*session.Event, error) bool) {
+		var texts []string
+		for _, part := range ctx.UserContent().Parts {
+			texts = append(texts, part.Text)
+		}
+		f.inputs = append(f.inputs, strings.Join(texts, "|"))
+
+		city, err := ctx.Session().State().Get("city")
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		event := session.NewEvent(ctx.InvocationID())
+		event.Author = ctx.Agent().Name()
+		event.Content = genai.NewContentFromText(fmt.Sprintf("sunny in %v", city), genai.RoleModel)
+		event.Actions.StateDelta = map[string]any{"forecast": "sunny", "temp:scratch": 1}
+		yield(event, nil)
+	}
+}
+
+func startRESTServer(t *testing.T) (*httptest.Server, *forecaster, session.Service) {
+	t.Helper()
+
+	f := &forecaster{}
+	remoteAgent, err := agent.New(agent.Config{Name: "forecaster", Run: f.run})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessionService := session.InMemoryService()
+	server := httptest.NewServer(adkrest.NewHandler(&launcher.Config{
+		SessionService: sessionService,
+		AgentLoader:    agent.NewSingleLoader(remoteAgent),
+	}, time.Minute))
+	t.Cleanup(server.Close)
+	return server, f, sessionService
+}
+
+func runREST(t *testing.T, r *runner.Runner, msg string) []restEventSummary {
+	t.Helper()
+
+	var got []restEventSummary
+	for event, err := range r.Run(t.Context(), "user_id", "session_id", genai.NewContentFromText(msg, genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("got unexpected error: %v", err)
+		}
+		s := restEventSummary{Author: event.Author, ErrorMessage: event.ErrorMessage}
+		if event.Content != nil {
+			s.Text = event.Content.Parts[0].Text
+		}
+		got = append(got, s)
+	}
+	return got
+}
+
+func newRESTRunner(t *testing.T, cfg RESTConfig) (*runner.Runner, session.Service) {
+	t.Helper()
+
+	restAgent, err := NewREST(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessionService := session.InMemoryService()
+	r, err := runner.New(runner.Config{AppName: "local_app", Agent: restAgent, SessionService: sessionService})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = sessionService.Create(t.Context(), &session.CreateRequest{
+		AppName:   "local_app",
+		UserID:    "user_id",
+		SessionID: "session_id",
+		State:     map[string]any{"city": "Paris"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, sessionService
+}
+
+func getLocalState(t *testing.T, sessionService session.Service, key string) any {
+	t.Helper()
+
+	resp, err := sessionService.Get(t.Context(), &session.GetRequest{AppName: "local_app", UserID: "user_id", SessionID: "session_id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := resp.Session.State().Get(key)
+	if err != nil {
+		return nil
+	}
+	return value
+}
+
+func TestRESTAgent_SyncState(t *testing.T) {
+	server, remote, remoteSessions := startRESTServer(t)
+	r, localSessions := newRESTRunner(t, RESTConfig{
+		Name:         "weather",
+		BaseURL:      server.URL,
+		AppName:      "forecaster",
+		SendState:    true,
+		ReceiveState: true,
+	})
+
+	got := runREST(t, r, "hi")
+	want := []restEventSummary{{Author: "weather", Text: "sunny in Paris"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("first run events mismatch (-want +got):\n%s", diff)
+	}
+	if got := getLocalState(t, localSessions, "forecast"); got != "sunny" {
+		t.Errorf("local state forecast = %v, want sunny", got)
+	}
+	if got := getLocalState(t, localSessions, "temp:scratch"); got != nil {
+		t.Errorf("local state temp:scratch = %v, want unset", got)
+	}
+
+	// Change the local state, the remote session must see the change.
+	resp, err := localSessions.Get(t.Context(), &session.GetRequest{AppName: "local_app", UserID: "user_id", SessionID: "session_id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateEvent := session.NewEvent("")
+	stateEvent.Author = "user"
+	stateEvent.Actions.StateDelta = map[string]any{"city": "Rome"}
+	if err := localSessions.AppendEvent(t.Context(), resp.Session, stateEvent); err != nil {
+		t.Fatal(err)
+	}
+
+	got = runREST(t, r, "and now?")
+	want = []restEventSummary{{Author: "weather", Text: "sunny in Rome"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("second run events mismatch (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"hi", "and now?"}, remote.inputs); diff != "" {
+		t.Errorf("remote inputs mismatch (-want +got):\n%s", diff)
+	}
+	remoteSession, err := remoteSessions.Get(t.Context(), &session.GetRequest{AppName: "forecaster", UserID: "user_id", SessionID: "session_id"})
+	if err != nil {
+		t.Fatalf("remote session was not created with the local session ID: %v", err)
+	}
+	if got, _ := remoteSession.Session.State().Get("city"); got != "Rome" {
+		t.Errorf("remote state city = %v, want Rome", got)
+	}
+}
+
+func TestRESTAgent_NoStateSync(t *testing.T) {
+	server, _, remoteSessions := startRESTServer(t)
+	_, err := remoteSessions.Create(t.Context(), &session.CreateRequest{
+		AppName:   "forecaster",
+		UserID:    "remote_user",
+		SessionID: "remote_session",
+		State:     map[string]any{"city": "Oslo"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, localSessions := newRESTRunner(t, RESTConfig{
+		Name:    "weather",
+		BaseURL: server.URL,
+		AppName: "forecaster",
+		SessionMapper: func(agent.ReadonlyContext) (string, string) {
+			return "remote_user", "remote_session"
+		},
+	})
+
+	got := runREST(t, r, "hi")
+	want := []restEventSummary{{Author: "weather", Text: "sunny in Oslo"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("events mismatch (-want +got):\n%s", diff)
+	}
+	if got := getLocalState(t, localSessions, "forecast"); got != nil {
+		t.Errorf("local state forecast = %v, want unset", got)
+	}
+}
+
+func TestRESTAgent_RemoteError(t *testing.T) {
+	server, _, _ := startRESTServer(t)
+	r, _ := newRESTRunner(t, RESTConfig{
+		Name:    "weather",
+		BaseURL: server.URL,
+		AppName: "forecaster",
+	})
+
+	// The remote agent fails as the session has no city.
+	got := runREST(t, r, "hi")
+	if len(got) != 1 || got[0].Author != "weather" || !strings.Contains(got[0].ErrorMessage, "remote agent failed") {
+		t.Errorf("got events %+v, want a single error event", got)
+	}
+}
+
+func TestRESTAgent_SessionLookupFails(t *testing.T) {
+	var posts int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPost {
+			posts++
+		}
+		http.Error(rw, "database unavailable", http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+	r, _ := newRESTRunner(t, RESTConfig{
+		Name:    "weather",
+		BaseURL: server.URL,
+		AppName: "forecaster",
+	})
+
+	// Only missing sessions are created, other failures are reported.
+	got := runREST(t, r, "hi")
+	if len(got) != 1 || !strings.Contains(got[0].ErrorMessage, "database unavailable") {
+		t.Errorf("got events %+v, want a single error event", got)
+	}
+	if posts != 0 {
+		t.Errorf("got %d POST requests, want none", posts)
+	}
+}
+
+func TestNewREST_Validation(t *testing.T) {
+	if _, err := NewREST(RESTConfig{Name: "weather", AppName: "forecaster"}); err == nil {
+		t.Error("NewREST() succeeded without BaseURL, want error")
+	}
+	if _, err := NewREST(RESTConfig{Name: "weather", BaseURL: "http://localhost"}); err == nil {
+		t.Error("NewREST() succeeded without AppName, want error")
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	agentinternal "google.golang.org/adk/internal/agent"
+)
+
+// ReservedName is the author name of events with the end-user's input. No
+// agent can use it.
+const ReservedName = "user"
+
+// transferTargetNameRegex matches the names which are valid in function
+// calls, as LLM agents transfer to other agents by name.
+var transferTargetNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.-]{0,63}$`)
+
+// ValidateTree validates the agent tree rooted at root. It checks that:
+//   - agent names are non-empty, not reserved and unique across the tree,
+//   - an agent instance has at most one parent,
+//   - agents an LLM agent can transfer to, i.e. its sub-agents, parent and
+//     peers, have names usable in function calls,
+//   - the tools of an agent have unique names.
+//
+// All problems are reported together, each naming the path of the agent in
+// the tree, e.g. "root/planner/search".
+//
+// ValidateTree is called by runner.New and NewMultiLoader.
+func ValidateTree(root Agent) error {
+	v := &treeValidator{
+		paths:     make(map[string]string),
+		instances: make(map[Agent]string),
+	}
+	v.visit(root, nil, "")
+	return errors.Join(v.errs...)
+}
+
+type treeValidator struct {
+	// paths maps agent names to the path where they were first seen.
+	paths map[string]string
+	// instances maps agents to the path where they were first seen.
+	instances map[Agent]string
+	errs      []error
+}
+
+func (v *treeValidator) visit(a, parent Agent, parentPath string) {
+	path := a.Name()
+	if parentPath != "" {
+		path = parentPath + "/" + a.Name()
+	}
+
+	if other, ok := v.instances[a]; ok {
+		v.errorf(path, "agent instance is already used at %q, an agent can have at most one parent", other)
+		return
+	}
+	v.instances[a] = path
+
+	switch name := a.Name(); {
+	case name == "":
+		v.errorf(path, "agent name must not be empty")
+	case name == ReservedName:
+		v.errorf(path, "agent name %q is reserved for the end-user's input", ReservedName)
+	default:
+		if other, ok := v.paths[name]; ok {
+			v.errorf(path, "agent name %q is already used at %q, agent names must be unique in the agent tree", name, other)
+		} else {
+			v.paths[name] = path
+		}
+	}
+
+	if isTransferTarget(a, parent) && a.Name() != "" && !transferTargetNameRegex.MatchString(a.Name()) {
+		v.errorf(path, "agent name %q is not a valid identifier, as required for agents an LLM agent can transfer to", a.Name())
+	}
+
+	if owner, ok := a.(agentinternal.ToolOwner); ok {
+		seen := make(map[string]bool)
+		for _, name := range owner.ToolNames() {
+			if seen[name] {
+				v.errorf(path, "tool name %q is used by more than one tool", name)
+			}
+			seen[name] = true
+		}
+	}
+
+	for _, sub := range a.SubAgents() {
+		v.visit(sub, a, path)
+	}
+}
+
+func (v *treeValidator) errorf(path, format string, args ...any) {
+	v.errs = append(v.errs, fmt.Errorf("agent %q: %s", path, fmt.Sprintf(format, args...)))
+}
+
+// isTransferTarget reports whether an LLM agent can transfer to the agent,
+// i.e. whether the agent has an LLM agent as its parent, sibling or sub-agent.
+func isTransferTarget(a, parent Agent) bool {
+	if parent != nil {
+		if isLLMAgent(parent) {
+			return true
+		}
+		for _, sibling := range parent.SubAgents() {
+			if sibling != a && isLLMAgent(sibling) {
+				return true
+			}
+		}
+	}
+	for _, sub := range a.SubAgents() {
+		if isLLMAgent(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+func isLLMAgent(a Agent) bool {
+	internalAgent, ok := a.(agentinternal.Agent)
+	return ok && agentinternal.Reveal(internalAgent).AgentType == agentinternal.TypeLLMAgent
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent_test
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestValidateTree(t *testing.T) {
+	shared := newCustomAgent(t, "shared")
+
+	tests := []struct {
+		name     string
+		root     agent.Agent
+		wantErrs []string
+	}{
+		{
+			name: "valid tree",
+			root: newLLMAgent(t, "root", nil, newLLMAgent(t, "planner", nil), newCustomAgent(t, "worker")),
+		},
+		{
+			name: "names with spaces outside of LLM transfers",
+			root: newCustomAgent(t, "Root Agent", newCustomAgent(t, "Inner 1")),
+		},
+		{
+			name:     "empty name",
+			root:     newCustomAgent(t, "root", newCustomAgent(t, "")),
+			wantErrs: []string{`agent "root/": agent name must not be empty`},
+		},
+		{
+			name:     "reserved name",
+			root:     newCustomAgent(t, "root", newCustomAgent(t, "a", newCustomAgent(t, "user"))),
+			wantErrs: []string{`agent "root/a/user": agent name "user" is reserved`},
+		},
+		{
+			name:     "duplicate names",
+			root:     newCustomAgent(t, "root", newCustomAgent(t, "a", newCustomAgent(t, "x")), newCustomAgent(t, "b", newCustomAgent(t, "x"))),
+			wantErrs: []string{`agent "root/b/x": agent name "x" is already used at "root/a/x"`},
+		},
+		{
+			name:     "agent with two parents",
+			root:     newCustomAgent(t, "root", newCustomAgent(t, "a", shared), newCustomAgent(t, "b", shared)),
+			wantErrs: []string{`agent "root/b/shared": agent instance is already used at "root/a/shared"`},
+		},
+		{
+			name: "invalid transfer target names",
+			root: newLLMAgent(t, "root", nil, newCustomAgent(t, "Inner 1"), newCustomAgent(t, "Inner 2")),
+			wantErrs: []string{
+				`agent "root/Inner 1": agent name "Inner 1" is not a valid identifier`,
+				`agent "root/Inner 2": agent name "Inner 2" is not a valid identifier`,
+			},
+		},
+		{
+			name:     "invalid name of LLM agent's parent",
+			root:     newCustomAgent(t, "Root Agent", newLLMAgent(t, "assistant", nil)),
+			wantErrs: []string{`agent "Root Agent": agent name "Root Agent" is not a valid identifier`},
+		},
+		{
+			name: "tool name collision",
+			root: newCustomAgent(t, "root", newLLMAgent(t, "assistant", []tool.Tool{
+				newTool(t, "get_weather"), newTool(t, "get_time"), newTool(t, "get_weather"),
+			})),
+			wantErrs: []string{`agent "root/assistant": tool name "get_weather" is used by more than one tool`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := agent.ValidateTree(tt.root)
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("ValidateTree() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("ValidateTree() succeeded, want errors %v", tt.wantErrs)
+			}
+			gotErrs := strings.Split(err.Error(), "\n")
+			if len(gotErrs) != len(tt.wantErrs) {
+				t.Fatalf("ValidateTree() error = %v, want %d errors", err, len(tt.wantErrs))
+			}
+			for i, want := range tt.wantErrs {
+				if !strings.HasPrefix(gotErrs[i], want) {
+					t.Errorf("error[%d] = %q, want prefix %q", i, gotErrs[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewMultiLoader_InvalidTree(t *testing.T) {
+	root := newCustomAgent(t, "root", newCustomAgent(t, "user"))
+	if _, err := agent.NewMultiLoader(root); err == nil {
+		t.Error("NewMultiLoader() succeeded, want error")
+	}
+}
+
+func newCustomAgent(t *testing.T, name string, subAgents ...agent.Agent) agent.Agent {
+	t.Helper()
+
+	a, err := agent.New(agent.Config{Name: name, SubAgents: subAgents})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func newLLMAgent(t *testing.T, name string, tools []tool.Tool, subAgents ...agent.Agent) agent.Agent {
+	t.Helper()
+
+	a, err := llmagent.New(llmagent.Config{Name: name, Tools: tools, SubAgents: subAgents})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func newTool(t *testing.T, name string) tool.Tool {
+	t.Helper()
+
+	type args struct{}
+	result, err := functiontool.New(functiontool.Config{Name: name}, func(tool.Context, args) (string, error) {
+		return "", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
@@ -0,0 +1,315 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package graphagent provides an agent that runs its sub-agents as a directed
+// acyclic graph of dependencies.
+package graphagent
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+
+	"google.golang.org/adk/agent"
+	agentinternal "google.golang.org/adk/internal/agent"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/session"
+)
+
+// Condition decides whether an Edge is taken once its source node completes.
+// It has access to the session state, including the outputs of the nodes
+// which completed so far.
+type Condition func(ctx agent.ReadonlyContext) (bool, error)
+
+// Edge declares that the sub-agent named To depends on the sub-agent named
+// From.
+type Edge struct {
+	From string
+	To   string
+	// Condition is optional. If set, it's evaluated after From completes and
+	// the edge is only taken if it returns true.
+	Condition Condition
+}
+
+// Config defines the configuration for a GraphAgent.
+type Config struct {
+	// Basic agent setup. Every sub-agent is a node of the graph.
+	AgentConfig agent.Config
+
+	// Edges declare dependencies between sub-agents, referenced by name.
+	// Sub-agents without incoming edges start immediately.
+	Edges []Edge
+}
+
+// New creates a GraphAgent.
+//
+// GraphAgent runs its sub-agents as nodes of a directed acyclic graph. A node
+// starts once all of its upstream nodes have settled, i.e. either completed
+// or were skipped, and at least one of its incoming edges was taken. A node
+// whose incoming edges were all rejected by their conditions, or whose
+// upstream nodes were all skipped, is skipped as well.
+//
+// Independent nodes run concurrently. Like with ParallelAgent, each node runs
+// in its own branch, so nodes don't see each other's conversation history.
+// Use session state, e.g. llmagent.Config.OutputKey, to pass results between
+// nodes.
+//
+// The graph is validated for unknown nodes and cycles when it's created. If
+// any node fails, the remaining nodes are cancelled.
+func New(cfg Config) (agent.Agent, error) {
+	if cfg.AgentConfig.Run != nil {
+		return nil, fmt.Errorf("GraphAgent doesn't allow custom Run implementations")
+	}
+
+	g, err := newGraph(cfg.AgentConfig.SubAgents, cfg.Edges)
+	if err != nil {
+		return nil, err
+	}
+	cfg.AgentConfig.Run = g.run
+
+	graphAgent, err := agent.New(cfg.AgentConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create base agent: %w", err)
+	}
+
+	internalAgent, ok := graphAgent.(agentinternal.Agent)
+	if !ok {
+		return nil, fmt.Errorf("internal error: failed to convert to internal agent")
+	}
+	state := agentinternal.Reveal(internalAgent)
+	state.AgentType = agentinternal.TypeGraphAgent
+	state.Config = cfg
+
+	return graphAgent, nil
+}
+
+type graph struct {
+	nodes    []agent.Agent
+	incoming map[string][]Edge
+	outgoing map[string][]Edge
+}
+
+func newGraph(nodes []agent.Agent, edges []Edge) (*graph, error) {
+	g := &graph{
+		nodes:    nodes,
+		incoming: make(map[string][]Edge),
+		outgoing: make(map[string][]Edge),
+	}
+
+	names := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		names[n.Name()] = true
+	}
+	seen := make(map[[2]string]bool, len(edges))
+	for _, e := range edges {
+		if !names[e.From] {
+			return nil, fmt.Errorf("edge %q -> %q: %q is not a sub-agent", e.From, e.To, e.From)
+		}
+		if !names[e.To] {
+			return nil, fmt.Errorf("edge %q -> %q: %q is not a sub-agent", e.From, e.To, e.To)
+		}
+		if seen[[2]string{e.From, e.To}] {
+			return nil, fmt.Errorf("edge %q -> %q is declared more than once", e.From, e.To)
+		}
+		seen[[2]string{e.From, e.To}] = true
+		g.incoming[e.To] = append(g.incoming[e.To], e)
+		g.outgoing[e.From] = append(g.outgoing[e.From], e)
+	}
+
+	if cycle := g.findCycle(); cycle != nil {
+		return nil, fmt.Errorf("graph contains a cycle: %s", strings.Join(cycle, " -> "))
+	}
+	return g, nil
+}
+
+// findCycle returns the node names forming a cycle, or nil if the graph is
+// acyclic.
+func (g *graph) findCycle() []string {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	status := make(map[string]int, len(g.nodes))
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		status[name] = inProgress
+		path = append(path, name)
+		for _, e := range g.outgoing[name] {
+			switch status[e.To] {
+			case inProgress:
+				for i, n := range path {
+					if n == e.To {
+						return append(path[i:], e.To)
+					}
+				}
+			case unvisited:
+				if cycle := visit(e.To); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		status[name] = done
+		return nil
+	}
+
+	for _, n := range g.nodes {
+		if status[n.Name()] == unvisited {
+			if cycle := visit(n.Name()); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+func (g *graph) run(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+	return func(yield func(*session.Event, error) bool) {
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			resultsChan = make(chan result)
+			doneChan    = make(chan bool)
+			running     = 0
+			// unsettled counts upstream nodes which haven't completed or
+			// been skipped yet.
+			unsettled = make(map[string]int, len(g.nodes))
+			// taken counts incoming edges which were taken.
+			taken = make(map[string]int, len(g.nodes))
+		)
+		defer close(doneChan)
+
+		start := func(node agent.Agent) {
+			running++
+			subCtx := icontext.NewInvocationContext(runCtx, icontext.InvocationContextParams{
+				Artifacts:   ctx.Artifacts(),
+				Memory:      ctx.Memory(),
+				Session:     ctx.Session(),
+				Branch:      nodeBranch(ctx, node),
+				Agent:       node,
+				UserContent: ctx.UserContent(),
+				RunConfig:   ctx.RunConfig(),
+			})
+			go runNode(subCtx, node, resultsChan, doneChan)
+		}
+
+		var settle func(name string, completed bool) error
+		settle = func(name string, completed bool) error {
+			for _, e := range g.outgoing[name] {
+				if completed {
+					ok := true
+					if e.Condition != nil {
+						var err error
+						ok, err = e.Condition(icontext.NewReadonlyContext(ctx))
+						if err != nil {
+							return fmt.Errorf("failed to evaluate condition of edge %q -> %q: %w", e.From, e.To, err)
+						}
+					}
+					if ok {
+						taken[e.To]++
+					}
+				}
+				unsettled[e.To]--
+				if unsettled[e.To] > 0 {
+					continue
+				}
+				if taken[e.To] > 0 {
+					start(g.node(e.To))
+				} else if err := settle(e.To, false); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		for _, n := range g.nodes {
+			unsettled[n.Name()] = len(g.incoming[n.Name()])
+		}
+		for _, n := range g.nodes {
+			if unsettled[n.Name()] == 0 {
+				start(n)
+			}
+		}
+
+		for running > 0 {
+			res := <-resultsChan
+			if res.completed != "" {
+				running--
+				if err := settle(res.completed, true); err != nil {
+					yield(nil, err)
+					return
+				}
+				continue
+			}
+			if !yield(res.event, res.err) {
+				return
+			}
+			if res.err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (g *graph) node(name string) agent.Agent {
+	for _, n := range g.nodes {
+		if n.Name() == name {
+			return n
+		}
+	}
+	return nil
+}
+
+func nodeBranch(ctx agent.InvocationContext, node agent.Agent) string {
+	branch := fmt.Sprintf("%s.%s", ctx.Agent().Name(), node.Name())
+	if ctx.Branch() != "" {
+		branch = fmt.Sprintf("%s.%s", ctx.Branch(), branch)
+	}
+	return branch
+}
+
+// runNode forwards the events of the node to results and reports completion
+// once the node's iterator is exhausted. It stops early if done is closed.
+func runNode(ctx agent.InvocationContext, node agent.Agent, results chan<- result, done <-chan bool) {
+	send := func(res result) bool {
+		select {
+		case <-done:
+			return false
+		case results <- res:
+			return true
+		}
+	}
+
+	for event, err := range node.Run(ctx) {
+		if err == nil && ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		if !send(result{event: event, err: err}) || err != nil {
+			return
+		}
+	}
+	send(result{completed: node.Name()})
+}
+
+type result struct {
+	event *session.Event
+	err   error
+	// completed is set to the name of the node once it has finished.
+	completed string
+}
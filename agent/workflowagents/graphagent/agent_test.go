@@ -0,0 +1,283 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphagent_test
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/workflowagents/graphagent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+func TestGraphAgent(t *testing.T) {
+	stateEquals := func(key, want string) graphagent.Condition {
+		return func(ctx agent.ReadonlyContext) (bool, error) {
+			v, err := ctx.ReadonlyState().Get(key)
+			if err != nil {
+				return false, err
+			}
+			return v == want, nil
+		}
+	}
+
+	tests := []struct {
+		name      string
+		nodes     []string
+		edges     []graphagent.Edge
+		agentErr  string // name of the node which fails
+		wantRuns  []string
+		wantOrder [][2]string // pairs of nodes where the first must complete before the second starts
+		wantErr   bool
+	}{
+		{
+			name:     "independent nodes",
+			nodes:    []string{"a", "b", "c"},
+			wantRuns: []string{"a", "b", "c"},
+		},
+		{
+			name:  "fan-out and fan-in",
+			nodes: []string{"a", "b", "c", "d"},
+			edges: []graphagent.Edge{
+				{From: "a", To: "b"},
+				{From: "a", To: "c"},
+				{From: "b", To: "d"},
+				{From: "c", To: "d"},
+			},
+			wantRuns:  []string{"a", "b", "c", "d"},
+			wantOrder: [][2]string{{"a", "b"}, {"a", "c"}, {"b", "d"}, {"c", "d"}},
+		},
+		{
+			name:  "conditional branch",
+			nodes: []string{"a", "yes", "no", "after_no"},
+			edges: []graphagent.Edge{
+				{From: "a", To: "yes", Condition: stateEquals("a", "done")},
+				{From: "a", To: "no", Condition: stateEquals("a", "other")},
+				{From: "no", To: "after_no"},
+			},
+			wantRuns:  []string{"a", "yes"},
+			wantOrder: [][2]string{{"a", "yes"}},
+		},
+		{
+			name:  "join after branch",
+			nodes: []string{"a", "yes", "no", "join"},
+			edges: []graphagent.Edge{
+				{From: "a", To: "yes", Condition: stateEquals("a", "done")},
+				{From: "a", To: "no", Condition: stateEquals("a", "other")},
+				{From: "yes", To: "join"},
+				{From: "no", To: "join"},
+			},
+			wantRuns:  []string{"a", "join", "yes"},
+			wantOrder: [][2]string{{"a", "yes"}, {"yes", "join"}},
+		},
+		{
+			name:  "node error",
+			nodes: []string{"a", "b"},
+			edges: []graphagent.Edge{
+				{From: "a", To: "b"},
+			},
+			agentErr: "a",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{}
+			var subAgents []agent.Agent
+			for _, name := range tt.nodes {
+				var err error
+				if name == tt.agentErr {
+					err = fmt.Errorf("node failure")
+				}
+				subAgents = append(subAgents, rec.newAgent(t, name, err))
+			}
+
+			graphAgent, err := graphagent.New(graphagent.Config{
+				AgentConfig: agent.Config{
+					Name:      "graph",
+					SubAgents: subAgents,
+				},
+				Edges: tt.edges,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			events, err := runAgent(t, graphAgent)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var gotRuns []string
+			for _, ev := range events {
+				gotRuns = append(gotRuns, ev.Author)
+			}
+			slices.Sort(gotRuns)
+			if diff := cmp.Diff(tt.wantRuns, gotRuns); diff != "" {
+				t.Errorf("run nodes mismatch (-want +got):\n%s", diff)
+			}
+
+			for _, pair := range tt.wantOrder {
+				if !rec.finishedBefore(pair[0], pair[1]) {
+					t.Errorf("%q did not finish before %q started", pair[0], pair[1])
+				}
+			}
+		})
+	}
+}
+
+func TestNew_Validation(t *testing.T) {
+	rec := &recorder{}
+	subAgents := []agent.Agent{rec.newAgent(t, "a", nil), rec.newAgent(t, "b", nil), rec.newAgent(t, "c", nil)}
+
+	tests := []struct {
+		name    string
+		edges   []graphagent.Edge
+		wantErr string
+	}{
+		{
+			name:    "unknown node",
+			edges:   []graphagent.Edge{{From: "a", To: "d"}},
+			wantErr: `"d" is not a sub-agent`,
+		},
+		{
+			name:    "duplicate edge",
+			edges:   []graphagent.Edge{{From: "a", To: "b"}, {From: "a", To: "b"}},
+			wantErr: "declared more than once",
+		},
+		{
+			name:    "self loop",
+			edges:   []graphagent.Edge{{From: "a", To: "a"}},
+			wantErr: "cycle: a -> a",
+		},
+		{
+			name:    "cycle",
+			edges:   []graphagent.Edge{{From: "a", To: "b"}, {From: "b", To: "c"}, {From: "c", To: "a"}},
+			wantErr: "cycle: a -> b -> c -> a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := graphagent.New(graphagent.Config{
+				AgentConfig: agent.Config{Name: "graph", SubAgents: subAgents},
+				Edges:       tt.edges,
+			})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("New() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func runAgent(t *testing.T, a agent.Agent) ([]*session.Event, error) {
+	t.Helper()
+	ctx := t.Context()
+
+	sessionService := session.InMemoryService()
+	agentRunner, err := runner.New(runner.Config{
+		AppName:        "test_app",
+		Agent:          a,
+		SessionService: sessionService,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = sessionService.Create(ctx, &session.CreateRequest{
+		AppName:   "test_app",
+		UserID:    "user_id",
+		SessionID: "session_id",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events []*session.Event
+	for event, err := range agentRunner.Run(ctx, "user_id", "session_id", genai.NewContentFromText("user input", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// recorder keeps track of start and end times of the node runs.
+type recorder struct {
+	mu           sync.Mutex
+	starts, ends map[string]time.Time
+}
+
+func (r *recorder) newAgent(t *testing.T, name string, agentErr error) agent.Agent {
+	t.Helper()
+
+	a, err := agent.New(agent.Config{
+		Name: name,
+		Run: func(agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				start := time.Now()
+				time.Sleep(2 * time.Millisecond)
+				if agentErr != nil {
+					yield(nil, agentErr)
+					return
+				}
+				ev := &session.Event{
+					LLMResponse: model.LLMResponse{
+						Content: genai.NewContentFromText("hello from "+name, genai.RoleModel),
+					},
+					Actions: session.EventActions{StateDelta: map[string]any{name: "done"}},
+				}
+				r.record(name, start, time.Now())
+				yield(ev, nil)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func (r *recorder) record(name string, start, end time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.starts == nil {
+		r.starts, r.ends = make(map[string]time.Time), make(map[string]time.Time)
+	}
+	r.starts[name], r.ends[name] = start, end
+}
+
+func (r *recorder) finishedBefore(first, second string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	end, ok1 := r.ends[first]
+	start, ok2 := r.starts[second]
+	return ok1 && ok2 && !end.After(start)
+}
@@ -19,12 +19,28 @@ package loopagent
 import (
 	"fmt"
 	"iter"
+	"strings"
 
 	"google.golang.org/adk/agent"
 	agentinternal "google.golang.org/adk/internal/agent"
+	icontext "google.golang.org/adk/internal/context"
 	"google.golang.org/adk/session"
 )
 
+const (
+	// MetadataKeyIteration is the CustomMetadata key of iteration summary
+	// events holding the index of the completed iteration.
+	MetadataKeyIteration = "adk_loop_iteration"
+	// MetadataKeyExit is the CustomMetadata key of iteration summary events
+	// reporting whether the loop stops after the completed iteration.
+	MetadataKeyExit = "adk_loop_exit"
+)
+
+// ExitCondition decides whether the loop stops after an iteration. It has
+// access to the session state, including the changes made by the sub-agents
+// during the iteration.
+type ExitCondition func(ctx agent.ReadonlyContext) (bool, error)
+
 // Config defines the configuration for a LoopAgent.
 type Config struct {
 	// Basic agent setup.
@@ -33,6 +49,21 @@ type Config struct {
 	// If MaxIterations == 0, then LoopAgent runs indefinitely or until any
 	// sub-agent escalates.
 	MaxIterations uint
+
+	// ExitCondition is optional. If set, it's evaluated after each iteration
+	// and the loop stops once it returns true. Unlike escalation, it only
+	// stops this loop and doesn't affect the enclosing agents.
+	ExitCondition ExitCondition
+	// IterationKey is an optional state key under which the 0-based index of
+	// the current iteration is visible to the sub-agents, e.g. as
+	// {temp:iteration} in llmagent instructions. It must have the
+	// session.KeyPrefixTemp prefix.
+	IterationKey string
+	// EmitIterationEvents makes the LoopAgent emit an event without content
+	// after each completed iteration. The event's CustomMetadata holds the
+	// iteration index under MetadataKeyIteration and whether the loop stops
+	// under MetadataKeyExit.
+	EmitIterationEvents bool
 }
 
 // New creates a LoopAgent.
@@ -46,9 +77,15 @@ func New(cfg Config) (agent.Agent, error) {
 	if cfg.AgentConfig.Run != nil {
 		return nil, fmt.Errorf("LoopAgent doesn't allow custom Run implementations")
 	}
+	if cfg.IterationKey != "" && !strings.HasPrefix(cfg.IterationKey, session.KeyPrefixTemp) {
+		return nil, fmt.Errorf("IterationKey %q must have the %q prefix", cfg.IterationKey, session.KeyPrefixTemp)
+	}
 
 	loopAgentImpl := &loopAgent{
-		maxIterations: cfg.MaxIterations,
+		maxIterations:       cfg.MaxIterations,
+		exitCondition:       cfg.ExitCondition,
+		iterationKey:        cfg.IterationKey,
+		emitIterationEvents: cfg.EmitIterationEvents,
 	}
 	cfg.AgentConfig.Run = loopAgentImpl.Run
 
@@ -69,14 +106,24 @@ func New(cfg Config) (agent.Agent, error) {
 }
 
 type loopAgent struct {
-	maxIterations uint
+	maxIterations       uint
+	exitCondition       ExitCondition
+	iterationKey        string
+	emitIterationEvents bool
 }
 
 func (a *loopAgent) Run(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
 	count := a.maxIterations
 
 	return func(yield func(*session.Event, error) bool) {
-		for {
+		for iteration := 0; ; iteration++ {
+			if a.iterationKey != "" {
+				if err := ctx.Session().State().Set(a.iterationKey, iteration); err != nil {
+					yield(nil, fmt.Errorf("failed to set iteration index: %w", err))
+					return
+				}
+			}
+
 			shouldExit := false
 			for _, subAgent := range ctx.Agent().SubAgents() {
 				for event, err := range subAgent.Run(ctx) {
@@ -85,7 +132,9 @@ func (a *loopAgent) Run(ctx agent.InvocationContext) iter.Seq2[*session.Event, e
 						return
 					}
 
-					if event.Actions.Escalate {
+					// Events forwarded from agent tools belong to nested
+					// agents, which don't control this loop.
+					if event != nil && !event.Forwarded && event.Actions.Escalate {
 						shouldExit = true
 					}
 				}
@@ -94,12 +143,38 @@ func (a *loopAgent) Run(ctx agent.InvocationContext) iter.Seq2[*session.Event, e
 				}
 			}
 
+			if a.exitCondition != nil {
+				var err error
+				shouldExit, err = a.exitCondition(icontext.NewReadonlyContext(ctx))
+				if err != nil {
+					yield(nil, fmt.Errorf("failed to evaluate exit condition after iteration %d: %w", iteration, err))
+					return
+				}
+			}
+
 			if count > 0 {
 				count--
 				if count == 0 {
+					shouldExit = true
+				}
+			}
+
+			if a.emitIterationEvents {
+				event := session.NewEvent(ctx.InvocationID())
+				event.Author = ctx.Agent().Name()
+				event.Branch = ctx.Branch()
+				event.CustomMetadata = map[string]any{
+					MetadataKeyIteration: iteration,
+					MetadataKeyExit:      shouldExit,
+				}
+				if !yield(event, nil) {
 					return
 				}
 			}
+
+			if shouldExit {
+				return
+			}
 		}
 	}
 }
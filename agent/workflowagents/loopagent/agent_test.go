@@ -27,10 +27,12 @@ import (
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
 	"google.golang.org/adk/agent/workflowagents/loopagent"
+	"google.golang.org/adk/internal/testutil"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/runner"
 	"google.golang.org/adk/session"
 	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/agenttool"
 	"google.golang.org/adk/tool/functiontool"
 )
 
@@ -249,6 +251,153 @@ func TestNewLoopAgent(t *testing.T) {
 	}
 }
 
+func TestLoopAgent_ExitCondition(t *testing.T) {
+	ctx := t.Context()
+
+	counter, err := agent.New(agent.Config{
+		Name: "counter",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				iteration, err := ctx.Session().State().Get("temp:iteration")
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				yield(&session.Event{
+					LLMResponse: model.LLMResponse{
+						Content: genai.NewContentFromText(fmt.Sprintf("iteration %v", iteration), genai.RoleModel),
+					},
+					Actions: session.EventActions{StateDelta: map[string]any{"count": iteration.(int) + 1}},
+				}, nil)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loopAgent, err := loopagent.New(loopagent.Config{
+		AgentConfig: agent.Config{
+			Name:      "test_agent",
+			SubAgents: []agent.Agent{counter},
+		},
+		MaxIterations: 10,
+		ExitCondition: func(ctx agent.ReadonlyContext) (bool, error) {
+			count, err := ctx.ReadonlyState().Get("count")
+			if err != nil {
+				return false, err
+			}
+			return count.(int) >= 2, nil
+		},
+		IterationKey:        "temp:iteration",
+		EmitIterationEvents: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sessionService := session.InMemoryService()
+	agentRunner, err := runner.New(runner.Config{
+		AppName:        "test_app",
+		Agent:          loopAgent,
+		SessionService: sessionService,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = sessionService.Create(ctx, &session.CreateRequest{
+		AppName:   "test_app",
+		UserID:    "user_id",
+		SessionID: "session_id",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type summary struct {
+		Author string
+		Text   string
+		Meta   map[string]any
+	}
+	var got []summary
+	for event, err := range agentRunner.Run(ctx, "user_id", "session_id", genai.NewContentFromText("user input", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("got unexpected error: %v", err)
+		}
+		s := summary{Author: event.Author, Meta: event.CustomMetadata}
+		if event.Content != nil {
+			s.Text = event.Content.Parts[0].Text
+		}
+		got = append(got, s)
+	}
+
+	want := []summary{
+		{Author: "counter", Text: "iteration 0"},
+		{Author: "test_agent", Meta: map[string]any{loopagent.MetadataKeyIteration: 0, loopagent.MetadataKeyExit: false}},
+		{Author: "counter", Text: "iteration 1"},
+		{Author: "test_agent", Meta: map[string]any{loopagent.MetadataKeyIteration: 1, loopagent.MetadataKeyExit: true}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("events mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoopAgent_ForwardedEscalation(t *testing.T) {
+	// The nested agent escalates, which ends its own run but not the loop
+	// of the agent calling it through an agent tool.
+	nested, err := agent.New(agent.Config{
+		Name:        "nested",
+		Description: "Escalates.",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				event := session.NewEvent(ctx.InvocationID())
+				event.Author = ctx.Agent().Name()
+				event.Content = genai.NewContentFromText("escalating", genai.RoleModel)
+				event.Actions.Escalate = true
+				yield(event, nil)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := genai.NewContentFromFunctionCall("nested", map[string]any{"request": "go"}, genai.RoleModel)
+	done := genai.NewContentFromText("done", genai.RoleModel)
+	caller, err := llmagent.New(llmagent.Config{
+		Name:  "caller",
+		Model: &testutil.MockModel{Responses: []*genai.Content{call, done, call, done}},
+		Tools: []tool.Tool{agenttool.New(nested, &agenttool.Config{ForwardEvents: true})},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loop, err := loopagent.New(loopagent.Config{
+		AgentConfig:   agent.Config{Name: "loop", SubAgents: []agent.Agent{caller}},
+		MaxIterations: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts, err := testutil.CollectTextParts(testutil.NewTestAgentRunner(t, loop).Run(t, "session", "hi"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"escalating", "done", "escalating", "done"}, parts); diff != "" {
+		t.Errorf("text parts mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNew_InvalidIterationKey(t *testing.T) {
+	_, err := loopagent.New(loopagent.Config{
+		AgentConfig:  agent.Config{Name: "test_agent"},
+		IterationKey: "iteration",
+	})
+	if err == nil {
+		t.Error("New() succeeded, want error")
+	}
+}
+
 func newCustomAgent(t *testing.T, id int) agent.Agent {
 	t.Helper()
 
@@ -0,0 +1,284 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mapagent provides an agent that runs its sub-agent once for each
+// item of a list stored in session state.
+package mapagent
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"google.golang.org/adk/agent"
+	agentinternal "google.golang.org/adk/internal/agent"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/llminternal"
+	"google.golang.org/adk/session"
+)
+
+// ErrorPolicy defines how MapAgent handles a failing run of its sub-agent.
+type ErrorPolicy string
+
+const (
+	// ErrorPolicyFailFast cancels the remaining runs and returns the error of
+	// the first failing run.
+	ErrorPolicyFailFast ErrorPolicy = "fail_fast"
+	// ErrorPolicyCollect lets the remaining runs complete. Failed runs have a
+	// nil result, and their errors are stored under Config.ErrorsKey.
+	ErrorPolicyCollect ErrorPolicy = "collect"
+)
+
+// DefaultItemKey is the state key used when Config.ItemKey is empty.
+const DefaultItemKey = session.KeyPrefixTemp + "item"
+
+// Config defines the configuration for a MapAgent.
+type Config struct {
+	// Basic agent setup. It must have exactly one sub-agent.
+	AgentConfig agent.Config
+
+	// InputKey is the state key holding the list of items.
+	InputKey string
+	// ItemKey is the state key under which the current item is visible to
+	// the sub-agent, e.g. as {temp:item} in llmagent instructions. It must
+	// have the session.KeyPrefixTemp prefix. Defaults to DefaultItemKey.
+	ItemKey string
+	// ResultKey is the state key the sub-agent writes its result to. If
+	// empty, the OutputKey of the sub-agent is used, if it's an LLM agent.
+	ResultKey string
+	// OutputKey is the state key where the list of results is stored. The
+	// results are in the same order as the input items.
+	OutputKey string
+
+	// MaxConcurrency limits the number of concurrent runs of the sub-agent.
+	// If zero, all items are processed concurrently.
+	MaxConcurrency int
+	// ErrorPolicy defines how failing runs are handled. Defaults to
+	// ErrorPolicyFailFast.
+	ErrorPolicy ErrorPolicy
+	// ErrorsKey is an optional state key used with ErrorPolicyCollect. The
+	// stored list is aligned with the input items and holds either nil or
+	// the error message of the corresponding run.
+	ErrorsKey string
+}
+
+// New creates a MapAgent.
+//
+// MapAgent reads a list from session state and runs its sub-agent once per
+// item, with the item injected into a temporary state key. The runs are
+// isolated in the same way as ParallelAgent's sub-agents. Once all runs
+// complete, MapAgent emits an event storing the ordered results in state.
+//
+// Use the MapAgent when the same processing must be applied to each element
+// of a collection, such as each document or each ticket.
+func New(cfg Config) (agent.Agent, error) {
+	if cfg.AgentConfig.Run != nil {
+		return nil, fmt.Errorf("MapAgent doesn't allow custom Run implementations")
+	}
+	if len(cfg.AgentConfig.SubAgents) != 1 {
+		return nil, fmt.Errorf("MapAgent requires exactly one sub-agent, got %d", len(cfg.AgentConfig.SubAgents))
+	}
+	if cfg.InputKey == "" {
+		return nil, fmt.Errorf("InputKey is required")
+	}
+	if cfg.OutputKey == "" {
+		return nil, fmt.Errorf("OutputKey is required")
+	}
+	if cfg.ItemKey == "" {
+		cfg.ItemKey = DefaultItemKey
+	}
+	if !strings.HasPrefix(cfg.ItemKey, session.KeyPrefixTemp) {
+		return nil, fmt.Errorf("ItemKey %q must have the %q prefix", cfg.ItemKey, session.KeyPrefixTemp)
+	}
+	if cfg.ResultKey == "" {
+		if llmAgent, ok := cfg.AgentConfig.SubAgents[0].(llminternal.Agent); ok {
+			cfg.ResultKey = llminternal.Reveal(llmAgent).OutputKey
+		}
+	}
+	if cfg.ResultKey == "" {
+		return nil, fmt.Errorf("ResultKey is required unless the sub-agent is an LLM agent with OutputKey")
+	}
+	if cfg.MaxConcurrency < 0 {
+		return nil, fmt.Errorf("MaxConcurrency must not be negative")
+	}
+	switch cfg.ErrorPolicy {
+	case "":
+		cfg.ErrorPolicy = ErrorPolicyFailFast
+	case ErrorPolicyFailFast, ErrorPolicyCollect:
+	default:
+		return nil, fmt.Errorf("unknown ErrorPolicy %q", cfg.ErrorPolicy)
+	}
+
+	mapAgentImpl := &mapAgent{cfg: cfg}
+	cfg.AgentConfig.Run = mapAgentImpl.run
+
+	mapAgent, err := agent.New(cfg.AgentConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create base agent: %w", err)
+	}
+
+	internalAgent, ok := mapAgent.(agentinternal.Agent)
+	if !ok {
+		return nil, fmt.Errorf("internal error: failed to convert to internal agent")
+	}
+	state := agentinternal.Reveal(internalAgent)
+	state.AgentType = agentinternal.TypeMapAgent
+	state.Config = cfg
+
+	return mapAgent, nil
+}
+
+type mapAgent struct {
+	cfg Config
+}
+
+func (a *mapAgent) run(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+	return func(yield func(*session.Event, error) bool) {
+		items, err := a.items(ctx)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		var (
+			subAgent    = ctx.Agent().SubAgents()[0]
+			results     = make([]any, len(items))
+			errs        = make([]error, len(items))
+			resultsChan = make(chan result)
+			doneChan    = make(chan bool)
+		)
+
+		errGroup, errGroupCtx := errgroup.WithContext(ctx)
+		if a.cfg.MaxConcurrency > 0 {
+			errGroup.SetLimit(a.cfg.MaxConcurrency)
+		}
+
+		go func() {
+			for i, item := range items {
+				errGroup.Go(func() error {
+					if errGroupCtx.Err() != nil {
+						return nil
+					}
+					subCtx := icontext.NewInvocationContext(errGroupCtx, icontext.InvocationContextParams{
+						Artifacts:   ctx.Artifacts(),
+						Memory:      ctx.Memory(),
+						Session:     newItemSession(ctx.Session(), a.cfg.ItemKey, item),
+						Branch:      itemBranch(ctx, subAgent, i),
+						Agent:       subAgent,
+						UserContent: ctx.UserContent(),
+						RunConfig:   ctx.RunConfig(),
+					})
+					res, err := a.runItem(subCtx, subAgent, resultsChan, doneChan)
+					results[i], errs[i] = res, err
+					if err != nil && a.cfg.ErrorPolicy == ErrorPolicyFailFast {
+						return err
+					}
+					return nil
+				})
+			}
+			_ = errGroup.Wait() // this error is already sent to the user via iterator
+			close(resultsChan)
+		}()
+
+		defer close(doneChan)
+		for res := range resultsChan {
+			if !yield(res.event, res.err) || res.err != nil {
+				return
+			}
+		}
+
+		event := session.NewEvent(ctx.InvocationID())
+		event.Author = ctx.Agent().Name()
+		event.Branch = ctx.Branch()
+		event.Actions.StateDelta[a.cfg.OutputKey] = results
+		if a.cfg.ErrorPolicy == ErrorPolicyCollect && a.cfg.ErrorsKey != "" {
+			messages := make([]any, len(errs))
+			for i, err := range errs {
+				if err != nil {
+					messages[i] = err.Error()
+				}
+			}
+			event.Actions.StateDelta[a.cfg.ErrorsKey] = messages
+		}
+		yield(event, nil)
+	}
+}
+
+// runItem forwards the events of a single sub-agent run and returns the last
+// value it wrote to the result key. With ErrorPolicyFailFast, the error of
+// the run is forwarded as well.
+func (a *mapAgent) runItem(ctx agent.InvocationContext, subAgent agent.Agent, results chan<- result, done <-chan bool) (any, error) {
+	var value any
+	for event, err := range subAgent.Run(ctx) {
+		if err != nil {
+			err = fmt.Errorf("failed to run sub-agent %q on item %q: %w", subAgent.Name(), ctx.Branch(), err)
+			if a.cfg.ErrorPolicy == ErrorPolicyFailFast {
+				select {
+				case <-done:
+				case results <- result{err: err}:
+				}
+			}
+			return nil, err
+		}
+		if event != nil && !event.Forwarded {
+			if v, ok := event.Actions.StateDelta[a.cfg.ResultKey]; ok {
+				value = v
+			}
+		}
+		select {
+		case <-done:
+			return nil, ctx.Err()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case results <- result{event: event}:
+		}
+	}
+	return value, nil
+}
+
+// items returns the list stored under the input key.
+func (a *mapAgent) items(ctx agent.InvocationContext) ([]any, error) {
+	value, err := ctx.Session().State().Get(a.cfg.InputKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read items from state key %q: %w", a.cfg.InputKey, err)
+	}
+	if items, ok := value.([]any); ok {
+		return items, nil
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("state key %q holds %T, want a list", a.cfg.InputKey, value)
+	}
+	items := make([]any, v.Len())
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+	return items, nil
+}
+
+func itemBranch(ctx agent.InvocationContext, subAgent agent.Agent, index int) string {
+	branch := fmt.Sprintf("%s.%s_%d", ctx.Agent().Name(), subAgent.Name(), index)
+	if ctx.Branch() != "" {
+		branch = fmt.Sprintf("%s.%s", ctx.Branch(), branch)
+	}
+	return branch
+}
+
+type result struct {
+	event *session.Event
+	err   error
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mapagent_test
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/agent/workflowagents/mapagent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+func TestMapAgent_LLMSubAgent(t *testing.T) {
+	llm := &echoLLM{}
+	worker, err := llmagent.New(llmagent.Config{
+		Name:        "worker",
+		Model:       llm,
+		Instruction: "{temp:item}",
+		OutputKey:   "summary",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mapAgent, err := mapagent.New(mapagent.Config{
+		AgentConfig:    agent.Config{Name: "map", SubAgents: []agent.Agent{worker}},
+		InputKey:       "documents",
+		OutputKey:      "summaries",
+		MaxConcurrency: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, _, err := runAgent(t, mapAgent, map[string]any{"documents": []string{"a", "b", "c", "d", "e"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []any{"processed a", "processed b", "processed c", "processed d", "processed e"}
+	if diff := cmp.Diff(want, state["summaries"]); diff != "" {
+		t.Errorf("summaries mismatch (-want +got):\n%s", diff)
+	}
+	if got := llm.maxRunning.Load(); got > 2 {
+		t.Errorf("got %d concurrent runs, want at most 2", got)
+	}
+}
+
+func TestMapAgent_ErrorPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      mapagent.ErrorPolicy
+		wantErr     bool
+		wantResults []any
+		wantErrors  []any
+	}{
+		{
+			name:    "fail fast",
+			policy:  mapagent.ErrorPolicyFailFast,
+			wantErr: true,
+		},
+		{
+			name:        "collect",
+			policy:      mapagent.ErrorPolicyCollect,
+			wantResults: []any{"A", nil, "C"},
+			wantErrors:  []any{nil, `failed to run sub-agent "worker" on item "map.worker_1": bad item`, nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapAgent, err := mapagent.New(mapagent.Config{
+				AgentConfig: agent.Config{Name: "map", SubAgents: []agent.Agent{newUpperAgent(t)}},
+				InputKey:    "items",
+				ResultKey:   "upper",
+				OutputKey:   "results",
+				ErrorPolicy: tt.policy,
+				ErrorsKey:   "errors",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			state, _, err := runAgent(t, mapAgent, map[string]any{"items": []any{"a", "bad", "c"}})
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.wantResults, state["results"]); diff != "" {
+				t.Errorf("results mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantErrors, state["errors"]); diff != "" {
+				t.Errorf("errors mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNew_Validation(t *testing.T) {
+	worker := newUpperAgent(t)
+	tests := []struct {
+		name string
+		cfg  mapagent.Config
+	}{
+		{
+			name: "no sub-agents",
+			cfg:  mapagent.Config{AgentConfig: agent.Config{Name: "map"}, InputKey: "in", OutputKey: "out", ResultKey: "r"},
+		},
+		{
+			name: "missing input key",
+			cfg:  mapagent.Config{AgentConfig: agent.Config{Name: "map", SubAgents: []agent.Agent{worker}}, OutputKey: "out", ResultKey: "r"},
+		},
+		{
+			name: "missing result key",
+			cfg:  mapagent.Config{AgentConfig: agent.Config{Name: "map", SubAgents: []agent.Agent{worker}}, InputKey: "in", OutputKey: "out"},
+		},
+		{
+			name: "non-temp item key",
+			cfg:  mapagent.Config{AgentConfig: agent.Config{Name: "map", SubAgents: []agent.Agent{worker}}, InputKey: "in", OutputKey: "out", ResultKey: "r", ItemKey: "item"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := mapagent.New(tt.cfg); err == nil {
+				t.Error("New() succeeded, want error")
+			}
+		})
+	}
+}
+
+func runAgent(t *testing.T, a agent.Agent, initialState map[string]any) (map[string]any, []*session.Event, error) {
+	t.Helper()
+	ctx := t.Context()
+
+	sessionService := session.InMemoryService()
+	agentRunner, err := runner.New(runner.Config{
+		AppName:        "test_app",
+		Agent:          a,
+		SessionService: sessionService,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = sessionService.Create(ctx, &session.CreateRequest{
+		AppName:   "test_app",
+		UserID:    "user_id",
+		SessionID: "session_id",
+		State:     initialState,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events []*session.Event
+	for event, err := range agentRunner.Run(ctx, "user_id", "session_id", genai.NewContentFromText("user input", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			return nil, nil, err
+		}
+		events = append(events, event)
+	}
+
+	resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "test_app", UserID: "user_id", SessionID: "session_id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := make(map[string]any)
+	for k, v := range resp.Session.State().All() {
+		state[k] = v
+	}
+	return state, events, nil
+}
+
+// newUpperAgent returns an agent which stores the upper-cased item under the
+// "upper" state key and fails for the "bad" item.
+func newUpperAgent(t *testing.T) agent.Agent {
+	t.Helper()
+
+	a, err := agent.New(agent.Config{
+		Name: "worker",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				item, err := ctx.Session().State().Get(mapagent.DefaultItemKey)
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				if item == "bad" {
+					yield(nil, fmt.Errorf("bad item"))
+					return
+				}
+				yield(&session.Event{
+					Actions: session.EventActions{StateDelta: map[string]any{"upper": strings.ToUpper(item.(string))}},
+				}, nil)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+// echoLLM replies with the system instruction and tracks the number of
+// concurrent calls.
+type echoLLM struct {
+	running, maxRunning atomic.Int32
+}
+
+func (m *echoLLM) Name() string {
+	return "echo"
+}
+
+func (m *echoLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		running := m.running.Add(1)
+		defer m.running.Add(-1)
+		for {
+			maxRunning := m.maxRunning.Load()
+			if running <= maxRunning || m.maxRunning.CompareAndSwap(maxRunning, running) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+
+		instruction := req.Config.SystemInstruction.Parts[0].Text
+		yield(&model.LLMResponse{
+			Content: genai.NewContentFromText("processed "+instruction, genai.RoleModel),
+		}, nil)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mapagent
+
+import (
+	"iter"
+
+	"google.golang.org/adk/session"
+)
+
+// itemSession exposes the current item under the item key on top of the
+// shared session state. Concurrent runs of the sub-agent each get their own
+// itemSession, so they don't overwrite each other's items.
+type itemSession struct {
+	session.Session
+	key  string
+	item any
+}
+
+func newItemSession(s session.Session, key string, item any) *itemSession {
+	return &itemSession{Session: s, key: key, item: item}
+}
+
+func (s *itemSession) State() session.State {
+	return &itemState{State: s.Session.State(), key: s.key, item: s.item}
+}
+
+type itemState struct {
+	session.State
+	key  string
+	item any
+}
+
+func (s *itemState) Get(key string) (any, error) {
+	if key == s.key {
+		return s.item, nil
+	}
+	return s.State.Get(key)
+}
+
+func (s *itemState) All() iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		if !yield(s.key, s.item) {
+			return
+		}
+		for k, v := range s.State.All() {
+			if k == s.key {
+				continue
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
@@ -16,8 +16,12 @@
 package parallelagent
 
 import (
+	"context"
 	"fmt"
 	"iter"
+	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -27,10 +31,46 @@ import (
 	"google.golang.org/adk/session"
 )
 
+// ErrorPolicy defines how ParallelAgent handles a failing sub-agent.
+type ErrorPolicy string
+
+const (
+	// ErrorPolicyFailFast cancels the remaining sub-agents and returns the
+	// error of the first failing one.
+	ErrorPolicyFailFast ErrorPolicy = "fail_fast"
+	// ErrorPolicyContinue lets the remaining sub-agents complete. The errors
+	// are not returned, but reported under Config.ErrorsKey, which is
+	// required with this policy.
+	ErrorPolicyContinue ErrorPolicy = "continue"
+)
+
 // Config defines the configuration for a ParallelAgent.
 type Config struct {
 	// Basic agent setup.
 	AgentConfig agent.Config
+
+	// MaxConcurrency limits the number of sub-agents running at the same
+	// time. If zero, all sub-agents run concurrently.
+	MaxConcurrency int
+	// Timeout limits the duration of each sub-agent run. If zero, the runs
+	// are not limited.
+	Timeout time.Duration
+	// Timeouts overrides Timeout for the sub-agents with the given names.
+	Timeouts map[string]time.Duration
+	// ErrorPolicy defines how failing sub-agents are handled. Defaults to
+	// ErrorPolicyFailFast.
+	ErrorPolicy ErrorPolicy
+	// ErrorsKey is the state key used with ErrorPolicyContinue, required
+	// with it so that errors aren't lost. The stored map holds the error
+	// message of each failed sub-agent, keyed by the sub-agent name.
+	ErrorsKey string
+
+	// OutputKey is an optional state key where the final outputs of the
+	// sub-agents are stored once all of them complete. The stored map holds
+	// the text of the final response of each sub-agent, keyed by the
+	// sub-agent name. Sub-agents which failed or produced no final response
+	// are omitted.
+	OutputKey string
 }
 
 // New creates a ParallelAgent.
@@ -41,12 +81,41 @@ type Config struct {
 // attempts on a single task, such as:
 // - Running different algorithms simultaneously.
 // - Generating multiple responses for review by a subsequent evaluation agent.
+//
+// If OutputKey or ErrorsKey are set, ParallelAgent emits an event updating
+// them once all sub-agents complete, so that a follow-on agent, e.g. in a
+// SequentialAgent, can consume the results of all branches.
 func New(cfg Config) (agent.Agent, error) {
 	if cfg.AgentConfig.Run != nil {
 		return nil, fmt.Errorf("ParallelAgent doesn't allow custom Run implementations")
 	}
+	if cfg.MaxConcurrency < 0 {
+		return nil, fmt.Errorf("MaxConcurrency must not be negative")
+	}
+	if cfg.Timeout < 0 {
+		return nil, fmt.Errorf("Timeout must not be negative")
+	}
+	for name, timeout := range cfg.Timeouts {
+		if !hasSubAgent(cfg.AgentConfig.SubAgents, name) {
+			return nil, fmt.Errorf("timeout for %q: not a sub-agent", name)
+		}
+		if timeout < 0 {
+			return nil, fmt.Errorf("timeout for %q must not be negative", name)
+		}
+	}
+	switch cfg.ErrorPolicy {
+	case "":
+		cfg.ErrorPolicy = ErrorPolicyFailFast
+	case ErrorPolicyFailFast, ErrorPolicyContinue:
+	default:
+		return nil, fmt.Errorf("unknown ErrorPolicy %q", cfg.ErrorPolicy)
+	}
+	if cfg.ErrorPolicy == ErrorPolicyContinue && cfg.ErrorsKey == "" {
+		return nil, fmt.Errorf("ErrorPolicy %q requires ErrorsKey", cfg.ErrorPolicy)
+	}
 
-	cfg.AgentConfig.Run = run
+	parallelAgentImpl := &parallelAgent{cfg: cfg}
+	cfg.AgentConfig.Run = parallelAgentImpl.run
 
 	parallelAgent, err := agent.New(cfg.AgentConfig)
 	if err != nil {
@@ -64,41 +133,69 @@ func New(cfg Config) (agent.Agent, error) {
 	return parallelAgent, nil
 }
 
-func run(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+type parallelAgent struct {
+	cfg Config
+}
+
+func (a *parallelAgent) run(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
 	curAgent := ctx.Agent()
 
 	var (
 		errGroup, errGroupCtx = errgroup.WithContext(ctx)
 		doneChan              = make(chan bool)
 		resultsChan           = make(chan result)
-	)
 
-	for _, sa := range ctx.Agent().SubAgents() {
-		branch := fmt.Sprintf("%s.%s", curAgent.Name(), sa.Name())
-		if ctx.Branch() != "" {
-			branch = fmt.Sprintf("%s.%s", ctx.Branch(), branch)
-		}
-		subAgent := sa
-		errGroup.Go(func() error {
-			subCtx := icontext.NewInvocationContext(errGroupCtx, icontext.InvocationContextParams{
-				Artifacts:   ctx.Artifacts(),
-				Memory:      ctx.Memory(),
-				Session:     ctx.Session(),
-				Branch:      branch,
-				Agent:       subAgent,
-				UserContent: ctx.UserContent(),
-				RunConfig:   ctx.RunConfig(),
-			})
+		mu      sync.Mutex
+		outputs = make(map[string]any)
+		errs    = make(map[string]any)
+	)
+	if a.cfg.MaxConcurrency > 0 {
+		errGroup.SetLimit(a.cfg.MaxConcurrency)
+	}
 
-			if err := runSubAgent(subCtx, subAgent, resultsChan, doneChan); err != nil {
-				return fmt.Errorf("failed to run sub-agent %q: %w", subAgent.Name(), err)
+	go func() {
+		for _, sa := range curAgent.SubAgents() {
+			branch := fmt.Sprintf("%s.%s", curAgent.Name(), sa.Name())
+			if ctx.Branch() != "" {
+				branch = fmt.Sprintf("%s.%s", ctx.Branch(), branch)
 			}
+			subAgent := sa
+			errGroup.Go(func() error {
+				if errGroupCtx.Err() != nil {
+					return nil
+				}
+				runCtx, cancel := a.withTimeout(errGroupCtx, subAgent)
+				defer cancel()
 
-			return nil
-		})
-	}
+				subCtx := icontext.NewInvocationContext(runCtx, icontext.InvocationContextParams{
+					Artifacts:   ctx.Artifacts(),
+					Memory:      ctx.Memory(),
+					Session:     ctx.Session(),
+					Branch:      branch,
+					Agent:       subAgent,
+					UserContent: ctx.UserContent(),
+					RunConfig:   ctx.RunConfig(),
+				})
 
-	go func() {
+				output, err := a.runSubAgent(subCtx, subAgent, resultsChan, doneChan)
+				if err != nil {
+					err = fmt.Errorf("failed to run sub-agent %q: %w", subAgent.Name(), err)
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case err != nil:
+					errs[subAgent.Name()] = err.Error()
+				case output != "":
+					outputs[subAgent.Name()] = output
+				}
+				if a.cfg.ErrorPolicy == ErrorPolicyFailFast {
+					return err
+				}
+				return nil
+			})
+		}
 		_ = errGroup.Wait() // this error is already sent to the user via iterator
 		close(resultsChan)
 	}()
@@ -106,37 +203,103 @@ func run(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
 	return func(yield func(*session.Event, error) bool) {
 		defer close(doneChan)
 
+		failed := false
 		for res := range resultsChan {
+			if res.err != nil {
+				failed = true
+			}
 			if !yield(res.event, res.err) {
-				break
+				return
 			}
 		}
+		if failed || (a.cfg.OutputKey == "" && a.cfg.ErrorPolicy != ErrorPolicyContinue) {
+			return
+		}
+
+		event := session.NewEvent(ctx.InvocationID())
+		event.Author = curAgent.Name()
+		event.Branch = ctx.Branch()
+		if a.cfg.OutputKey != "" {
+			event.Actions.StateDelta[a.cfg.OutputKey] = outputs
+		}
+		if a.cfg.ErrorPolicy == ErrorPolicyContinue {
+			event.Actions.StateDelta[a.cfg.ErrorsKey] = errs
+		}
+		yield(event, nil)
 	}
 }
 
-func runSubAgent(ctx agent.InvocationContext, agent agent.Agent, results chan<- result, done <-chan bool) error {
+// withTimeout returns the context for a run of the sub-agent, limited by its
+// configured timeout.
+func (a *parallelAgent) withTimeout(ctx context.Context, subAgent agent.Agent) (context.Context, context.CancelFunc) {
+	timeout := a.cfg.Timeout
+	if t, ok := a.cfg.Timeouts[subAgent.Name()]; ok {
+		timeout = t
+	}
+	if timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// runSubAgent forwards the events of the sub-agent and returns the text of
+// its final response. With ErrorPolicyFailFast, the error of the run is
+// forwarded as well.
+func (a *parallelAgent) runSubAgent(ctx agent.InvocationContext, agent agent.Agent, results chan<- result, done <-chan bool) (string, error) {
+	forwardErr := func(err error) {
+		if a.cfg.ErrorPolicy != ErrorPolicyFailFast {
+			return
+		}
+		select {
+		case <-done:
+		case results <- result{err: err}:
+		}
+	}
+
+	var output string
 	for event, err := range agent.Run(ctx) {
+		if err != nil {
+			forwardErr(err)
+			return "", err
+		}
 		select {
 		case <-done:
-			return nil
+			return "", nil
 		case <-ctx.Done():
-			select {
-			case <-done:
-			case results <- result{
-				err: ctx.Err(),
-			}:
-			}
-			return ctx.Err()
-		case results <- result{
-			event: event,
-			err:   err,
-		}:
-			if err != nil {
-				return err
+			forwardErr(ctx.Err())
+			return "", ctx.Err()
+		case results <- result{event: event}:
+		}
+		if event != nil && !event.Forwarded && event.IsFinalResponse() {
+			if text := eventText(event); text != "" {
+				output = text
 			}
 		}
 	}
-	return nil
+	return output, nil
+}
+
+// eventText returns the non-thought text of the event's content.
+func eventText(event *session.Event) string {
+	if event.Content == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, part := range event.Content.Parts {
+		if part.Text != "" && !part.Thought {
+			sb.WriteString(part.Text)
+		}
+	}
+	return sb.String()
+}
+
+func hasSubAgent(subAgents []agent.Agent, name string) bool {
+	for _, sa := range subAgents {
+		if sa.Name() == name {
+			return true
+		}
+	}
+	return false
 }
 
 type result struct {
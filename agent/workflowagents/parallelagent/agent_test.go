@@ -20,6 +20,7 @@ import (
 	"iter"
 	rand "math/rand/v2"
 	"slices"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -161,6 +162,166 @@ func TestNewParallelAgent(t *testing.T) {
 	}
 }
 
+func TestParallelAgent_ContinueAndAggregate(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	newAgent := func(name string, run func(ctx agent.InvocationContext) (string, error)) agent.Agent {
+		return must(agent.New(agent.Config{
+			Name: name,
+			Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+				return func(yield func(*session.Event, error) bool) {
+					cur := running.Add(1)
+					defer running.Add(-1)
+					for {
+						m := maxRunning.Load()
+						if cur <= m || maxRunning.CompareAndSwap(m, cur) {
+							break
+						}
+					}
+
+					text, err := run(ctx)
+					if err != nil {
+						yield(nil, err)
+						return
+					}
+					yield(&session.Event{
+						LLMResponse: model.LLMResponse{
+							Content: genai.NewContentFromText(text, genai.RoleModel),
+						},
+					}, nil)
+				}
+			},
+		}))
+	}
+
+	subAgents := []agent.Agent{
+		newAgent("fast", func(agent.InvocationContext) (string, error) {
+			time.Sleep(2 * time.Millisecond)
+			return "fast result", nil
+		}),
+		newAgent("slow", func(ctx agent.InvocationContext) (string, error) {
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(10 * time.Second):
+				return "slow result", nil
+			}
+		}),
+		newAgent("broken", func(agent.InvocationContext) (string, error) {
+			return "", fmt.Errorf("broken")
+		}),
+		newAgent("other", func(agent.InvocationContext) (string, error) {
+			time.Sleep(2 * time.Millisecond)
+			return "other result", nil
+		}),
+	}
+
+	parallelAgent, err := parallelagent.New(parallelagent.Config{
+		AgentConfig: agent.Config{
+			Name:      "test_agent",
+			SubAgents: subAgents,
+		},
+		MaxConcurrency: 2,
+		Timeout:        time.Minute,
+		Timeouts:       map[string]time.Duration{"slow": 20 * time.Millisecond},
+		ErrorPolicy:    parallelagent.ErrorPolicyContinue,
+		ErrorsKey:      "errors",
+		OutputKey:      "results",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := runAgent(t, parallelAgent)
+
+	wantResults := map[string]any{"fast": "fast result", "other": "other result"}
+	if diff := cmp.Diff(wantResults, state["results"]); diff != "" {
+		t.Errorf("results mismatch (-want +got):\n%s", diff)
+	}
+	wantErrors := map[string]any{
+		"slow":   `failed to run sub-agent "slow": context deadline exceeded`,
+		"broken": `failed to run sub-agent "broken": broken`,
+	}
+	if diff := cmp.Diff(wantErrors, state["errors"]); diff != "" {
+		t.Errorf("errors mismatch (-want +got):\n%s", diff)
+	}
+	if got := maxRunning.Load(); got > 2 {
+		t.Errorf("got %d concurrent sub-agents, want at most 2", got)
+	}
+}
+
+func TestNew_Validation(t *testing.T) {
+	subAgents := []agent.Agent{must(agent.New(agent.Config{Name: "sub", Run: customRun(1, nil)}))}
+	tests := []struct {
+		name string
+		cfg  parallelagent.Config
+	}{
+		{
+			name: "negative max concurrency",
+			cfg:  parallelagent.Config{MaxConcurrency: -1},
+		},
+		{
+			name: "timeout for unknown sub-agent",
+			cfg:  parallelagent.Config{Timeouts: map[string]time.Duration{"unknown": time.Second}},
+		},
+		{
+			name: "unknown error policy",
+			cfg:  parallelagent.Config{ErrorPolicy: "ignore"},
+		},
+		{
+			name: "continue without errors key",
+			cfg:  parallelagent.Config{ErrorPolicy: parallelagent.ErrorPolicyContinue},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.AgentConfig = agent.Config{Name: "test_agent", SubAgents: subAgents}
+			if _, err := parallelagent.New(tt.cfg); err == nil {
+				t.Error("New() succeeded, want error")
+			}
+		})
+	}
+}
+
+// runAgent runs the agent to completion and returns the final session state.
+func runAgent(t *testing.T, a agent.Agent) map[string]any {
+	t.Helper()
+	ctx := t.Context()
+
+	sessionService := session.InMemoryService()
+	agentRunner, err := runner.New(runner.Config{
+		AppName:        "test_app",
+		Agent:          a,
+		SessionService: sessionService,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = sessionService.Create(ctx, &session.CreateRequest{
+		AppName:   "test_app",
+		UserID:    "user_id",
+		SessionID: "session_id",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, err := range agentRunner.Run(ctx, "user_id", "session_id", genai.NewContentFromText("user input", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("got unexpected error: %v", err)
+		}
+	}
+
+	resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "test_app", UserID: "user_id", SessionID: "session_id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := make(map[string]any)
+	for k, v := range resp.Session.State().All() {
+		state[k] = v
+	}
+	return state
+}
+
 // newParallelAgent creates parallel agent with 2 subagents emitting maxIterations events or infinitely if maxIterations==0.
 func newParallelAgent(t *testing.T, maxIterations uint, numSubAgents int, agentErr error) agent.Agent {
 	var subAgents []agent.Agent
@@ -0,0 +1,463 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retryagent provides an agent that wraps another agent with
+// retries, a timeout and a fallback.
+package retryagent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/agent"
+	agentinternal "google.golang.org/adk/internal/agent"
+	"google.golang.org/adk/session"
+)
+
+const (
+	// MetadataKeyAttempt is the CustomMetadata key holding the 1-based
+	// attempt number of forwarded events and failure events.
+	MetadataKeyAttempt = "adk_retry_attempt"
+	// MetadataKeyError is the CustomMetadata key of failure events holding
+	// the error message of the failed attempt.
+	MetadataKeyError = "adk_retry_error"
+)
+
+// FailedAttempts defines what happens to the events of failed attempts.
+type FailedAttempts string
+
+const (
+	// FailedAttemptsTag forwards the events of each attempt as they are
+	// produced, tagged with the attempt number under MetadataKeyAttempt.
+	// When an attempt fails, the RetryAgent emits an event without content
+	// which records the error under MetadataKeyError.
+	FailedAttemptsTag FailedAttempts = "tag"
+	// FailedAttemptsSuppress buffers the events of each attempt and only
+	// forwards them once the attempt succeeds. The events of failed attempts
+	// are dropped, so they never reach the session history.
+	//
+	// Each attempt runs on a scratch view of the session: the wrapped agent
+	// sees the events and state changes of the current attempt, e.g. its own
+	// function calls and responses, while the session itself is only updated
+	// with the events of the successful attempt.
+	FailedAttemptsSuppress FailedAttempts = "suppress"
+)
+
+// Config defines the configuration for a RetryAgent.
+type Config struct {
+	// Basic agent setup. It must have exactly one sub-agent, which is the
+	// wrapped agent.
+	AgentConfig agent.Config
+
+	// MaxAttempts is the number of times the wrapped agent is run before
+	// giving up. Defaults to 1, i.e. no retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. Defaults to 1s.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries. If zero, the delay is not
+	// capped.
+	MaxBackoff time.Duration
+	// BackoffMultiplier is the factor the delay grows by after each retry.
+	// Defaults to 2.
+	BackoffMultiplier float64
+
+	// AttemptTimeout limits the duration of each attempt, i.e. of iterating
+	// over the events of the wrapped agent. Attempts which time out are
+	// failed. If zero, attempts are not limited.
+	AttemptTimeout time.Duration
+	// Timeout limits the duration of the whole RetryAgent run: the attempts,
+	// the backoff between them and the Fallback agent. When it's exceeded,
+	// no more attempts are made and the run fails. If zero, the run is not
+	// limited.
+	Timeout time.Duration
+
+	// Fallback is an optional agent which runs when all attempts fail. It's
+	// added to the sub-agents of the RetryAgent.
+	Fallback agent.Agent
+
+	// FailedAttempts defines how the events of failed attempts are handled.
+	// Defaults to FailedAttemptsTag.
+	FailedAttempts FailedAttempts
+}
+
+// New creates a RetryAgent.
+//
+// RetryAgent runs the wrapped agent and retries it with exponential backoff
+// when it fails. An attempt fails if the wrapped agent returns an error, emits
+// an event with ErrorCode or ErrorMessage set, or exceeds AttemptTimeout. When
+// all attempts fail, the Fallback agent runs, or the error of the last attempt
+// is returned if there is no Fallback.
+//
+// Use the RetryAgent to make agents calling flaky systems, such as remote A2A
+// agents, more resilient.
+func New(cfg Config) (agent.Agent, error) {
+	if cfg.AgentConfig.Run != nil {
+		return nil, fmt.Errorf("RetryAgent doesn't allow custom Run implementations")
+	}
+	if len(cfg.AgentConfig.SubAgents) != 1 {
+		return nil, fmt.Errorf("RetryAgent requires exactly one sub-agent, got %d", len(cfg.AgentConfig.SubAgents))
+	}
+	if cfg.MaxAttempts < 0 {
+		return nil, fmt.Errorf("MaxAttempts must not be negative")
+	}
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.InitialBackoff < 0 || cfg.MaxBackoff < 0 || cfg.AttemptTimeout < 0 || cfg.Timeout < 0 {
+		return nil, fmt.Errorf("durations must not be negative")
+	}
+	if cfg.InitialBackoff == 0 {
+		cfg.InitialBackoff = time.Second
+	}
+	if cfg.BackoffMultiplier == 0 {
+		cfg.BackoffMultiplier = 2
+	}
+	if cfg.BackoffMultiplier < 1 {
+		return nil, fmt.Errorf("BackoffMultiplier must be at least 1")
+	}
+	switch cfg.FailedAttempts {
+	case "":
+		cfg.FailedAttempts = FailedAttemptsTag
+	case FailedAttemptsTag, FailedAttemptsSuppress:
+	default:
+		return nil, fmt.Errorf("unknown FailedAttempts policy %q", cfg.FailedAttempts)
+	}
+
+	retryAgentImpl := &retryAgent{cfg: cfg}
+
+	agentCfg := cfg.AgentConfig
+	agentCfg.Run = retryAgentImpl.run
+	if cfg.Fallback != nil {
+		agentCfg.SubAgents = append([]agent.Agent{agentCfg.SubAgents[0]}, cfg.Fallback)
+	}
+
+	retryAgent, err := agent.New(agentCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create base agent: %w", err)
+	}
+
+	internalAgent, ok := retryAgent.(agentinternal.Agent)
+	if !ok {
+		return nil, fmt.Errorf("internal error: failed to convert to internal agent")
+	}
+	state := agentinternal.Reveal(internalAgent)
+	state.AgentType = agentinternal.TypeRetryAgent
+	state.Config = cfg
+
+	return retryAgent, nil
+}
+
+type retryAgent struct {
+	cfg Config
+}
+
+func (a *retryAgent) run(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+	return func(yield func(*session.Event, error) bool) {
+		if a.cfg.Timeout == 0 {
+			a.retry(ctx, yield)
+			return
+		}
+		timeoutCtx, cancel := context.WithTimeout(ctx, a.cfg.Timeout)
+		defer cancel()
+		a.retry(&invocationContext{InvocationContext: ctx, ctx: timeoutCtx}, func(event *session.Event, err error) bool {
+			if err != nil && timeoutCtx.Err() != nil && ctx.Err() == nil {
+				err = fmt.Errorf("agent %q timed out after %v: %w", ctx.Agent().Name(), a.cfg.Timeout, err)
+			}
+			return yield(event, err)
+		})
+	}
+}
+
+// retry runs the attempts of the wrapped agent, then the Fallback agent if
+// they all fail.
+func (a *retryAgent) retry(ctx agent.InvocationContext, yield func(*session.Event, error) bool) {
+	wrapped := ctx.Agent().SubAgents()[0]
+	backoff := a.cfg.InitialBackoff
+
+	var lastErr error
+	for attempt := 1; attempt <= a.cfg.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			if err := sleep(ctx, backoff); err != nil {
+				yield(nil, err)
+				return
+			}
+			backoff = time.Duration(float64(backoff) * a.cfg.BackoffMultiplier)
+			if a.cfg.MaxBackoff > 0 && backoff > a.cfg.MaxBackoff {
+				backoff = a.cfg.MaxBackoff
+			}
+		}
+
+		events, ok, err := a.runAttempt(ctx, wrapped, attempt, yield)
+		if !ok {
+			return
+		}
+		if ctx.Err() != nil {
+			yield(nil, ctx.Err())
+			return
+		}
+		if err == nil {
+			for _, event := range events {
+				if !yield(event, nil) {
+					return
+				}
+			}
+			return
+		}
+
+		lastErr = err
+		if a.cfg.FailedAttempts == FailedAttemptsTag {
+			event := session.NewEvent(ctx.InvocationID())
+			event.Author = ctx.Agent().Name()
+			event.Branch = ctx.Branch()
+			event.CustomMetadata = map[string]any{
+				MetadataKeyAttempt: attempt,
+				MetadataKeyError:   err.Error(),
+			}
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+
+	if a.cfg.Fallback == nil {
+		yield(nil, fmt.Errorf("agent %q failed after %d attempts: %w", wrapped.Name(), a.cfg.MaxAttempts, lastErr))
+		return
+	}
+	for event, err := range a.cfg.Fallback.Run(ctx) {
+		if !yield(event, err) {
+			return
+		}
+	}
+}
+
+// runAttempt runs the wrapped agent once. With FailedAttemptsTag, the events
+// are forwarded immediately, otherwise they are returned if the attempt
+// succeeds. It returns false if the consumer stopped the iteration.
+func (a *retryAgent) runAttempt(ctx agent.InvocationContext, wrapped agent.Agent, attempt int, yield func(*session.Event, error) bool) ([]*session.Event, bool, error) {
+	attemptCtx := &invocationContext{InvocationContext: ctx, ctx: ctx}
+	if a.cfg.AttemptTimeout > 0 {
+		timeoutCtx, cancel := context.WithTimeout(ctx, a.cfg.AttemptTimeout)
+		defer cancel()
+		attemptCtx.ctx = timeoutCtx
+	}
+	var scratch *attemptSession
+	if a.cfg.FailedAttempts == FailedAttemptsSuppress {
+		scratch = newAttemptSession(ctx.Session())
+		attemptCtx.session = scratch
+	}
+
+	var events []*session.Event
+	for event, err := range wrapped.Run(attemptCtx) {
+		if attemptCtx.Err() != nil {
+			break
+		}
+		if err != nil {
+			return nil, true, err
+		}
+		if event == nil {
+			continue
+		}
+		if scratch != nil {
+			scratch.appendEvent(event)
+			events = append(events, event)
+		} else {
+			event.CustomMetadata = maps.Clone(event.CustomMetadata)
+			if event.CustomMetadata == nil {
+				event.CustomMetadata = make(map[string]any)
+			}
+			event.CustomMetadata[MetadataKeyAttempt] = attempt
+			if !yield(event, nil) {
+				return nil, false, nil
+			}
+		}
+		if event.ErrorCode != "" || event.ErrorMessage != "" {
+			return nil, true, eventError(event)
+		}
+	}
+	if err := attemptCtx.Err(); err != nil && ctx.Err() == nil {
+		return nil, true, fmt.Errorf("attempt timed out after %v: %w", a.cfg.AttemptTimeout, err)
+	}
+	return events, true, nil
+}
+
+func eventError(event *session.Event) error {
+	switch {
+	case event.ErrorCode == "":
+		return errors.New(event.ErrorMessage)
+	case event.ErrorMessage == "":
+		return errors.New(event.ErrorCode)
+	default:
+		return fmt.Errorf("%s: %s", event.ErrorCode, event.ErrorMessage)
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// invocationContext replaces the context.Context and optionally the session
+// of an InvocationContext, keeping the rest of the invocation, e.g. its ID and
+// branch.
+type invocationContext struct {
+	agent.InvocationContext
+	ctx     context.Context
+	session session.Session
+}
+
+func (c *invocationContext) Session() session.Session {
+	if c.session != nil {
+		return c.session
+	}
+	return c.InvocationContext.Session()
+}
+
+func (c *invocationContext) Deadline() (time.Time, bool) {
+	return c.ctx.Deadline()
+}
+
+func (c *invocationContext) Done() <-chan struct{} {
+	return c.ctx.Done()
+}
+
+func (c *invocationContext) Err() error {
+	return c.ctx.Err()
+}
+
+func (c *invocationContext) Value(key any) any {
+	return c.ctx.Value(key)
+}
+
+// attemptSession is the scratch session of a suppressed attempt. It extends
+// the underlying session with the events of the attempt and keeps the state
+// changes of the attempt to itself, so nothing of a failed attempt reaches
+// the session.
+type attemptSession struct {
+	session.Session
+
+	mu     sync.RWMutex
+	events []*session.Event
+	state  map[string]any
+}
+
+func newAttemptSession(s session.Session) *attemptSession {
+	return &attemptSession{Session: s, state: make(map[string]any)}
+}
+
+// appendEvent adds an event of the attempt and applies its state delta. Like
+// the runner, it skips partial and forwarded events.
+func (s *attemptSession) appendEvent(event *session.Event) {
+	if event.Partial || event.Forwarded {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	maps.Copy(s.state, event.Actions.StateDelta)
+}
+
+func (s *attemptSession) State() session.State {
+	return (*attemptState)(s)
+}
+
+func (s *attemptSession) Events() session.Events {
+	return (*attemptEvents)(s)
+}
+
+type attemptState attemptSession
+
+func (s *attemptState) Get(key string) (any, error) {
+	s.mu.RLock()
+	value, ok := s.state[key]
+	s.mu.RUnlock()
+	if ok {
+		return value, nil
+	}
+	return s.Session.State().Get(key)
+}
+
+func (s *attemptState) Set(key string, value any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state[key] = value
+	return nil
+}
+
+func (s *attemptState) All() iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		s.mu.RLock()
+		state := maps.Clone(s.state)
+		s.mu.RUnlock()
+		for key, value := range s.Session.State().All() {
+			if _, ok := state[key]; ok {
+				continue
+			}
+			if !yield(key, value) {
+				return
+			}
+		}
+		for key, value := range state {
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+type attemptEvents attemptSession
+
+func (e *attemptEvents) All() iter.Seq[*session.Event] {
+	return func(yield func(*session.Event) bool) {
+		for event := range e.Session.Events().All() {
+			if !yield(event) {
+				return
+			}
+		}
+		e.mu.RLock()
+		events := slices.Clone(e.events)
+		e.mu.RUnlock()
+		for _, event := range events {
+			if !yield(event) {
+				return
+			}
+		}
+	}
+}
+
+func (e *attemptEvents) Len() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.Session.Events().Len() + len(e.events)
+}
+
+func (e *attemptEvents) At(i int) *session.Event {
+	if n := e.Session.Events().Len(); i >= n {
+		e.mu.RLock()
+		defer e.mu.RUnlock()
+		return e.events[i-n]
+	}
+	return e.Session.Events().At(i)
+}
@@ -0,0 +1,406 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retryagent_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/workflowagents/retryagent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+// failure modes of the flaky agent
+const (
+	failWithError = iota
+	failWithErrorEvent
+	failWithTimeout
+)
+
+func TestRetryAgent(t *testing.T) {
+	tests := []struct {
+		name        string
+		failures    int // number of failing attempts before success
+		failureMode int
+		cfg         retryagent.Config
+		fallback    bool
+		wantEvents  []summary
+		wantErr     bool
+	}{
+		{
+			name:       "success on first attempt",
+			cfg:        retryagent.Config{MaxAttempts: 3},
+			wantEvents: []summary{{Author: "flaky", Text: "attempt 1 ok", Attempt: 1}},
+		},
+		{
+			name:        "retry after error",
+			failures:    2,
+			failureMode: failWithError,
+			cfg:         retryagent.Config{MaxAttempts: 3},
+			wantEvents: []summary{
+				{Author: "retry", Attempt: 1, Error: "attempt 1 failed"},
+				{Author: "retry", Attempt: 2, Error: "attempt 2 failed"},
+				{Author: "flaky", Text: "attempt 3 ok", Attempt: 3},
+			},
+		},
+		{
+			name:        "retry after error event",
+			failures:    1,
+			failureMode: failWithErrorEvent,
+			cfg:         retryagent.Config{MaxAttempts: 3},
+			wantEvents: []summary{
+				{Author: "flaky", Attempt: 1},
+				{Author: "retry", Attempt: 1, Error: "attempt 1 failed"},
+				{Author: "flaky", Text: "attempt 2 ok", Attempt: 2},
+			},
+		},
+		{
+			name:        "retry after timeout",
+			failures:    1,
+			failureMode: failWithTimeout,
+			cfg:         retryagent.Config{MaxAttempts: 2, AttemptTimeout: 10 * time.Millisecond},
+			wantEvents: []summary{
+				{Author: "retry", Attempt: 1, Error: "attempt timed out after 10ms: context deadline exceeded"},
+				{Author: "flaky", Text: "attempt 2 ok", Attempt: 2},
+			},
+		},
+		{
+			name:        "suppress failed attempts",
+			failures:    2,
+			failureMode: failWithErrorEvent,
+			cfg:         retryagent.Config{MaxAttempts: 3, FailedAttempts: retryagent.FailedAttemptsSuppress},
+			wantEvents:  []summary{{Author: "flaky", Text: "attempt 3 ok"}},
+		},
+		{
+			name:        "fallback",
+			failures:    2,
+			failureMode: failWithError,
+			cfg:         retryagent.Config{MaxAttempts: 2, FailedAttempts: retryagent.FailedAttemptsSuppress},
+			fallback:    true,
+			wantEvents:  []summary{{Author: "fallback", Text: "fallback"}},
+		},
+		{
+			name:        "all attempts fail",
+			failures:    2,
+			failureMode: failWithError,
+			cfg:         retryagent.Config{MaxAttempts: 2},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.AgentConfig = agent.Config{
+				Name:      "retry",
+				SubAgents: []agent.Agent{newFlakyAgent(t, tt.failures, tt.failureMode)},
+			}
+			tt.cfg.InitialBackoff = time.Millisecond
+			if tt.fallback {
+				tt.cfg.Fallback = newTextAgent(t, "fallback")
+			}
+			retryAgent, err := retryagent.New(tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			events, err := runAgent(t, retryAgent)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.wantEvents, events); diff != "" {
+				t.Errorf("events mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRetryAgent_Timeout(t *testing.T) {
+	// The first attempt hangs until the overall timeout, so neither the
+	// second attempt nor the fallback runs.
+	retryAgent, err := retryagent.New(retryagent.Config{
+		AgentConfig: agent.Config{
+			Name:      "retry",
+			SubAgents: []agent.Agent{newFlakyAgent(t, 1, failWithTimeout)},
+		},
+		MaxAttempts:    2,
+		InitialBackoff: time.Millisecond,
+		Timeout:        10 * time.Millisecond,
+		Fallback:       newTextAgent(t, "fallback"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = runAgent(t, retryAgent)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if want := `agent "retry" timed out after 10ms`; !strings.Contains(err.Error(), want) {
+		t.Errorf("got error %q, want it to contain %q", err, want)
+	}
+}
+
+func TestNew_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  retryagent.Config
+	}{
+		{
+			name: "no sub-agent",
+			cfg:  retryagent.Config{AgentConfig: agent.Config{Name: "retry"}},
+		},
+		{
+			name: "negative max attempts",
+			cfg:  retryagent.Config{AgentConfig: agent.Config{Name: "retry", SubAgents: []agent.Agent{newTextAgent(t, "a")}}, MaxAttempts: -1},
+		},
+		{
+			name: "backoff multiplier below 1",
+			cfg:  retryagent.Config{AgentConfig: agent.Config{Name: "retry", SubAgents: []agent.Agent{newTextAgent(t, "a")}}, BackoffMultiplier: 0.5},
+		},
+		{
+			name: "unknown failed attempts policy",
+			cfg:  retryagent.Config{AgentConfig: agent.Config{Name: "retry", SubAgents: []agent.Agent{newTextAgent(t, "a")}}, FailedAttempts: "drop"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := retryagent.New(tt.cfg); err == nil {
+				t.Error("New() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestRetryAgent_SuppressedAttemptSession(t *testing.T) {
+	ctx := t.Context()
+
+	// Each attempt records its number in the state, both directly and via an
+	// event, and reads it back together with the events of the session.
+	attempt := 0
+	wrapped, err := agent.New(agent.Config{
+		Name: "reader",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				attempt++
+				if err := ctx.Session().State().Set("direct", attempt); err != nil {
+					yield(nil, err)
+					return
+				}
+				event := session.NewEvent(ctx.InvocationID())
+				event.Author = "reader"
+				event.Content = genai.NewContentFromText(fmt.Sprintf("step of attempt %d", attempt), genai.RoleModel)
+				event.Actions.StateDelta["step"] = attempt
+				if !yield(event, nil) {
+					return
+				}
+
+				step, err := ctx.Session().State().Get("step")
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				var texts []string
+				for event := range ctx.Session().Events().All() {
+					if event.Content != nil {
+						texts = append(texts, event.Content.Parts[0].Text)
+					}
+				}
+				if attempt < 2 {
+					yield(nil, fmt.Errorf("attempt %d failed", attempt))
+					return
+				}
+				event = session.NewEvent(ctx.InvocationID())
+				event.Author = "reader"
+				event.Content = genai.NewContentFromText(fmt.Sprintf("step %v, events %q", step, texts), genai.RoleModel)
+				yield(event, nil)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	retryAgent, err := retryagent.New(retryagent.Config{
+		AgentConfig:    agent.Config{Name: "retry", SubAgents: []agent.Agent{wrapped}},
+		MaxAttempts:    2,
+		InitialBackoff: time.Millisecond,
+		FailedAttempts: retryagent.FailedAttemptsSuppress,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sessionService := session.InMemoryService()
+	agentRunner, err := runner.New(runner.Config{
+		AppName:        "test_app",
+		Agent:          retryAgent,
+		SessionService: sessionService,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sessionService.Create(ctx, &session.CreateRequest{AppName: "test_app", UserID: "user_id", SessionID: "session_id"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, err := range agentRunner.Run(ctx, "user_id", "session_id", genai.NewContentFromText("user input", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "test_app", UserID: "user_id", SessionID: "session_id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for event := range resp.Session.Events().All() {
+		got = append(got, event.Content.Parts[0].Text)
+	}
+	want := []string{
+		"user input",
+		"step of attempt 2",
+		`step 2, events ["user input" "step of attempt 2"]`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("session events mismatch (-want +got):\n%s", diff)
+	}
+	gotState := make(map[string]any)
+	for key, value := range resp.Session.State().All() {
+		gotState[key] = value
+	}
+	if diff := cmp.Diff(map[string]any{"step": 2}, gotState); diff != "" {
+		t.Errorf("session state mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// summary holds the fields of an event relevant for the tests.
+type summary struct {
+	Author  string
+	Text    string
+	Attempt any
+	Error   any
+}
+
+func runAgent(t *testing.T, a agent.Agent) ([]summary, error) {
+	t.Helper()
+	ctx := t.Context()
+
+	sessionService := session.InMemoryService()
+	agentRunner, err := runner.New(runner.Config{
+		AppName:        "test_app",
+		Agent:          a,
+		SessionService: sessionService,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = sessionService.Create(ctx, &session.CreateRequest{
+		AppName:   "test_app",
+		UserID:    "user_id",
+		SessionID: "session_id",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events []summary
+	for event, err := range agentRunner.Run(ctx, "user_id", "session_id", genai.NewContentFromText("user input", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			return nil, err
+		}
+		s := summary{
+			Author:  event.Author,
+			Attempt: event.CustomMetadata[retryagent.MetadataKeyAttempt],
+			Error:   event.CustomMetadata[retryagent.MetadataKeyError],
+		}
+		if event.Content != nil {
+			s.Text = event.Content.Parts[0].Text
+		}
+		events = append(events, s)
+	}
+	return events, nil
+}
+
+// newFlakyAgent returns an agent which fails the first failures runs.
+func newFlakyAgent(t *testing.T, failures, mode int) agent.Agent {
+	t.Helper()
+
+	attempt := 0
+	a, err := agent.New(agent.Config{
+		Name: "flaky",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				attempt++
+				if attempt > failures {
+					yield(&session.Event{
+						LLMResponse: model.LLMResponse{
+							Content: genai.NewContentFromText(fmt.Sprintf("attempt %d ok", attempt), genai.RoleModel),
+						},
+					}, nil)
+					return
+				}
+				switch mode {
+				case failWithError:
+					yield(nil, fmt.Errorf("attempt %d failed", attempt))
+				case failWithErrorEvent:
+					yield(&session.Event{
+						LLMResponse: model.LLMResponse{ErrorMessage: fmt.Sprintf("attempt %d failed", attempt)},
+					}, nil)
+				case failWithTimeout:
+					<-ctx.Done()
+					yield(nil, ctx.Err())
+				}
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func newTextAgent(t *testing.T, name string) agent.Agent {
+	t.Helper()
+
+	a, err := agent.New(agent.Config{
+		Name: name,
+		Run: func(agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				yield(&session.Event{
+					LLMResponse: model.LLMResponse{
+						Content: genai.NewContentFromText(name, genai.RoleModel),
+					},
+				}, nil)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
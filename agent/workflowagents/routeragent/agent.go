@@ -0,0 +1,234 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package routeragent provides an agent that runs exactly one of its sub-agents,
+// chosen by Go predicates or by an LLM classification call.
+package routeragent
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	agentinternal "google.golang.org/adk/internal/agent"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/utils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+// MetadataKeyRoute is the key in session.Event.CustomMetadata under which
+// the router records the name of the chosen sub-agent.
+const MetadataKeyRoute = "adk_route"
+
+// Condition decides whether a Route should be taken. It has access to the
+// session state and the user content that started the invocation.
+type Condition func(ctx agent.ReadonlyContext) (bool, error)
+
+// Route maps a Condition to the sub-agent that runs when it's satisfied.
+type Route struct {
+	// Agent is the name of the sub-agent to run. It must be one of the
+	// sub-agents listed in Config.AgentConfig.SubAgents.
+	Agent string
+	// Condition is evaluated when the router runs. Routes are evaluated in
+	// the order they are listed and the first satisfied one is taken.
+	Condition Condition
+}
+
+// Config defines the configuration for a RouterAgent.
+type Config struct {
+	// Basic agent setup.
+	AgentConfig agent.Config
+
+	// Routes are evaluated in order. The first Route whose Condition returns
+	// true selects the sub-agent to run.
+	Routes []Route
+
+	// Classifier is an optional LLM used to select a sub-agent when none of
+	// the Routes match. The model is given the names and descriptions of the
+	// sub-agents together with the user content and is expected to reply
+	// with the name of a single sub-agent.
+	Classifier model.LLM
+	// ClassifierInstruction overrides the default system instruction sent to
+	// the Classifier. The list of sub-agents is always appended to it.
+	ClassifierInstruction string
+
+	// DefaultAgent is the name of the sub-agent to run when neither Routes
+	// nor Classifier select one. If empty, the router fails in that case.
+	DefaultAgent string
+}
+
+// New creates a RouterAgent.
+//
+// RouterAgent runs exactly one of its sub-agents. The sub-agent is chosen by
+// evaluating Routes in order, then by asking the Classifier, and finally by
+// falling back to DefaultAgent.
+//
+// Before running the chosen sub-agent, the router emits an event authored by
+// itself which records the chosen sub-agent name under MetadataKeyRoute in
+// the event's CustomMetadata.
+//
+// Use the RouterAgent when your workflow needs to branch, such as dispatching
+// a request to a specialized agent based on its category.
+func New(cfg Config) (agent.Agent, error) {
+	if cfg.AgentConfig.Run != nil {
+		return nil, fmt.Errorf("RouterAgent doesn't allow custom Run implementations")
+	}
+
+	subAgents := make(map[string]agent.Agent, len(cfg.AgentConfig.SubAgents))
+	for _, sa := range cfg.AgentConfig.SubAgents {
+		subAgents[sa.Name()] = sa
+	}
+	for i, route := range cfg.Routes {
+		if route.Condition == nil {
+			return nil, fmt.Errorf("route %d: condition is required", i)
+		}
+		if _, ok := subAgents[route.Agent]; !ok {
+			return nil, fmt.Errorf("route %d: %q is not a sub-agent", i, route.Agent)
+		}
+	}
+	if cfg.DefaultAgent != "" {
+		if _, ok := subAgents[cfg.DefaultAgent]; !ok {
+			return nil, fmt.Errorf("default agent %q is not a sub-agent", cfg.DefaultAgent)
+		}
+	}
+
+	routerAgentImpl := &routerAgent{
+		routes:                cfg.Routes,
+		classifier:            cfg.Classifier,
+		classifierInstruction: cfg.ClassifierInstruction,
+		defaultAgent:          cfg.DefaultAgent,
+		subAgents:             subAgents,
+	}
+	cfg.AgentConfig.Run = routerAgentImpl.Run
+
+	routerAgent, err := agent.New(cfg.AgentConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create base agent: %w", err)
+	}
+
+	internalAgent, ok := routerAgent.(agentinternal.Agent)
+	if !ok {
+		return nil, fmt.Errorf("internal error: failed to convert to internal agent")
+	}
+	state := agentinternal.Reveal(internalAgent)
+	state.AgentType = agentinternal.TypeRouterAgent
+	state.Config = cfg
+
+	return routerAgent, nil
+}
+
+type routerAgent struct {
+	routes                []Route
+	classifier            model.LLM
+	classifierInstruction string
+	defaultAgent          string
+	subAgents             map[string]agent.Agent
+}
+
+func (a *routerAgent) Run(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+	return func(yield func(*session.Event, error) bool) {
+		name, err := a.route(ctx)
+		if err != nil {
+			yield(nil, fmt.Errorf("router %q: %w", ctx.Agent().Name(), err))
+			return
+		}
+
+		event := session.NewEvent(ctx.InvocationID())
+		event.Author = ctx.Agent().Name()
+		event.Branch = ctx.Branch()
+		event.CustomMetadata = map[string]any{MetadataKeyRoute: name}
+		if !yield(event, nil) {
+			return
+		}
+
+		for event, err := range a.subAgents[name].Run(ctx) {
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+// route returns the name of the sub-agent which should handle the request.
+func (a *routerAgent) route(ctx agent.InvocationContext) (string, error) {
+	rctx := icontext.NewReadonlyContext(ctx)
+	for i, route := range a.routes {
+		ok, err := route.Condition(rctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to evaluate route %d: %w", i, err)
+		}
+		if ok {
+			return route.Agent, nil
+		}
+	}
+
+	if a.classifier != nil {
+		name, err := a.classify(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to classify request: %w", err)
+		}
+		if _, ok := a.subAgents[name]; ok {
+			return name, nil
+		}
+	}
+
+	if a.defaultAgent == "" {
+		return "", fmt.Errorf("no route matched and no default agent is configured")
+	}
+	return a.defaultAgent, nil
+}
+
+const defaultClassifierInstruction = `You are a request router. Decide which one of the agents listed below is the best fit to handle the user's request.
+Reply with the exact name of that agent and nothing else. If none of them fits, reply with "none".`
+
+// classify asks the classifier model to choose a sub-agent. It returns the
+// trimmed model reply, which may not be a valid sub-agent name.
+func (a *routerAgent) classify(ctx agent.InvocationContext) (string, error) {
+	instruction := a.classifierInstruction
+	if instruction == "" {
+		instruction = defaultClassifierInstruction
+	}
+	var sb strings.Builder
+	sb.WriteString("\n\nAgents:\n")
+	for _, sa := range ctx.Agent().SubAgents() {
+		fmt.Fprintf(&sb, "- %s: %s\n", sa.Name(), sa.Description())
+	}
+
+	req := &model.LLMRequest{
+		Model:  a.classifier.Name(),
+		Config: &genai.GenerateContentConfig{},
+	}
+	utils.AppendInstructions(req, instruction+sb.String())
+	if userContent := ctx.UserContent(); userContent != nil {
+		req.Contents = append(req.Contents, userContent)
+	}
+
+	var reply strings.Builder
+	for resp, err := range a.classifier.GenerateContent(ctx, req, false) {
+		if err != nil {
+			return "", err
+		}
+		if resp == nil || resp.Content == nil {
+			continue
+		}
+		for _, text := range utils.TextParts(resp.Content) {
+			reply.WriteString(text)
+		}
+	}
+	return strings.Trim(strings.TrimSpace(reply.String()), "`\"'."), nil
+}
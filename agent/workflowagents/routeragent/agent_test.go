@@ -0,0 +1,259 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routeragent_test
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/workflowagents/routeragent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+func TestRouterAgent(t *testing.T) {
+	stateEquals := func(key, want string) routeragent.Condition {
+		return func(ctx agent.ReadonlyContext) (bool, error) {
+			v, err := ctx.ReadonlyState().Get(key)
+			if err != nil {
+				return false, nil
+			}
+			return v == want, nil
+		}
+	}
+
+	tests := []struct {
+		name         string
+		state        map[string]any
+		routes       []routeragent.Route
+		classifier   model.LLM
+		defaultAgent string
+		wantRoute    string
+		wantErr      bool
+	}{
+		{
+			name:  "first matching route",
+			state: map[string]any{"topic": "billing"},
+			routes: []routeragent.Route{
+				{Agent: "tech", Condition: stateEquals("topic", "tech")},
+				{Agent: "billing", Condition: stateEquals("topic", "billing")},
+			},
+			defaultAgent: "tech",
+			wantRoute:    "billing",
+		},
+		{
+			name: "user content condition",
+			routes: []routeragent.Route{
+				{Agent: "tech", Condition: func(ctx agent.ReadonlyContext) (bool, error) {
+					return strings.Contains(ctx.UserContent().Parts[0].Text, "laptop"), nil
+				}},
+			},
+			wantRoute: "tech",
+		},
+		{
+			name:         "default route",
+			state:        map[string]any{"topic": "other"},
+			routes:       []routeragent.Route{{Agent: "tech", Condition: stateEquals("topic", "tech")}},
+			defaultAgent: "billing",
+			wantRoute:    "billing",
+		},
+		{
+			name:       "classifier",
+			classifier: &fakeClassifier{reply: " billing\n"},
+			wantRoute:  "billing",
+		},
+		{
+			name:         "classifier fallback to default",
+			classifier:   &fakeClassifier{reply: "none"},
+			defaultAgent: "tech",
+			wantRoute:    "tech",
+		},
+		{
+			name:    "no route",
+			routes:  []routeragent.Route{{Agent: "tech", Condition: stateEquals("topic", "tech")}},
+			wantErr: true,
+		},
+		{
+			name: "condition error",
+			routes: []routeragent.Route{{Agent: "tech", Condition: func(agent.ReadonlyContext) (bool, error) {
+				return false, fmt.Errorf("boom")
+			}}},
+			defaultAgent: "tech",
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+
+			routerAgent, err := routeragent.New(routeragent.Config{
+				AgentConfig: agent.Config{
+					Name:      "router",
+					SubAgents: []agent.Agent{newCustomAgent(t, "tech"), newCustomAgent(t, "billing")},
+				},
+				Routes:       tt.routes,
+				Classifier:   tt.classifier,
+				DefaultAgent: tt.defaultAgent,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			events, err := runAgent(ctx, t, routerAgent, tt.state, "my laptop is broken")
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(events) != 2 {
+				t.Fatalf("got %d events, want 2", len(events))
+			}
+			if events[0].Author != "router" {
+				t.Errorf("routing event author = %q, want %q", events[0].Author, "router")
+			}
+			if diff := cmp.Diff(map[string]any{routeragent.MetadataKeyRoute: tt.wantRoute}, events[0].CustomMetadata); diff != "" {
+				t.Errorf("routing event metadata mismatch (-want +got):\n%s", diff)
+			}
+			if events[1].Author != tt.wantRoute {
+				t.Errorf("got response from %q, want %q", events[1].Author, tt.wantRoute)
+			}
+		})
+	}
+}
+
+func TestNew_Validation(t *testing.T) {
+	subAgents := []agent.Agent{newCustomAgent(t, "tech")}
+	always := func(agent.ReadonlyContext) (bool, error) { return true, nil }
+
+	tests := []struct {
+		name string
+		cfg  routeragent.Config
+	}{
+		{
+			name: "unknown route agent",
+			cfg: routeragent.Config{
+				AgentConfig: agent.Config{Name: "router", SubAgents: subAgents},
+				Routes:      []routeragent.Route{{Agent: "billing", Condition: always}},
+			},
+		},
+		{
+			name: "missing condition",
+			cfg: routeragent.Config{
+				AgentConfig: agent.Config{Name: "router", SubAgents: subAgents},
+				Routes:      []routeragent.Route{{Agent: "tech"}},
+			},
+		},
+		{
+			name: "unknown default agent",
+			cfg: routeragent.Config{
+				AgentConfig:  agent.Config{Name: "router", SubAgents: subAgents},
+				DefaultAgent: "billing",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := routeragent.New(tt.cfg); err == nil {
+				t.Error("New() succeeded, want error")
+			}
+		})
+	}
+}
+
+func runAgent(ctx context.Context, t *testing.T, a agent.Agent, state map[string]any, msg string) ([]*session.Event, error) {
+	t.Helper()
+
+	sessionService := session.InMemoryService()
+	agentRunner, err := runner.New(runner.Config{
+		AppName:        "test_app",
+		Agent:          a,
+		SessionService: sessionService,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = sessionService.Create(ctx, &session.CreateRequest{
+		AppName:   "test_app",
+		UserID:    "user_id",
+		SessionID: "session_id",
+		State:     state,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events []*session.Event
+	for event, err := range agentRunner.Run(ctx, "user_id", "session_id", genai.NewContentFromText(msg, genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func newCustomAgent(t *testing.T, name string) agent.Agent {
+	t.Helper()
+
+	a, err := agent.New(agent.Config{
+		Name:        name,
+		Description: fmt.Sprintf("handles %s requests", name),
+		Run: func(agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				yield(&session.Event{
+					LLMResponse: model.LLMResponse{
+						Content: genai.NewContentFromText("hello from "+name, genai.RoleModel),
+					},
+				}, nil)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+type fakeClassifier struct {
+	reply string
+}
+
+func (f *fakeClassifier) Name() string {
+	return "fake-classifier"
+}
+
+func (f *fakeClassifier) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		if req.Config == nil || req.Config.SystemInstruction == nil {
+			yield(nil, fmt.Errorf("missing system instruction"))
+			return
+		}
+		yield(&model.LLMResponse{
+			Content: genai.NewContentFromText(f.reply, genai.RoleModel),
+		}, nil)
+	}
+}
@@ -149,7 +149,7 @@ func TestNewSequentialAgent(t *testing.T) {
 				}, "test_agent"), newCustomAgent(t, 3)},
 			},
 			wantErr:        true,
-			wantErrMessage: `failed to create agent tree: agent names must be unique in the agent tree, found duplicate: "test_agent"`,
+			wantErrMessage: `invalid agent tree: agent "test_agent/test_agent": agent name "test_agent" is already used at "test_agent", agent names must be unique in the agent tree`,
 		},
 		{
 			name: "err with 2 levels of inner sequential with same name as parent ",
@@ -160,7 +160,7 @@ func TestNewSequentialAgent(t *testing.T) {
 				}, "test_agent1"), newCustomAgent(t, 3)},
 			},
 			wantErr:        true,
-			wantErrMessage: `failed to create agent tree: agent names must be unique in the agent tree, found duplicate: "test_agent1"`,
+			wantErrMessage: `invalid agent tree: agent "test_agent/test_agent1/test_agent1": agent name "test_agent1" is already used at "test_agent/test_agent1", agent names must be unique in the agent tree`,
 		},
 		{
 			name: "err with repeated inner sequential",
@@ -181,7 +181,7 @@ func TestNewSequentialAgent(t *testing.T) {
 				},
 			},
 			wantErr:        true,
-			wantErrMessage: `failed to create agent tree: "same_agent" agent cannot have >1 parents, found: "test_agent1", "test_agent"`,
+			wantErrMessage: `invalid agent tree: agent "test_agent/same_agent": agent instance is already used at "test_agent/test_agent1/same_agent", an agent can have at most one parent`,
 		},
 	}
 	for _, tt := range tests {
@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mcp provides a launcher serving agents as MCP tools over stdio, for
+// MCP clients which start the agent application as a subprocess.
+package mcp
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"google.golang.org/adk/cmd/launcher"
+	"google.golang.org/adk/cmd/launcher/universal"
+	"google.golang.org/adk/internal/cli/util"
+	"google.golang.org/adk/server/adkmcp"
+)
+
+// mcpConfig contains command-line params for MCP launcher
+type mcpConfig struct {
+	exportTools bool
+}
+
+// mcpLauncher serves agents over MCP on stdin and stdout
+type mcpLauncher struct {
+	flags  *flag.FlagSet // flags are used to parse command-line arguments
+	config *mcpConfig    // config contains parsed command-line parameters
+}
+
+// NewLauncher creates new MCP stdio launcher
+func NewLauncher() launcher.SubLauncher {
+	config := &mcpConfig{}
+
+	fs := flag.NewFlagSet("mcp", flag.ContinueOnError)
+	fs.BoolVar(&config.exportTools, "export_tools", false, "publishes the tools of the agents as MCP tools as well")
+
+	return &mcpLauncher{config: config, flags: fs}
+}
+
+// Run implements launcher.SubLauncher. It serves MCP on stdin and stdout
+// until the client disconnects. Nothing else may be written to stdout.
+func (l *mcpLauncher) Run(ctx context.Context, config *launcher.Config) error {
+	server, err := adkmcp.NewServer(adkmcp.Config{
+		AgentLoader:     config.AgentLoader,
+		SessionService:  config.SessionService,
+		ArtifactService: config.ArtifactService,
+		MemoryService:   config.MemoryService,
+		ExportTools:     l.config.exportTools,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create MCP server: %w", err)
+	}
+	return server.Run(ctx, &mcp.StdioTransport{})
+}
+
+// Parse implements launcher.SubLauncher. After parsing MCP-specific
+// arguments returns remaining un-parsed arguments
+func (l *mcpLauncher) Parse(args []string) ([]string, error) {
+	err := l.flags.Parse(args)
+	if err != nil || !l.flags.Parsed() {
+		return nil, fmt.Errorf("failed to parse flags: %v", err)
+	}
+	return l.flags.Args(), nil
+}
+
+// Keyword implements launcher.SubLauncher. Returns the command-line keyword for this launcher.
+func (l *mcpLauncher) Keyword() string {
+	return "mcp"
+}
+
+// CommandLineSyntax implements launcher.SubLauncher. Returns the command-line syntax for the MCP launcher.
+func (l *mcpLauncher) CommandLineSyntax() string {
+	return util.FormatFlagUsage(l.flags)
+}
+
+// SimpleDescription implements launcher.SubLauncher. Returns a simple description of the MCP launcher.
+func (l *mcpLauncher) SimpleDescription() string {
+	return "serves agents as MCP tools over stdio."
+}
+
+// Execute implements launcher.Launcher. It parses arguments and runs the launcher.
+func (l *mcpLauncher) Execute(ctx context.Context, config *launcher.Config, args []string) error {
+	remainingArgs, err := l.Parse(args)
+	if err != nil {
+		return fmt.Errorf("cannot parse args: %w", err)
+	}
+	// do not accept additional arguments
+	err = universal.ErrorOnUnparsedArgs(remainingArgs)
+	if err != nil {
+		return fmt.Errorf("cannot parse all the arguments: %w", err)
+	}
+	return l.Run(ctx, config)
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mcp provides a sublauncher that serves agents as MCP tools over
+// the streamable HTTP transport.
+package mcp
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/gorilla/mux"
+
+	"google.golang.org/adk/cmd/launcher"
+	"google.golang.org/adk/cmd/launcher/web"
+	"google.golang.org/adk/internal/cli/util"
+	"google.golang.org/adk/server/adkmcp"
+)
+
+// mcpPath is the path of the MCP endpoint
+const mcpPath = "/mcp"
+
+// mcpConfig contains parameters for launching ADK MCP server
+type mcpConfig struct {
+	exportTools bool
+}
+
+type mcpLauncher struct {
+	flags  *flag.FlagSet // flags are used to parse command-line arguments
+	config *mcpConfig
+}
+
+// NewLauncher creates new MCP launcher. It extends Web launcher
+func NewLauncher() web.Sublauncher {
+	config := &mcpConfig{}
+
+	fs := flag.NewFlagSet("mcp", flag.ContinueOnError)
+	fs.BoolVar(&config.exportTools, "export_tools", false, "publishes the tools of the agents as MCP tools as well")
+
+	return &mcpLauncher{
+		config: config,
+		flags:  fs,
+	}
+}
+
+// CommandLineSyntax implements web.Sublauncher. Returns the command-line syntax for the MCP launcher.
+func (m *mcpLauncher) CommandLineSyntax() string {
+	return util.FormatFlagUsage(m.flags)
+}
+
+// Keyword implements web.Sublauncher. Returns the command-line keyword for MCP launcher.
+func (m *mcpLauncher) Keyword() string {
+	return "mcp"
+}
+
+// Parse parses the command-line arguments for the MCP launcher.
+func (m *mcpLauncher) Parse(args []string) ([]string, error) {
+	err := m.flags.Parse(args)
+	if err != nil || !m.flags.Parsed() {
+		return nil, fmt.Errorf("failed to parse mcp flags: %v", err)
+	}
+	return m.flags.Args(), nil
+}
+
+// SetupSubrouters implements web.Sublauncher. It adds the MCP endpoint to the main router.
+func (m *mcpLauncher) SetupSubrouters(router *mux.Router, config *launcher.Config) error {
+	handler, err := adkmcp.NewHandler(adkmcp.Config{
+		AgentLoader:     config.AgentLoader,
+		SessionService:  config.SessionService,
+		ArtifactService: config.ArtifactService,
+		MemoryService:   config.MemoryService,
+		ExportTools:     m.config.exportTools,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create MCP handler: %w", err)
+	}
+	router.Handle(mcpPath, handler)
+	return nil
+}
+
+// SimpleDescription implements web.Sublauncher.
+func (m *mcpLauncher) SimpleDescription() string {
+	return fmt.Sprintf("starts MCP server which handles streamable HTTP requests on %s path", mcpPath)
+}
+
+// UserMessage implements web.Sublauncher.
+func (m *mcpLauncher) UserMessage(webURL string, printer func(v ...any)) {
+	printer(fmt.Sprintf("       mcp:  you can access MCP using streamable HTTP: %s%s", webURL, mcpPath))
+}
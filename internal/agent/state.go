@@ -31,9 +31,21 @@ const (
 	TypeLoopAgent       Type = "LoopAgent"
 	TypeSequentialAgent Type = "SequentialAgent"
 	TypeParallelAgent   Type = "ParallelAgent"
+	TypeRouterAgent     Type = "RouterAgent"
+	TypeGraphAgent      Type = "GraphAgent"
+	TypeMapAgent        Type = "MapAgent"
+	TypeRetryAgent      Type = "RetryAgent"
 	TypeCustomAgent     Type = "CustomAgent"
 )
 
+// ToolOwner is implemented by agents with tools, such as LLM agents. It
+// allows validating the tool names as part of the agent tree validation.
+type ToolOwner interface {
+	// ToolNames returns the names of the tools configured for the agent.
+	// Tools provided dynamically by toolsets are not included.
+	ToolNames() []string
+}
+
 func (s *State) internal() *State { return s }
 
 func Reveal(a Agent) *State { return a.internal() }
@@ -49,10 +49,24 @@ type State struct {
 	OutputSchema *genai.Schema
 
 	OutputKey string
+
+	MaxToolResultSize int
+
+	BeforeToolCallbacks []BeforeToolCallback
+	AfterToolCallbacks  []AfterToolCallback
 }
 
 type InstructionProvider func(ctx agent.ReadonlyContext) (string, error)
 
 func (s *State) internal() *State { return s }
 
+// ToolNames returns the names of the agent's tools, not including toolsets.
+func (s *State) ToolNames() []string {
+	names := make([]string, 0, len(s.Tools))
+	for _, t := range s.Tools {
+		names = append(names, t.Name())
+	}
+	return names
+}
+
 func Reveal(a Agent) *State { return a.internal() }
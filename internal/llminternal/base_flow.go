@@ -27,6 +27,7 @@ import (
 	"google.golang.org/adk/internal/agent/parentmap"
 	"google.golang.org/adk/internal/agent/runconfig"
 	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/llminternal/googlellm"
 	"google.golang.org/adk/internal/telemetry"
 	"google.golang.org/adk/internal/toolinternal"
 	"google.golang.org/adk/internal/utils"
@@ -167,7 +168,19 @@ func (f *Flow) runOneStep(ctx agent.InvocationContext) iter.Seq2[*session.Event,
 
 			// Handle function calls.
 
-			ev, err := f.handleFunctionCalls(ctx, tools, resp)
+			// Tools can forward events, e.g. of the agents they wrap, before
+			// the function response event.
+			stopped := false
+			forward := func(ev *session.Event) bool {
+				if !stopped && !yield(ev, nil) {
+					stopped = true
+				}
+				return !stopped
+			}
+			ev, err := f.handleFunctionCalls(ctx, tools, resp, forward)
+			if stopped {
+				return
+			}
 			if err != nil {
 				yield(nil, err)
 				return
@@ -216,7 +229,7 @@ func (f *Flow) preprocess(ctx agent.InvocationContext, req *model.LLMRequest) er
 	}
 
 	// run processors for tools.
-	tools := Reveal(llmAgent).Tools
+	tools := slices.Clone(Reveal(llmAgent).Tools)
 	for _, toolSet := range Reveal(llmAgent).Toolsets {
 		tsTools, err := toolSet.Tools(icontext.NewReadonlyContext(ctx))
 		if err != nil {
@@ -226,13 +239,32 @@ func (f *Flow) preprocess(ctx agent.InvocationContext, req *model.LLMRequest) er
 		tools = append(tools, tsTools...)
 	}
 
-	return toolPreprocess(ctx, req, tools)
+	if err := toolPreprocess(ctx, req, tools); err != nil {
+		return err
+	}
+	// Only the tools of Google LLMs are known, the tools of other models are
+	// left to them to check.
+	if llm, ok := f.Model.(googlellm.GoogleLLM); ok && req.Config != nil {
+		if err := googlellm.ValidateTools(llm.GetGoogleLLMVariant(), req.Model, req.Config.Tools); err != nil {
+			return fmt.Errorf("agent %q uses incompatible tools: %w", ctx.Agent().Name(), err)
+		}
+	}
+	return nil
 }
 
 // toolPreprocess runs tool preprocess on the given request
 // If a tool set is encountered, it's expanded recursively in DFS fashion.
 // TODO: check need/feasibility of running this concurrently.
 func toolPreprocess(ctx agent.InvocationContext, req *model.LLMRequest, tools []tool.Tool) error {
+	// Tools with the same name would replace each other in req.Tools.
+	names := make(map[string]bool, len(tools))
+	for _, t := range tools {
+		if names[t.Name()] {
+			return fmt.Errorf("agent %q has several tools named %q, rename them with tool.PrefixToolset or remove them with tool.FilterToolset", ctx.Agent().Name(), t.Name())
+		}
+		names[t.Name()] = true
+	}
+
 	for _, t := range tools {
 		requestProcessor, ok := t.(toolinternal.RequestProcessor)
 		if !ok {
@@ -364,10 +396,11 @@ func findLongRunningFunctionCallIDs(c *genai.Content, tools map[string]tool.Tool
 }
 
 // handleFunctionCalls calls the functions and returns the function response event.
+// Events forwarded by the tools are passed to forward as they are produced.
 //
 // TODO: accept filters to include/exclude function calls.
 // TODO: check feasibility of running tool.Run concurrently.
-func (f *Flow) handleFunctionCalls(ctx agent.InvocationContext, toolsDict map[string]tool.Tool, resp *model.LLMResponse) (*session.Event, error) {
+func (f *Flow) handleFunctionCalls(ctx agent.InvocationContext, toolsDict map[string]tool.Tool, resp *model.LLMResponse, forward func(*session.Event) bool) (*session.Event, error) {
 	var fnResponseEvents []*session.Event
 
 	fnCalls := utils.FunctionCalls(resp.Content)
@@ -380,11 +413,14 @@ func (f *Flow) handleFunctionCalls(ctx agent.InvocationContext, toolsDict map[st
 		if !ok {
 			return nil, fmt.Errorf("tool %q is not a function tool", curTool.Name())
 		}
-		toolCtx := toolinternal.NewToolContext(ctx, fnCall.ID, &session.EventActions{StateDelta: make(map[string]any)})
+		toolCtx := toolinternal.NewForwardingToolContext(ctx, fnCall.ID, &session.EventActions{StateDelta: make(map[string]any)}, forward)
 		// toolCtx := tool.
 		spans := telemetry.StartTrace(ctx, "execute_tool "+fnCall.Name)
 
 		result := f.callTool(funcTool, fnCall.Args, toolCtx)
+		if llmAgent := asLLMAgent(ctx.Agent()); llmAgent != nil {
+			result = offloadToolResult(toolCtx, llmAgent.internal().MaxToolResultSize, fnCall, result)
+		}
 
 		// TODO: agent.canonical_after_tool_callbacks
 		// TODO: handle long-running tool.
@@ -403,6 +439,9 @@ func (f *Flow) handleFunctionCalls(ctx agent.InvocationContext, toolsDict map[st
 				},
 			},
 		}
+		if toolinternal.IsCached(toolCtx) {
+			ev.CustomMetadata = map[string]any{toolinternal.CachedFunctionCallsKey: []string{fnCall.ID}}
+		}
 		ev.Author = ctx.Agent().Name()
 		ev.Branch = ctx.Branch()
 		ev.Actions = *toolCtx.Actions()
@@ -420,17 +459,33 @@ func (f *Flow) handleFunctionCalls(ctx agent.InvocationContext, toolsDict map[st
 }
 
 func (f *Flow) callTool(tool toolinternal.FunctionTool, fArgs map[string]any, toolCtx tool.Context) map[string]any {
-	result, err := f.invokeBeforeToolCallbacks(tool, fArgs, toolCtx)
-	if result == nil && err == nil {
-		result, err = tool.Run(toolCtx, fArgs)
-	}
-	result, err = f.invokeAfterToolCallbacks(tool, fArgs, toolCtx, result, err)
+	result, err := f.runTool(tool, fArgs, toolCtx)
 	if err != nil {
 		return map[string]any{"error": err.Error()}
 	}
 	return result
 }
 
+// RunTool runs the tool of the agent a through the tool callbacks of a, like
+// the flow of a does. It is used to call the tools of an agent outside of
+// its flow.
+func RunTool(a Agent, tool toolinternal.FunctionTool, fArgs map[string]any, toolCtx tool.Context) (map[string]any, error) {
+	f := &Flow{
+		BeforeToolCallbacks: a.internal().BeforeToolCallbacks,
+		AfterToolCallbacks:  a.internal().AfterToolCallbacks,
+	}
+	return f.runTool(tool, fArgs, toolCtx)
+}
+
+func (f *Flow) runTool(tool toolinternal.FunctionTool, fArgs map[string]any, toolCtx tool.Context) (map[string]any, error) {
+	result, err := f.invokeBeforeToolCallbacks(tool, fArgs, toolCtx)
+	if result == nil && err == nil {
+		toolinternal.MarkRun(toolCtx)
+		result, err = tool.Run(toolCtx, fArgs)
+	}
+	return f.invokeAfterToolCallbacks(tool, fArgs, toolCtx, result, err)
+}
+
 func (f *Flow) invokeBeforeToolCallbacks(tool toolinternal.FunctionTool, fArgs map[string]any, toolCtx tool.Context) (map[string]any, error) {
 	for _, callback := range f.BeforeToolCallbacks {
 		result, err := callback(toolCtx, tool, fArgs)
@@ -471,12 +526,16 @@ func mergeParallelFunctionResponseEvents(events []*session.Event) (*session.Even
 	}
 	var parts []*genai.Part
 	var actions *session.EventActions
+	var cached []string
 	for _, ev := range events {
 		if ev == nil || ev.LLMResponse.Content == nil {
 			continue
 		}
 		parts = append(parts, ev.LLMResponse.Content.Parts...)
 		actions = mergeEventActions(actions, &ev.Actions)
+		if ids, ok := ev.CustomMetadata[toolinternal.CachedFunctionCallsKey].([]string); ok {
+			cached = append(cached, ids...)
+		}
 	}
 	// reuse events[0]
 	ev := events[0]
@@ -486,6 +545,9 @@ func mergeParallelFunctionResponseEvents(events []*session.Event) (*session.Even
 			Parts: parts,
 		},
 	}
+	if len(cached) > 0 {
+		ev.CustomMetadata = map[string]any{toolinternal.CachedFunctionCallsKey: cached}
+	}
 	ev.Actions = *actions
 	return ev, nil
 }
@@ -21,6 +21,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/genai"
 
+	"google.golang.org/adk/agent"
+	icontext "google.golang.org/adk/internal/context"
 	"google.golang.org/adk/internal/toolinternal"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/tool"
@@ -273,3 +275,35 @@ func TestCallTool(t *testing.T) {
 		})
 	}
 }
+
+func TestToolPreprocess_NameCollision(t *testing.T) {
+	a, err := agent.New(agent.Config{Name: "test_agent"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{Agent: a})
+
+	tests := []struct {
+		name    string
+		tools   []tool.Tool
+		wantErr bool
+	}{
+		{
+			name:  "distinct names",
+			tools: []tool.Tool{&mockFunctionTool{name: "a"}, &mockFunctionTool{name: "b"}},
+		},
+		{
+			name:    "same names",
+			tools:   []tool.Tool{&mockFunctionTool{name: "a"}, &mockFunctionTool{name: "b"}, &mockFunctionTool{name: "a"}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := toolPreprocess(ctx, &model.LLMRequest{}, tc.tools)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("toolPreprocess() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
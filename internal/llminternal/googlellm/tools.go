@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlellm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// builtInTools returns the names of the Gemini built-in tools of t.
+func builtInTools(t *genai.Tool) []string {
+	var names []string
+	if t.GoogleSearch != nil {
+		names = append(names, "google_search")
+	}
+	if t.GoogleSearchRetrieval != nil {
+		names = append(names, "google_search_retrieval")
+	}
+	if t.URLContext != nil {
+		names = append(names, "url_context")
+	}
+	if t.CodeExecution != nil {
+		names = append(names, "code_execution")
+	}
+	if t.GoogleMaps != nil {
+		names = append(names, "google_maps")
+	}
+	if t.EnterpriseWebSearch != nil {
+		names = append(names, "enterprise_web_search")
+	}
+	if t.Retrieval != nil {
+		names = append(names, "retrieval")
+	}
+	if t.ComputerUse != nil {
+		names = append(names, "computer_use")
+	}
+	if t.FileSearch != nil {
+		names = append(names, "file_search")
+	}
+	return names
+}
+
+// vertexAIOnly reports whether the built-in tool is only available on
+// Vertex AI.
+func vertexAIOnly(t *genai.Tool) (string, bool) {
+	switch {
+	case t.EnterpriseWebSearch != nil:
+		return "enterprise_web_search", true
+	case t.Retrieval != nil && (t.Retrieval.VertexAISearch != nil || t.Retrieval.VertexRAGStore != nil):
+		return "retrieval", true
+	}
+	return "", false
+}
+
+// ValidateTools checks that the tools of a request are supported together by
+// the model on the given variant:
+//   - tools only available on Vertex AI are not used with the Gemini API,
+//   - Gemini 1 models use a built-in tool alone, without other tools,
+//   - Gemini 2 models don't use built-in tools with function tools, except
+//     computer_use, which is used together with function tools.
+//
+// Requests to models other than Gemini 1 and 2 are only checked for the
+// variant.
+func ValidateTools(variant, model string, tools []*genai.Tool) error {
+	var builtIns []string
+	// exclusive holds the built-in tools which can't be used with function
+	// tools on Gemini 2 models.
+	var exclusive []string
+	hasFunctions := false
+	var errs []error
+	for _, t := range tools {
+		if t == nil {
+			continue
+		}
+		if len(t.FunctionDeclarations) > 0 {
+			hasFunctions = true
+		}
+		for _, name := range builtInTools(t) {
+			builtIns = append(builtIns, name)
+			if name != "computer_use" {
+				exclusive = append(exclusive, name)
+			}
+		}
+		if name, ok := vertexAIOnly(t); ok && variant != GoogleLLMVariantVertexAI {
+			errs = append(errs, fmt.Errorf("built-in tool %s is only supported on Vertex AI", name))
+		}
+	}
+	if len(builtIns) == 0 {
+		return errors.Join(errs...)
+	}
+
+	model = modelID(model)
+	switch {
+	case strings.HasPrefix(model, "gemini-1"):
+		if len(builtIns) > 1 || hasFunctions {
+			errs = append(errs, fmt.Errorf("model %s supports built-in tool %s only without other tools", model, builtIns[0]))
+		}
+	case strings.HasPrefix(model, "gemini-2"):
+		if hasFunctions && len(exclusive) > 0 {
+			errs = append(errs, fmt.Errorf("model %s doesn't support built-in tools (%s) together with function tools", model, strings.Join(exclusive, ", ")))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// modelID returns the model ID of a model name, e.g. "gemini-2.5-flash" for
+// "projects/p/locations/l/publishers/google/models/gemini-2.5-flash".
+func modelID(model string) string {
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		return model[i+1:]
+	}
+	return model
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlellm
+
+import (
+	"testing"
+
+	"google.golang.org/genai"
+)
+
+func TestValidateTools(t *testing.T) {
+	functions := &genai.Tool{FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "get_weather"}}}
+	search := &genai.Tool{GoogleSearch: &genai.GoogleSearch{}}
+	urlContext := &genai.Tool{URLContext: &genai.URLContext{}}
+	enterpriseSearch := &genai.Tool{EnterpriseWebSearch: &genai.EnterpriseWebSearch{}}
+	vertexAISearch := &genai.Tool{Retrieval: &genai.Retrieval{VertexAISearch: &genai.VertexAISearch{Datastore: "d"}}}
+	computerUse := &genai.Tool{ComputerUse: &genai.ComputerUse{Environment: genai.EnvironmentBrowser}}
+
+	testCases := []struct {
+		name    string
+		variant string
+		model   string
+		tools   []*genai.Tool
+		wantErr bool
+	}{
+		{
+			name:    "functions only",
+			variant: GoogleLLMVariantGeminiAPI,
+			model:   "gemini-1.5-flash",
+			tools:   []*genai.Tool{functions},
+		},
+		{
+			name:    "gemini 1 single built-in",
+			variant: GoogleLLMVariantGeminiAPI,
+			model:   "gemini-1.5-pro",
+			tools:   []*genai.Tool{search},
+		},
+		{
+			name:    "gemini 1 built-in with functions",
+			variant: GoogleLLMVariantVertexAI,
+			model:   "gemini-1.5-pro",
+			tools:   []*genai.Tool{search, functions},
+			wantErr: true,
+		},
+		{
+			name:    "gemini 1 two built-ins",
+			variant: GoogleLLMVariantGeminiAPI,
+			model:   "gemini-1.5-pro",
+			tools:   []*genai.Tool{search, urlContext},
+			wantErr: true,
+		},
+		{
+			name:    "gemini 2 built-ins together",
+			variant: GoogleLLMVariantGeminiAPI,
+			model:   "gemini-2.5-flash",
+			tools:   []*genai.Tool{search, urlContext},
+		},
+		{
+			name:    "gemini 2 built-in with functions",
+			variant: GoogleLLMVariantGeminiAPI,
+			model:   "models/gemini-2.5-flash",
+			tools:   []*genai.Tool{functions, urlContext},
+			wantErr: true,
+		},
+		{
+			name:    "gemini 2 computer use with functions",
+			variant: GoogleLLMVariantGeminiAPI,
+			model:   "gemini-2.5-computer-use-preview-10-2025",
+			tools:   []*genai.Tool{computerUse, functions},
+		},
+		{
+			name:    "gemini 2 computer use and search with functions",
+			variant: GoogleLLMVariantGeminiAPI,
+			model:   "gemini-2.5-computer-use-preview-10-2025",
+			tools:   []*genai.Tool{computerUse, search, functions},
+			wantErr: true,
+		},
+		{
+			name:    "other model built-in with functions",
+			variant: GoogleLLMVariantGeminiAPI,
+			model:   "gemini-3-pro-preview",
+			tools:   []*genai.Tool{functions, search},
+		},
+		{
+			name:    "vertex ai only tool on vertex ai",
+			variant: GoogleLLMVariantVertexAI,
+			model:   "projects/p/locations/l/publishers/google/models/gemini-2.5-pro",
+			tools:   []*genai.Tool{enterpriseSearch, vertexAISearch},
+		},
+		{
+			name:    "enterprise web search on gemini api",
+			variant: GoogleLLMVariantGeminiAPI,
+			model:   "gemini-2.5-pro",
+			tools:   []*genai.Tool{enterpriseSearch},
+			wantErr: true,
+		},
+		{
+			name:    "vertex ai search on gemini api",
+			variant: GoogleLLMVariantGeminiAPI,
+			model:   "gemini-2.5-pro",
+			tools:   []*genai.Tool{vertexAISearch},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTools(tt.variant, tt.model, tt.tools)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTools() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -26,6 +26,12 @@ const (
 	GoogleLLMVariantGeminiAPI = "GEMINI_API"
 )
 
+// GoogleLLM is implemented by the models backed by a Google LLM, which report
+// the variant they are using.
+type GoogleLLM interface {
+	GetGoogleLLMVariant() string
+}
+
 // GetGoogleLLMVariant returns the Google LLM variant to use.
 // see https://google.github.io/adk-docs/get-started/quickstart/#set-up-the-model
 func GetGoogleLLMVariant() string {
@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool"
+)
+
+// offloadToolResult saves the result of the function call as an artifact if
+// its JSON encoding is larger than maxSize bytes, and returns a preview of
+// the result and the name of the artifact instead. The result is returned
+// unchanged if offloading is disabled or fails, failures being logged.
+func offloadToolResult(ctx tool.Context, maxSize int, fnCall *genai.FunctionCall, result map[string]any) map[string]any {
+	if maxSize <= 0 || fnCall.Name == toolinternal.ReadToolResultName {
+		return result
+	}
+	data, err := json.Marshal(result)
+	if err != nil || len(data) <= maxSize {
+		return result
+	}
+	if ctx.Artifacts() == nil {
+		log.Printf("The result of tool %s is %d bytes long, but it isn't offloaded because the artifact service is not configured", fnCall.Name, len(data))
+		return result
+	}
+
+	id := fnCall.ID
+	if id == "" {
+		id = uuid.NewString()
+	}
+	name := fmt.Sprintf("%s%s_%s.json", toolinternal.ToolResultArtifactPrefix, fnCall.Name, id)
+	if _, err := ctx.Artifacts().Save(ctx, name, genai.NewPartFromBytes(data, "application/json")); err != nil {
+		log.Printf("Failed to offload the result of tool %s: %v", fnCall.Name, err)
+		return result
+	}
+
+	preview := strings.ToValidUTF8(string(data[:maxSize/2]), "")
+	return map[string]any{
+		"result_truncated": true,
+		"preview":          preview,
+		"artifact":         name,
+		"size":             len(data),
+		"note": fmt.Sprintf("The result is %d bytes long, only its beginning is shown in preview. "+
+			"The JSON encoded result was saved in the artifact %q, call %s to read it.", len(data), name, toolinternal.ReadToolResultName),
+	}
+}
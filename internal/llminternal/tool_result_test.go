@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/artifact"
+	artifactinternal "google.golang.org/adk/internal/artifact"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/session"
+)
+
+func TestOffloadToolResult(t *testing.T) {
+	large := map[string]any{"text": strings.Repeat("a", 200)}
+	largeJSON, err := json.Marshal(large)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		maxSize      int
+		fnCall       *genai.FunctionCall
+		noArtifacts  bool
+		wantOffload  bool
+		wantArtifact string
+	}{
+		{
+			name:    "disabled",
+			maxSize: 0,
+			fnCall:  &genai.FunctionCall{ID: "id1", Name: "search"},
+		},
+		{
+			name:    "small result",
+			maxSize: 1000,
+			fnCall:  &genai.FunctionCall{ID: "id1", Name: "search"},
+		},
+		{
+			name:         "large result",
+			maxSize:      100,
+			fnCall:       &genai.FunctionCall{ID: "id1", Name: "search"},
+			wantOffload:  true,
+			wantArtifact: "tool_result_search_id1.json",
+		},
+		{
+			name:    "read_tool_result",
+			maxSize: 100,
+			fnCall:  &genai.FunctionCall{ID: "id1", Name: toolinternal.ReadToolResultName},
+		},
+		{
+			name:        "no artifact service",
+			maxSize:     100,
+			fnCall:      &genai.FunctionCall{ID: "id1", Name: "search"},
+			noArtifacts: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			params := icontext.InvocationContextParams{}
+			service := artifact.InMemoryService()
+			if !tc.noArtifacts {
+				params.Artifacts = &artifactinternal.Artifacts{
+					Service:   service,
+					AppName:   "app",
+					UserID:    "user",
+					SessionID: "session",
+				}
+			}
+			actions := &session.EventActions{}
+			ctx := toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), params), tc.fnCall.ID, actions)
+
+			got := offloadToolResult(ctx, tc.maxSize, tc.fnCall, large)
+			if !tc.wantOffload {
+				if diff := cmp.Diff(large, got); diff != "" {
+					t.Errorf("offloadToolResult() mismatch (-want +got):\n%s", diff)
+				}
+				if len(actions.ArtifactDelta) > 0 {
+					t.Errorf("offloadToolResult() saved artifacts %v, want none", actions.ArtifactDelta)
+				}
+				return
+			}
+
+			if got["artifact"] != tc.wantArtifact || got["result_truncated"] != true || got["size"] != len(largeJSON) {
+				t.Errorf("offloadToolResult() = %v, want truncated result in artifact %q of size %d", got, tc.wantArtifact, len(largeJSON))
+			}
+			if preview, _ := got["preview"].(string); preview != string(largeJSON[:tc.maxSize/2]) {
+				t.Errorf("preview = %q, want %q", preview, largeJSON[:tc.maxSize/2])
+			}
+			if _, ok := actions.ArtifactDelta[tc.wantArtifact]; !ok {
+				t.Errorf("ArtifactDelta = %v, want artifact %q", actions.ArtifactDelta, tc.wantArtifact)
+			}
+			resp, err := service.Load(t.Context(), &artifact.LoadRequest{AppName: "app", UserID: "user", SessionID: "session", FileName: tc.wantArtifact})
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if diff := cmp.Diff(largeJSON, resp.Part.InlineData.Data); diff != "" {
+				t.Errorf("saved artifact mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
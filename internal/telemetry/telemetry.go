@@ -109,9 +109,9 @@ func RegisterTelemetry() {
 // That means that the spans are NOT recording/exporting
 // If the local tracer is not set, we'll set up tracer with all registered span processors.
 func getTracers() []trace.Tracer {
-	if localTracer.tp == nil {
-		RegisterTelemetry()
-	}
+	// RegisterTelemetry is guarded by sync.Once, which also makes localTracer
+	// safe to read from concurrently running agents.
+	RegisterTelemetry()
 	return []trace.Tracer{
 		localTracer.tp.Tracer(systemName),
 		otel.GetTracerProvider().Tracer(systemName),
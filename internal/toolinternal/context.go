@@ -16,6 +16,10 @@ package toolinternal
 
 import (
 	"context"
+	"errors"
+	"maps"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"google.golang.org/genai"
@@ -49,6 +53,17 @@ func (ia *internalArtifacts) Save(ctx context.Context, name string, data *genai.
 }
 
 func NewToolContext(ctx agent.InvocationContext, functionCallID string, actions *session.EventActions) tool.Context {
+	return newToolContext(ctx, functionCallID, actions, nil)
+}
+
+// NewForwardingToolContext creates a tool context whose events passed to
+// ForwardEvent are sent to forward, which yields them to the event stream of
+// the calling agent.
+func NewForwardingToolContext(ctx agent.InvocationContext, functionCallID string, actions *session.EventActions, forward func(*session.Event) bool) tool.Context {
+	return newToolContext(ctx, functionCallID, actions, forward)
+}
+
+func newToolContext(ctx agent.InvocationContext, functionCallID string, actions *session.EventActions, forward func(*session.Event) bool) *toolContext {
 	if functionCallID == "" {
 		functionCallID = uuid.NewString()
 	}
@@ -60,16 +75,34 @@ func NewToolContext(ctx agent.InvocationContext, functionCallID string, actions
 	}
 	cbCtx := contextinternal.NewCallbackContextWithDelta(ctx, actions.StateDelta)
 
-	return &toolContext{
+	toolCtx := &toolContext{
 		CallbackContext:   cbCtx,
 		invocationContext: ctx,
 		functionCallID:    functionCallID,
 		eventActions:      actions,
-		artifacts: &internalArtifacts{
+		forward:           forward,
+	}
+	if ctx.Artifacts() != nil {
+		toolCtx.artifacts = &internalArtifacts{
 			Artifacts:    ctx.Artifacts(),
 			eventActions: actions,
-		},
+		}
+	}
+	return toolCtx
+}
+
+// ForwardEvent sends the event to the event stream of the agent calling the
+// tool. It reports false if the event was not forwarded, i.e. if the tool
+// context doesn't support forwarding or the consumer of the stream stopped.
+func ForwardEvent(ctx tool.Context, event *session.Event) bool {
+	if d, ok := ctx.(*derivedContext); ok {
+		return d.forwardEvent(event)
+	}
+	c, ok := ctx.(*toolContext)
+	if !ok || c.forward == nil {
+		return false
 	}
+	return c.forward(event)
 }
 
 type toolContext struct {
@@ -78,9 +111,81 @@ type toolContext struct {
 	functionCallID    string
 	eventActions      *session.EventActions
 	artifacts         *internalArtifacts
+	forward           func(*session.Event) bool
+	cached            bool
+	ran               bool
+	values            map[any]any
+}
+
+// CachedFunctionCallsKey is the key of the custom metadata of function
+// response events listing the IDs of the function calls whose results were
+// served from a cache.
+const CachedFunctionCallsKey = "adk_cached_function_calls"
+
+// baseContext returns the tool context created by NewToolContext which ctx
+// is or derives from.
+func baseContext(ctx tool.Context) (*toolContext, bool) {
+	if d, ok := ctx.(*derivedContext); ok {
+		ctx = d.Context
+	}
+	c, ok := ctx.(*toolContext)
+	return c, ok
+}
+
+// MarkCached records that the result of the function call of the tool
+// context is served from a cache instead of running the tool.
+func MarkCached(ctx tool.Context) {
+	if c, ok := baseContext(ctx); ok {
+		c.cached = true
+	}
+}
+
+// IsCached reports whether MarkCached was called with the tool context.
+func IsCached(ctx tool.Context) bool {
+	c, ok := baseContext(ctx)
+	return ok && c.cached
+}
+
+// MarkRun records that the tool of the tool context is run, i.e. that its
+// result doesn't come from a before tool callback.
+func MarkRun(ctx tool.Context) {
+	if c, ok := baseContext(ctx); ok {
+		c.ran = true
+	}
+}
+
+// HasRun reports whether MarkRun was called with the tool context.
+func HasRun(ctx tool.Context) bool {
+	c, ok := baseContext(ctx)
+	return ok && c.ran
+}
+
+// SetValue stores a value of the function call of the tool context, e.g. to
+// pass it from a before to an after tool callback. The callbacks of a call
+// run sequentially, so values aren't synchronized.
+func SetValue(ctx tool.Context, key, value any) {
+	c, ok := baseContext(ctx)
+	if !ok {
+		return
+	}
+	if c.values == nil {
+		c.values = make(map[any]any)
+	}
+	c.values[key] = value
+}
+
+// Value returns the value stored by SetValue, or nil.
+func Value(ctx tool.Context, key any) any {
+	if c, ok := baseContext(ctx); ok {
+		return c.values[key]
+	}
+	return nil
 }
 
 func (c *toolContext) Artifacts() agent.Artifacts {
+	if c.artifacts == nil {
+		return nil
+	}
 	return c.artifacts
 }
 
@@ -96,6 +201,151 @@ func (c *toolContext) AgentName() string {
 	return c.invocationContext.Agent().Name()
 }
 
+// ErrMemoryNotConfigured is returned by SearchMemory when the invocation has
+// no memory service.
+var ErrMemoryNotConfigured = errors.New("memory service is not configured")
+
 func (c *toolContext) SearchMemory(ctx context.Context, query string) (*memory.SearchResponse, error) {
+	if c.invocationContext.Memory() == nil {
+		return nil, ErrMemoryNotConfigured
+	}
 	return c.invocationContext.Memory().Search(ctx, query)
 }
+
+// WithContext returns a tool context using ctx, usually derived from
+// toolCtx, for deadlines, cancellation and values.
+//
+// The derived context has its own copy of the EventActions of toolCtx. The
+// returned function ends the use of the derived context: if commit is true,
+// the actions of the derived context replace the actions of toolCtx. Either
+// way, the derived context is detached from toolCtx: its later changes and
+// forwarded events are dropped and its state changes and artifact saves fail,
+// so that a tool still running after its caller gave up doesn't write to the
+// session, the event of the call or the event stream of the agent.
+func WithContext(toolCtx tool.Context, ctx context.Context) (tool.Context, func(commit bool)) {
+	d := &derivedContext{Context: toolCtx, ctx: ctx}
+	if c, ok := toolCtx.(*toolContext); ok {
+		d.parent = c
+		d.Context = newToolContext(c.invocationContext, c.functionCallID, cloneActions(c.eventActions), c.forward)
+	}
+	return d, d.done
+}
+
+// cloneActions returns a copy of the actions, not sharing their maps.
+func cloneActions(actions *session.EventActions) *session.EventActions {
+	clone := *actions
+	clone.StateDelta = maps.Clone(actions.StateDelta)
+	clone.ArtifactDelta = maps.Clone(actions.ArtifactDelta)
+	return &clone
+}
+
+// derivedContext is a tool context with another context.Context.
+type derivedContext struct {
+	tool.Context
+	ctx context.Context
+	// parent is the tool context whose actions are replaced on commit, if
+	// the derived context has its own actions.
+	parent *toolContext
+
+	mu       sync.Mutex
+	detached bool
+}
+
+func (d *derivedContext) Deadline() (time.Time, bool) {
+	return d.ctx.Deadline()
+}
+
+func (d *derivedContext) Done() <-chan struct{} {
+	return d.ctx.Done()
+}
+
+func (d *derivedContext) Err() error {
+	return d.ctx.Err()
+}
+
+func (d *derivedContext) Value(key any) any {
+	return d.ctx.Value(key)
+}
+
+// errDetached is returned by the writes of derived contexts once they are
+// detached.
+var errDetached = errors.New("the tool call has ended")
+
+func (d *derivedContext) State() session.State {
+	return &derivedState{State: d.Context.State(), ctx: d}
+}
+
+func (d *derivedContext) Artifacts() agent.Artifacts {
+	if d.Context.Artifacts() == nil {
+		return nil
+	}
+	return &derivedArtifacts{Artifacts: d.Context.Artifacts(), ctx: d}
+}
+
+// derivedState is the state of a derived context, which can't be changed
+// once it is detached.
+type derivedState struct {
+	session.State
+	ctx *derivedContext
+}
+
+func (s *derivedState) Set(key string, val any) error {
+	s.ctx.mu.Lock()
+	defer s.ctx.mu.Unlock()
+	if s.ctx.detached {
+		return errDetached
+	}
+	return s.State.Set(key, val)
+}
+
+// derivedArtifacts are the artifacts of a derived context, which can't be
+// saved once it is detached.
+type derivedArtifacts struct {
+	agent.Artifacts
+	ctx *derivedContext
+}
+
+func (a *derivedArtifacts) Save(ctx context.Context, name string, data *genai.Part) (*artifact.SaveResponse, error) {
+	// The lock isn't held during the save, which may be slow: a save which
+	// completes after the detach only changes the detached actions.
+	a.ctx.mu.Lock()
+	detached := a.ctx.detached
+	a.ctx.mu.Unlock()
+	if detached {
+		return nil, errDetached
+	}
+	return a.Artifacts.Save(ctx, name, data)
+}
+
+func (d *derivedContext) forwardEvent(event *session.Event) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.detached {
+		return false
+	}
+	return ForwardEvent(d.Context, event)
+}
+
+func (d *derivedContext) done(commit bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.detached {
+		return
+	}
+	d.detached = true
+	if commit && d.parent != nil {
+		// Update the actions in place: the caller of the tool and the
+		// state of the parent context hold them and their maps.
+		parent, actions := d.parent.eventActions, d.Context.Actions()
+		maps.Copy(parent.StateDelta, actions.StateDelta)
+		if len(actions.ArtifactDelta) > 0 {
+			if parent.ArtifactDelta == nil {
+				parent.ArtifactDelta = make(map[string]int64)
+			}
+			maps.Copy(parent.ArtifactDelta, actions.ArtifactDelta)
+		}
+		parent.SkipSummarization = actions.SkipSummarization
+		parent.TransferToAgent = actions.TransferToAgent
+		parent.Escalate = actions.Escalate
+	}
+}
@@ -15,10 +15,14 @@
 package toolinternal
 
 import (
+	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"google.golang.org/adk/agent"
 	contextinternal "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/sessioninternal"
 	"google.golang.org/adk/session"
 )
 
@@ -36,3 +40,79 @@ func TestToolContext(t *testing.T) {
 		t.Errorf("ToolContext(%+T) is unexpectedly an InvocationContext", got)
 	}
 }
+
+func TestWithContext(t *testing.T) {
+	inv := contextinternal.NewInvocationContext(t.Context(), contextinternal.InvocationContextParams{})
+	var forwarded int
+	toolCtx := NewForwardingToolContext(inv, "fn1", &session.EventActions{}, func(*session.Event) bool {
+		forwarded++
+		return true
+	})
+
+	ctx, cancel := context.WithCancel(toolCtx)
+	derived, finish := WithContext(toolCtx, ctx)
+	if derived.FunctionCallID() != "fn1" {
+		t.Errorf("FunctionCallID() = %q, want %q", derived.FunctionCallID(), "fn1")
+	}
+	cancel()
+	if derived.Err() == nil || toolCtx.Err() != nil {
+		t.Errorf("Err() = %v, parent Err() = %v, want only the derived context to be cancelled", derived.Err(), toolCtx.Err())
+	}
+
+	if !ForwardEvent(derived, session.NewEvent("inv")) {
+		t.Error("ForwardEvent() = false before detach, want true")
+	}
+	finish(false)
+	if ForwardEvent(derived, session.NewEvent("inv")) {
+		t.Error("ForwardEvent() = true after detach, want false")
+	}
+	if forwarded != 1 {
+		t.Errorf("forwarded %d events, want 1", forwarded)
+	}
+}
+
+func TestWithContext_Actions(t *testing.T) {
+	for _, commit := range []bool{true, false} {
+		service := session.InMemoryService()
+		resp, err := service.Create(t.Context(), &session.CreateRequest{AppName: "app", UserID: "user"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		inv := contextinternal.NewInvocationContext(t.Context(), contextinternal.InvocationContextParams{
+			Session: sessioninternal.NewMutableSession(service, resp.Session),
+		})
+		actions := &session.EventActions{StateDelta: map[string]any{"before": 1}}
+		toolCtx := NewToolContext(inv, "fn1", actions)
+
+		derived, finish := WithContext(toolCtx, toolCtx)
+		if got, err := derived.State().Get("before"); err != nil || got != 1 {
+			t.Errorf("State().Get(%q) = %v, %v, want the state of the parent context", "before", got, err)
+		}
+		if err := derived.State().Set("during", 2); err != nil {
+			t.Fatal(err)
+		}
+		derived.Actions().Escalate = true
+		if _, ok := actions.StateDelta["during"]; ok {
+			t.Errorf("StateDelta = %v, want the changes of the derived context to be private before finish", actions.StateDelta)
+		}
+		finish(commit)
+		if err := derived.State().Set("after", 3); err == nil {
+			t.Error("State().Set() after finish succeeded, want an error")
+		}
+
+		want := &session.EventActions{StateDelta: map[string]any{"before": 1}}
+		if commit {
+			want = &session.EventActions{StateDelta: map[string]any{"before": 1, "during": 2}, Escalate: true}
+		}
+		if diff := cmp.Diff(want, actions); diff != "" {
+			t.Errorf("finish(%v): actions mismatch (-want +got):\n%s", commit, diff)
+		}
+		// The parent context keeps writing to the actions of the call.
+		if err := toolCtx.State().Set("parent", 4); err != nil {
+			t.Fatal(err)
+		}
+		if actions.StateDelta["parent"] != 4 {
+			t.Errorf("StateDelta = %v, want the changes of the parent context", actions.StateDelta)
+		}
+	}
+}
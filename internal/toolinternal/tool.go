@@ -22,6 +22,15 @@ import (
 	"google.golang.org/adk/tool"
 )
 
+const (
+	// ReadToolResultName is the name of the tool reading the tool results
+	// which were saved as artifacts because they were too large.
+	ReadToolResultName = "read_tool_result"
+	// ToolResultArtifactPrefix prefixes the names of the artifacts holding
+	// the tool results which were too large.
+	ToolResultArtifactPrefix = "tool_result_"
+)
+
 type FunctionTool interface {
 	tool.Tool
 	Declaration() *genai.FunctionDeclaration
@@ -31,3 +40,21 @@ type FunctionTool interface {
 type RequestProcessor interface {
 	ProcessRequest(ctx tool.Context, req *model.LLMRequest) error
 }
+
+// Wrapper is implemented by tools wrapping another tool, e.g. to rename it or
+// to limit its calls, so that callbacks can find the tool they handle.
+type Wrapper interface {
+	Unwrap() tool.Tool
+}
+
+// Unwrap returns the innermost tool wrapped by t, or t if it isn't a
+// Wrapper.
+func Unwrap(t tool.Tool) tool.Tool {
+	for {
+		w, ok := t.(Wrapper)
+		if !ok {
+			return t
+		}
+		t = w.Unwrap()
+	}
+}
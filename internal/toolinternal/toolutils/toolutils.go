@@ -17,6 +17,10 @@ package toolutils
 
 import (
 	"fmt"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
 
 	"google.golang.org/genai"
 
@@ -67,3 +71,63 @@ func PackTool(req *model.LLMRequest, tool Tool) error {
 	}
 	return nil
 }
+
+// IntArg returns the integer argument name of a tool call, or def if it isn't
+// set.
+func IntArg(args map[string]any, name string, def int) (int, error) {
+	switch v := args[name].(type) {
+	case nil:
+		return def, nil
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("argument %q must be an integer, got: %v", name, v)
+		}
+		return int(v), nil
+	default:
+		return 0, fmt.Errorf("argument %q must be an integer, got: %T", name, v)
+	}
+}
+
+// IsTextMIMEType reports whether content of the MIME type is text, to be
+// returned to the model as is rather than saved as an artifact.
+func IsTextMIMEType(mimeType string) bool {
+	switch {
+	case strings.HasPrefix(mimeType, "text/"),
+		mimeType == "application/json",
+		mimeType == "application/xml",
+		mimeType == "application/javascript",
+		mimeType == "application/x-yaml",
+		mimeType == "application/yaml",
+		strings.HasSuffix(mimeType, "+json"),
+		strings.HasSuffix(mimeType, "+xml"):
+		return true
+	}
+	return false
+}
+
+// ArtifactName returns the name of the artifact holding the content found at
+// u: the last segment of its path, or its host if the path is empty. The
+// extension of mimeType is added to names without one.
+func ArtifactName(u *url.URL, mimeType string) string {
+	p := u.Path
+	if p == "" {
+		p = u.Opaque
+	}
+	name := path.Base(p)
+	if name == "." || name == "/" {
+		name = u.Hostname()
+	}
+	if name == "" {
+		return u.String()
+	}
+	if path.Ext(name) == "" {
+		if exts, _ := mime.ExtensionsByType(mimeType); len(exts) > 0 {
+			name += exts[0]
+		}
+	}
+	return name
+}
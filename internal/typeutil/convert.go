@@ -25,10 +25,21 @@ import (
 // If non-nil resolvedSchema is provided, validation against the resolvedSchema will run
 // during the conversion.
 func ConvertToWithJSONSchema[From, To any](v From, resolvedSchema *jsonschema.Resolved) (To, error) {
-	var zero To
+	var typed To
+	if err := ConvertIntoWithJSONSchema(v, &typed, resolvedSchema); err != nil {
+		var zero To
+		return zero, err
+	}
+	return typed, nil
+}
+
+// ConvertIntoWithJSONSchema is like ConvertToWithJSONSchema, but stores the
+// converted value in the value pointed to by target, for types only known at
+// run time.
+func ConvertIntoWithJSONSchema(v, target any, resolvedSchema *jsonschema.Resolved) error {
 	rawArgs, err := json.Marshal(v)
 	if err != nil {
-		return zero, err
+		return err
 	}
 	if resolvedSchema != nil {
 		// See https://github.com/google/jsonschema-go/issues/23: in order to
@@ -36,15 +47,11 @@ func ConvertToWithJSONSchema[From, To any](v From, resolvedSchema *jsonschema.Re
 		// does not work as it cannot account for `omitempty` or custom marshalling.
 		var m map[string]any
 		if err := json.Unmarshal(rawArgs, &m); err != nil {
-			return zero, err
+			return err
 		}
 		if err := resolvedSchema.Validate(m); err != nil {
-			return zero, err
+			return err
 		}
 	}
-	var typed To
-	if err := json.Unmarshal(rawArgs, &typed); err != nil {
-		return zero, err
-	}
-	return typed, nil
+	return json.Unmarshal(rawArgs, target)
 }
@@ -0,0 +1,45 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "context"
+
+// Embedder computes embeddings of texts, e.g. to index and search documents.
+type Embedder interface {
+	Name() string
+	Embed(ctx context.Context, req *EmbedRequest) (*EmbedResponse, error)
+}
+
+// Task types of embedding requests, optimizing the embeddings for their use.
+const (
+	// EmbedTaskRetrievalDocument is for documents to be searched.
+	EmbedTaskRetrievalDocument = "RETRIEVAL_DOCUMENT"
+	// EmbedTaskRetrievalQuery is for search queries.
+	EmbedTaskRetrievalQuery = "RETRIEVAL_QUERY"
+)
+
+// EmbedRequest is the request to compute embeddings.
+type EmbedRequest struct {
+	Texts []string
+	// TaskType optimizes the embeddings for a task, e.g.
+	// EmbedTaskRetrievalDocument. Embedders may ignore it.
+	TaskType string
+}
+
+// EmbedResponse holds the embeddings of the texts of an EmbedRequest, in the
+// same order.
+type EmbedResponse struct {
+	Embeddings [][]float32
+}
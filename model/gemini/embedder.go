@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gemini
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+// maxEmbedBatchSize is the maximum number of texts embedded in one request.
+const maxEmbedBatchSize = 100
+
+type geminiEmbedder struct {
+	client             *genai.Client
+	name               string
+	versionHeaderValue string
+}
+
+// NewEmbedder returns [model.Embedder], backed by the Gemini API.
+//
+// It uses the provided context and configuration to initialize the underlying
+// [genai.Client]. The modelName specifies which embedding model to target
+// (e.g., "gemini-embedding-001").
+//
+// An error is returned if the [genai.Client] fails to initialize.
+func NewEmbedder(ctx context.Context, modelName string, cfg *genai.ClientConfig) (model.Embedder, error) {
+	client, err := genai.NewClient(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &geminiEmbedder{
+		name:               modelName,
+		client:             client,
+		versionHeaderValue: versionHeaderValue(),
+	}, nil
+}
+
+func (e *geminiEmbedder) Name() string {
+	return e.name
+}
+
+// Embed calls the underlying model, in batches of at most 100 texts.
+func (e *geminiEmbedder) Embed(ctx context.Context, req *model.EmbedRequest) (*model.EmbedResponse, error) {
+	headers := make(http.Header)
+	headers.Set("x-goog-api-client", e.versionHeaderValue)
+	headers.Set("user-agent", e.versionHeaderValue)
+	cfg := &genai.EmbedContentConfig{
+		TaskType:    req.TaskType,
+		HTTPOptions: &genai.HTTPOptions{Headers: headers},
+	}
+
+	resp := &model.EmbedResponse{Embeddings: make([][]float32, 0, len(req.Texts))}
+	for start := 0; start < len(req.Texts); start += maxEmbedBatchSize {
+		end := min(start+maxEmbedBatchSize, len(req.Texts))
+		contents := make([]*genai.Content, 0, end-start)
+		for _, text := range req.Texts[start:end] {
+			contents = append(contents, genai.NewContentFromText(text, genai.RoleUser))
+		}
+		batch, err := e.client.Models.EmbedContent(ctx, e.name, contents, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to call embedding model: %w", err)
+		}
+		if len(batch.Embeddings) != len(contents) {
+			return nil, fmt.Errorf("embedding model returned %d embeddings for %d texts", len(batch.Embeddings), len(contents))
+		}
+		for _, embedding := range batch.Embeddings {
+			resp.Embeddings = append(resp.Embeddings, embedding.Values)
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gemini
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func TestEmbedder_Embed(t *testing.T) {
+	var gotBatches []int
+	var gotTaskTypes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/models/gemini-embedding-001:batchEmbedContents") {
+			http.Error(w, "unexpected path "+r.URL.Path, http.StatusNotFound)
+			return
+		}
+		if !strings.HasPrefix(r.Header.Get("user-agent"), "google-adk/") {
+			http.Error(w, "missing user-agent header", http.StatusBadRequest)
+			return
+		}
+		var req struct {
+			Requests []struct {
+				TaskType string `json:"taskType"`
+			} `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		gotBatches = append(gotBatches, len(req.Requests))
+		gotTaskTypes = append(gotTaskTypes, req.Requests[0].TaskType)
+
+		var embeddings []map[string]any
+		for i := range req.Requests {
+			embeddings = append(embeddings, map[string]any{"values": []float32{float32(len(gotBatches)), float32(i)}})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"embeddings": embeddings})
+	}))
+	defer server.Close()
+
+	embedder, err := NewEmbedder(t.Context(), "gemini-embedding-001", &genai.ClientConfig{
+		APIKey:      "test-key",
+		Backend:     genai.BackendGeminiAPI,
+		HTTPOptions: genai.HTTPOptions{BaseURL: server.URL},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	texts := make([]string, 101)
+	for i := range texts {
+		texts[i] = "text"
+	}
+	resp, err := embedder.Embed(t.Context(), &model.EmbedRequest{Texts: texts, TaskType: model.EmbedTaskRetrievalDocument})
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+
+	if diff := cmp.Diff([]int{100, 1}, gotBatches); diff != "" {
+		t.Errorf("batch sizes mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"RETRIEVAL_DOCUMENT", "RETRIEVAL_DOCUMENT"}, gotTaskTypes); diff != "" {
+		t.Errorf("task types mismatch (-want +got):\n%s", diff)
+	}
+	if len(resp.Embeddings) != len(texts) {
+		t.Fatalf("got %d embeddings, want %d", len(resp.Embeddings), len(texts))
+	}
+	if diff := cmp.Diff([]float32{2, 0}, resp.Embeddings[100]); diff != "" {
+		t.Errorf("last embedding mismatch (-want +got):\n%s", diff)
+	}
+}
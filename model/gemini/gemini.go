@@ -27,10 +27,13 @@ import (
 
 	"google.golang.org/adk/internal/llminternal"
 	"google.golang.org/adk/internal/llminternal/converters"
+	"google.golang.org/adk/internal/llminternal/googlellm"
 	"google.golang.org/adk/internal/version"
 	"google.golang.org/adk/model"
 )
 
+var _ googlellm.GoogleLLM = (*geminiModel)(nil)
+
 // TODO: test coverage
 type geminiModel struct {
 	client             *genai.Client
@@ -52,20 +55,33 @@ func NewModel(ctx context.Context, modelName string, cfg *genai.ClientConfig) (m
 	}
 
 	// Create header value once, when the model is created
-	headerValue := fmt.Sprintf("google-adk/%s gl-go/%s", version.Version,
-		strings.TrimPrefix(runtime.Version(), "go"))
-
 	return &geminiModel{
 		name:               modelName,
 		client:             client,
-		versionHeaderValue: headerValue,
+		versionHeaderValue: versionHeaderValue(),
 	}, nil
 }
 
+// versionHeaderValue returns the value of the headers identifying ADK in
+// requests.
+func versionHeaderValue() string {
+	return fmt.Sprintf("google-adk/%s gl-go/%s", version.Version,
+		strings.TrimPrefix(runtime.Version(), "go"))
+}
+
 func (m *geminiModel) Name() string {
 	return m.name
 }
 
+// GetGoogleLLMVariant implements googlellm.GoogleLLM, returning the variant
+// of the backend of the client.
+func (m *geminiModel) GetGoogleLLMVariant() string {
+	if m.client.ClientConfig().Backend == genai.BackendVertexAI {
+		return googlellm.GoogleLLMVariantVertexAI
+	}
+	return googlellm.GoogleLLMVariantGeminiAPI
+}
+
 // GenerateContent calls the underlying model.
 func (m *geminiModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
 	m.maybeAppendUserContent(req)
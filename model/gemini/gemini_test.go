@@ -27,6 +27,7 @@ import (
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/internal/httprr"
+	"google.golang.org/adk/internal/llminternal/googlellm"
 	"google.golang.org/adk/internal/testutil"
 	"google.golang.org/adk/model"
 )
@@ -132,6 +133,36 @@ func TestModel_GenerateStream(t *testing.T) {
 	}
 }
 
+func TestModel_GoogleLLMVariant(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *genai.ClientConfig
+		want string
+	}{
+		{
+			name: "gemini api",
+			cfg:  &genai.ClientConfig{Backend: genai.BackendGeminiAPI, APIKey: "key"},
+			want: googlellm.GoogleLLMVariantGeminiAPI,
+		},
+		{
+			name: "vertex ai",
+			cfg:  &genai.ClientConfig{Backend: genai.BackendVertexAI, Project: "project", Location: "us-central1", HTTPClient: http.DefaultClient},
+			want: googlellm.GoogleLLMVariantVertexAI,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewModel(t.Context(), "gemini-2.5-flash", tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := m.(googlellm.GoogleLLM).GetGoogleLLMVariant(); got != tt.want {
+				t.Errorf("GetGoogleLLMVariant() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestModel_TrackingHeaders(t *testing.T) {
 	t.Run("verifies_headers_are_set", func(t *testing.T) {
 		httpRecordFilename := filepath.Join("testdata", strings.ReplaceAll(t.Name(), "/", "_")+".httprr")
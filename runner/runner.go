@@ -60,6 +60,10 @@ func New(cfg Config) (*Runner, error) {
 		return nil, fmt.Errorf("session service is required")
 	}
 
+	if err := agent.ValidateTree(cfg.Agent); err != nil {
+		return nil, fmt.Errorf("invalid agent tree: %w", err)
+	}
+
 	parents, err := parentmap.New(cfg.Agent)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create agent tree: %w", err)
@@ -161,8 +165,8 @@ func (r *Runner) Run(ctx context.Context, userID, sessionID string, msg *genai.C
 				continue
 			}
 
-			// only commit non-partial event to a session service
-			if !event.LLMResponse.Partial {
+			// only commit non-partial, non-forwarded event to a session service
+			if !event.LLMResponse.Partial && !event.Forwarded {
 				if err := r.sessionService.AppendEvent(ctx, session, event); err != nil {
 					yield(nil, fmt.Errorf("failed to add event to session: %w", err))
 					return
@@ -23,7 +23,11 @@ import (
 	"github.com/a2aproject/a2a-go/a2a"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/workflowagents/graphagent"
 	"google.golang.org/adk/agent/workflowagents/loopagent"
+	"google.golang.org/adk/agent/workflowagents/mapagent"
+	"google.golang.org/adk/agent/workflowagents/retryagent"
+	"google.golang.org/adk/agent/workflowagents/routeragent"
 	iagent "google.golang.org/adk/internal/agent"
 	"google.golang.org/adk/internal/llminternal"
 )
@@ -137,6 +141,14 @@ func buildAgentDescription(agent agent.Agent, state *iagent.State) string {
 			descriptionParts = append(descriptionParts, buildParallelAgentDescription(agent))
 		case iagent.TypeSequentialAgent:
 			descriptionParts = append(descriptionParts, buildSequentialAgentDescription(agent))
+		case iagent.TypeRouterAgent:
+			descriptionParts = append(descriptionParts, buildRouterAgentDescription(agent, state))
+		case iagent.TypeGraphAgent:
+			descriptionParts = append(descriptionParts, buildGraphAgentDescription(agent, state))
+		case iagent.TypeMapAgent:
+			descriptionParts = append(descriptionParts, buildMapAgentDescription(agent, state))
+		case iagent.TypeRetryAgent:
+			descriptionParts = append(descriptionParts, buildRetryAgentDescription(agent, state))
 		}
 	}
 
@@ -215,6 +227,82 @@ func buildLoopAgentDescription(agnt agent.Agent, state *iagent.State) string {
 	return fmt.Sprintf("%s in a loop (max %s iterations).", strings.Join(descriptions, " "), maxIterations)
 }
 
+func buildRouterAgentDescription(agnt agent.Agent, state *iagent.State) string {
+	routerConfig, ok := state.Config.(routeragent.Config)
+	if !ok {
+		return ""
+	}
+	subAgents := agnt.SubAgents()
+	descriptions := make([]string, len(subAgents))
+	for i, sub := range subAgents {
+		subDescription := sub.Description()
+		if subDescription == "" {
+			subDescription = fmt.Sprintf("execute the %s agent", sub.Name())
+		}
+		descriptions[i] = fmt.Sprintf("%s (%s)", sub.Name(), subDescription)
+	}
+	description := fmt.Sprintf("This agent will route the request to one of: %s.", strings.Join(descriptions, "; "))
+	if routerConfig.DefaultAgent != "" {
+		description = fmt.Sprintf("%s By default, it will use %s.", description, routerConfig.DefaultAgent)
+	}
+	return description
+}
+
+func buildGraphAgentDescription(agnt agent.Agent, state *iagent.State) string {
+	graphConfig, ok := state.Config.(graphagent.Config)
+	if !ok {
+		return ""
+	}
+	dependencies := make(map[string][]string)
+	for _, edge := range graphConfig.Edges {
+		dependencies[edge.To] = append(dependencies[edge.To], edge.From)
+	}
+	subAgents := agnt.SubAgents()
+	descriptions := make([]string, len(subAgents))
+	for i, sub := range subAgents {
+		subDescription := sub.Description()
+		if subDescription == "" {
+			subDescription = fmt.Sprintf("execute the %s agent", sub.Name())
+		}
+		if deps := dependencies[sub.Name()]; len(deps) > 0 {
+			descriptions[i] = fmt.Sprintf("%s after %s", subDescription, strings.Join(deps, " and "))
+		} else {
+			descriptions[i] = subDescription
+		}
+	}
+	return fmt.Sprintf("This agent will run a graph of agents: %s.", strings.Join(descriptions, "; "))
+}
+
+func buildMapAgentDescription(agnt agent.Agent, state *iagent.State) string {
+	mapConfig, ok := state.Config.(mapagent.Config)
+	if !ok {
+		return ""
+	}
+	sub := agnt.SubAgents()[0]
+	subDescription := sub.Description()
+	if subDescription == "" {
+		subDescription = fmt.Sprintf("execute the %s agent", sub.Name())
+	}
+	return fmt.Sprintf("This agent will %s for each item of %s.", subDescription, mapConfig.InputKey)
+}
+
+func buildRetryAgentDescription(agnt agent.Agent, state *iagent.State) string {
+	retryConfig, ok := state.Config.(retryagent.Config)
+	if !ok {
+		return ""
+	}
+	sub := agnt.SubAgents()[0]
+	subDescription := sub.Description()
+	if subDescription == "" {
+		subDescription = fmt.Sprintf("execute the %s agent", sub.Name())
+	}
+	description := fmt.Sprintf("This agent will %s (max %d attempts).", subDescription, retryConfig.MaxAttempts)
+	if retryConfig.Fallback != nil {
+		description = fmt.Sprintf("%s If all attempts fail, it will use %s.", description, retryConfig.Fallback.Name())
+	}
+	return description
+}
+
 func buildDescriptionFromInstructions(agent agent.Agent, llmState *llminternal.State) string {
 	state := getInternalState(agent)
 	descriptionParts := []string{}
@@ -266,6 +354,14 @@ func getDefaultAgentDescription(state *iagent.State) string {
 		return "A sequential workflow agent"
 	case iagent.TypeParallelAgent:
 		return "A parallel workflow agent"
+	case iagent.TypeRouterAgent:
+		return "A router workflow agent"
+	case iagent.TypeGraphAgent:
+		return "A graph workflow agent"
+	case iagent.TypeMapAgent:
+		return "A map workflow agent"
+	case iagent.TypeRetryAgent:
+		return "A retry workflow agent"
 	case iagent.TypeLLMAgent:
 		return "An LLM-based agent"
 	default:
@@ -281,6 +377,14 @@ func getAgentTypeTag(state *iagent.State) string {
 		return "sequential_workflow"
 	case iagent.TypeParallelAgent:
 		return "parallel_workflow"
+	case iagent.TypeRouterAgent:
+		return "router_workflow"
+	case iagent.TypeGraphAgent:
+		return "graph_workflow"
+	case iagent.TypeMapAgent:
+		return "map_workflow"
+	case iagent.TypeRetryAgent:
+		return "retry_workflow"
 	case iagent.TypeLLMAgent:
 		return "llm_agent"
 	default:
@@ -307,6 +411,6 @@ func getInternalState(agent agent.Agent) *iagent.State {
 }
 
 func isWorkflowAgent(state *iagent.State) bool {
-	workflowAgents := []iagent.Type{iagent.TypeLoopAgent, iagent.TypeSequentialAgent, iagent.TypeParallelAgent}
+	workflowAgents := []iagent.Type{iagent.TypeLoopAgent, iagent.TypeSequentialAgent, iagent.TypeParallelAgent, iagent.TypeRouterAgent, iagent.TypeGraphAgent, iagent.TypeMapAgent, iagent.TypeRetryAgent}
 	return slices.Contains(workflowAgents, state.AgentType)
 }
@@ -22,8 +22,12 @@ import (
 
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/agent/workflowagents/graphagent"
 	"google.golang.org/adk/agent/workflowagents/loopagent"
+	"google.golang.org/adk/agent/workflowagents/mapagent"
 	"google.golang.org/adk/agent/workflowagents/parallelagent"
+	"google.golang.org/adk/agent/workflowagents/retryagent"
+	"google.golang.org/adk/agent/workflowagents/routeragent"
 	"google.golang.org/adk/agent/workflowagents/sequentialagent"
 	"google.golang.org/adk/tool"
 	"google.golang.org/adk/tool/geminitool"
@@ -275,6 +279,171 @@ func TestGetAgentSkills_LLMAgent(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "router agent",
+			agent: must(routeragent.New(routeragent.Config{
+				AgentConfig: agent.Config{
+					Name:        "Test",
+					Description: "Test test.",
+					SubAgents: []agent.Agent{
+						must(agent.New(agent.Config{Name: "Inner 1", Description: "Inner 1 description"})),
+						must(agent.New(agent.Config{Name: "Inner 2"})),
+					},
+				},
+				DefaultAgent: "Inner 2",
+			})),
+			want: []a2a.AgentSkill{
+				{
+					ID:          "Test",
+					Description: "Test test. This agent will route the request to one of: Inner 1 (Inner 1 description); Inner 2 (execute the Inner 2 agent). By default, it will use Inner 2.",
+					Name:        "workflow",
+					Tags:        []string{"router_workflow"},
+				},
+				{
+					ID:          "Test-sub-agents",
+					Description: "Orchestrates: Inner 1 description; No description",
+					Name:        "sub-agents",
+					Tags:        []string{"router_workflow", "orchestration"},
+				},
+				{
+					ID:          "Inner 1_Inner 1",
+					Description: "Inner 1 description",
+					Name:        "Inner 1: custom",
+					Tags:        []string{"sub_agent:Inner 1", "custom_agent"},
+				},
+				{
+					ID:          "Inner 2_Inner 2",
+					Description: "A custom agent",
+					Name:        "Inner 2: custom",
+					Tags:        []string{"sub_agent:Inner 2", "custom_agent"},
+				},
+			},
+		},
+		{
+			name: "graph agent",
+			agent: must(graphagent.New(graphagent.Config{
+				AgentConfig: agent.Config{
+					Name:        "Test",
+					Description: "Test test.",
+					SubAgents: []agent.Agent{
+						must(agent.New(agent.Config{Name: "Inner 1", Description: "Inner 1 description"})),
+						must(agent.New(agent.Config{Name: "Inner 2", Description: "Inner 2 description"})),
+						must(agent.New(agent.Config{Name: "Inner 3", Description: "Inner 3 description"})),
+					},
+				},
+				Edges: []graphagent.Edge{
+					{From: "Inner 1", To: "Inner 3"},
+					{From: "Inner 2", To: "Inner 3"},
+				},
+			})),
+			want: []a2a.AgentSkill{
+				{
+					ID:          "Test",
+					Description: "Test test. This agent will run a graph of agents: Inner 1 description; Inner 2 description; Inner 3 description after Inner 1 and Inner 2.",
+					Name:        "workflow",
+					Tags:        []string{"graph_workflow"},
+				},
+				{
+					ID:          "Test-sub-agents",
+					Description: "Orchestrates: Inner 1 description; Inner 2 description; Inner 3 description",
+					Name:        "sub-agents",
+					Tags:        []string{"graph_workflow", "orchestration"},
+				},
+				{
+					ID:          "Inner 1_Inner 1",
+					Description: "Inner 1 description",
+					Name:        "Inner 1: custom",
+					Tags:        []string{"sub_agent:Inner 1", "custom_agent"},
+				},
+				{
+					ID:          "Inner 2_Inner 2",
+					Description: "Inner 2 description",
+					Name:        "Inner 2: custom",
+					Tags:        []string{"sub_agent:Inner 2", "custom_agent"},
+				},
+				{
+					ID:          "Inner 3_Inner 3",
+					Description: "Inner 3 description",
+					Name:        "Inner 3: custom",
+					Tags:        []string{"sub_agent:Inner 3", "custom_agent"},
+				},
+			},
+		},
+		{
+			name: "map agent",
+			agent: must(mapagent.New(mapagent.Config{
+				AgentConfig: agent.Config{
+					Name:        "Test",
+					Description: "Test test.",
+					SubAgents: []agent.Agent{
+						must(agent.New(agent.Config{Name: "Inner 1", Description: "summarize a document"})),
+					},
+				},
+				InputKey:  "documents",
+				ResultKey: "summary",
+				OutputKey: "summaries",
+			})),
+			want: []a2a.AgentSkill{
+				{
+					ID:          "Test",
+					Description: "Test test. This agent will summarize a document for each item of documents.",
+					Name:        "workflow",
+					Tags:        []string{"map_workflow"},
+				},
+				{
+					ID:          "Test-sub-agents",
+					Description: "Orchestrates: summarize a document",
+					Name:        "sub-agents",
+					Tags:        []string{"map_workflow", "orchestration"},
+				},
+				{
+					ID:          "Inner 1_Inner 1",
+					Description: "summarize a document",
+					Name:        "Inner 1: custom",
+					Tags:        []string{"sub_agent:Inner 1", "custom_agent"},
+				},
+			},
+		},
+		{
+			name: "retry agent",
+			agent: must(retryagent.New(retryagent.Config{
+				AgentConfig: agent.Config{
+					Name:        "Test",
+					Description: "Test test.",
+					SubAgents: []agent.Agent{
+						must(agent.New(agent.Config{Name: "Inner 1", Description: "call a remote service"})),
+					},
+				},
+				MaxAttempts: 3,
+				Fallback:    must(agent.New(agent.Config{Name: "Inner 2", Description: "apologize"})),
+			})),
+			want: []a2a.AgentSkill{
+				{
+					ID:          "Test",
+					Description: "Test test. This agent will call a remote service (max 3 attempts). If all attempts fail, it will use Inner 2.",
+					Name:        "workflow",
+					Tags:        []string{"retry_workflow"},
+				},
+				{
+					ID:          "Test-sub-agents",
+					Description: "Orchestrates: call a remote service; apologize",
+					Name:        "sub-agents",
+					Tags:        []string{"retry_workflow", "orchestration"},
+				},
+				{
+					ID:          "Inner 1_Inner 1",
+					Description: "call a remote service",
+					Name:        "Inner 1: custom",
+					Tags:        []string{"sub_agent:Inner 1", "custom_agent"},
+				},
+				{
+					ID:          "Inner 2_Inner 2",
+					Description: "apologize",
+					Name:        "Inner 2: custom",
+					Tags:        []string{"sub_agent:Inner 2", "custom_agent"},
+				},
+			},
+		},
 		{
 			name: "deep subagents",
 			agent: must(parallelagent.New(parallelagent.Config{
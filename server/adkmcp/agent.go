@@ -0,0 +1,144 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adkmcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/runner"
+)
+
+// validSessionID matches the session IDs accepted in the arguments of the
+// agent tools.
+var validSessionID = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// agentArgs are the arguments of an agent tool.
+type agentArgs struct {
+	Message   string `json:"message"`
+	SessionID string `json:"session_id,omitempty"`
+}
+
+var agentInputSchema = &jsonschema.Schema{
+	Type: "object",
+	Properties: map[string]*jsonschema.Schema{
+		"message": {
+			Type:        "string",
+			Description: "The message to send to the agent.",
+		},
+		"session_id": {
+			Type:        "string",
+			Description: "The session to send the message in. Use the session_id of a previous response to continue its conversation.",
+		},
+	},
+	Required: []string{"message"},
+}
+
+// newAgentTool returns the MCP tool running the agent a, loaded with the name
+// appName.
+func newAgentTool(cfg Config, appName string, a agent.Agent) *mcpTool {
+	description := a.Description()
+	if description == "" {
+		description = fmt.Sprintf("Sends a message to the agent %q and returns its response.", appName)
+	}
+	return &mcpTool{
+		tool: &mcp.Tool{
+			Name:        appName,
+			Description: description,
+			InputSchema: agentInputSchema,
+		},
+		handler: func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var args agentArgs
+			if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+				return errorResult(fmt.Errorf("invalid arguments: %w", err)), nil
+			}
+			if args.Message == "" {
+				return errorResult(errors.New("missing required argument \"message\"")), nil
+			}
+
+			userID, sessionID := cfg.SessionMapper(ctx, req, appName)
+			responseSessionID := sessionID
+			// The session_id of responses without a session_id argument is
+			// the mapped session itself.
+			if args.SessionID != "" && args.SessionID != sessionID {
+				if !validSessionID.MatchString(args.SessionID) {
+					return errorResult(fmt.Errorf("invalid session_id %q: only letters, digits, '-' and '_' are allowed", args.SessionID)), nil
+				}
+				// The sessions selected by the caller are scoped to the
+				// mapped session, so that callers can't reach the sessions
+				// of other callers.
+				sessionID += "." + args.SessionID
+				responseSessionID = args.SessionID
+			}
+			response, err := runAgent(ctx, cfg, appName, a, userID, sessionID, args.Message)
+			if err != nil {
+				return errorResult(err), nil
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: response}},
+				StructuredContent: map[string]any{
+					"response":   response,
+					"session_id": responseSessionID,
+				},
+			}, nil
+		},
+	}
+}
+
+// runAgent runs the agent with the message and returns the text of its final
+// responses.
+func runAgent(ctx context.Context, cfg Config, appName string, a agent.Agent, userID, sessionID, message string) (string, error) {
+	if _, err := getOrCreateSession(ctx, cfg.SessionService, appName, userID, sessionID); err != nil {
+		return "", err
+	}
+	r, err := runner.New(runner.Config{
+		AppName:         appName,
+		Agent:           a,
+		SessionService:  cfg.SessionService,
+		ArtifactService: cfg.ArtifactService,
+		MemoryService:   cfg.MemoryService,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create runner: %w", err)
+	}
+
+	var texts []string
+	for event, err := range r.Run(ctx, userID, sessionID, genai.NewContentFromText(message, genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			return "", fmt.Errorf("agent failed: %w", err)
+		}
+		if event.ErrorCode != "" || event.ErrorMessage != "" {
+			return "", fmt.Errorf("agent failed: %s %s", event.ErrorCode, event.ErrorMessage)
+		}
+		if event.Partial || !event.IsFinalResponse() || event.Content == nil {
+			continue
+		}
+		for _, part := range event.Content.Parts {
+			if part.Text != "" && !part.Thought {
+				texts = append(texts, part.Text)
+			}
+		}
+	}
+	return strings.Join(texts, "\n"), nil
+}
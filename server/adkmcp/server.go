@@ -0,0 +1,168 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package adkmcp allows to expose ADK agents via MCP.
+//
+// Each agent of an agent.Loader is published as an MCP tool which runs the
+// agent with runner.Runner. Optionally, the tools of the agents are
+// published as MCP tools as well.
+package adkmcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/internal/version"
+	"google.golang.org/adk/memory"
+	"google.golang.org/adk/session"
+)
+
+// SessionMapper maps a call of an MCP tool to the user and session the agent
+// runs with. appName is the name of the agent in the agent.Loader.
+type SessionMapper func(ctx context.Context, req *mcp.CallToolRequest, appName string) (userID, sessionID string)
+
+// Config allows to configure the MCP server.
+type Config struct {
+	// Name of the MCP server implementation reported to clients. Defaults to
+	// "adk".
+	Name string
+
+	// AgentLoader provides the agents to publish.
+	AgentLoader agent.Loader
+
+	// SessionService stores the sessions of the agents. Defaults to an
+	// in-memory service.
+	SessionService session.Service
+	// ArtifactService is optional.
+	ArtifactService artifact.Service
+	// MemoryService is optional.
+	MemoryService memory.Service
+
+	// SessionMapper maps tool calls to sessions. By default, all calls use
+	// the user "mcp_user" and the ID of the MCP session, so that each MCP
+	// client has its own conversation with each agent. Agent tools accept a
+	// "session_id" argument selecting another conversation, which is scoped
+	// to the mapped user and session: the ADK session is
+	// "<mapped session>.<session_id>", so that a caller can't reach the
+	// conversations of other callers.
+	SessionMapper SessionMapper
+
+	// ExportTools publishes the tools of the agents as MCP tools as well,
+	// named "<agent>_<tool>". Only function tools are published, not
+	// toolsets and long running tools. The tools run through the tool
+	// callbacks of their agent, e.g. confirmations and caches.
+	ExportTools bool
+}
+
+const (
+	defaultUserID    = "mcp_user"
+	defaultSessionID = "mcp_session"
+)
+
+// NewServer creates an MCP server publishing the agents of the loader.
+// Serve it with mcp.Server.Run, e.g. over mcp.StdioTransport, or over HTTP
+// with NewHandler.
+func NewServer(cfg Config) (*mcp.Server, error) {
+	if cfg.AgentLoader == nil {
+		return nil, fmt.Errorf("AgentLoader is required")
+	}
+	if cfg.Name == "" {
+		cfg.Name = "adk"
+	}
+	if cfg.SessionService == nil {
+		cfg.SessionService = session.InMemoryService()
+	}
+	if cfg.SessionMapper == nil {
+		cfg.SessionMapper = defaultSessionMapper
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: cfg.Name, Version: version.Version}, nil)
+	names := make(map[string]string)
+	for _, name := range cfg.AgentLoader.ListAgents() {
+		a, err := cfg.AgentLoader.LoadAgent(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load agent %q: %w", name, err)
+		}
+
+		tools := []*mcpTool{newAgentTool(cfg, name, a)}
+		if cfg.ExportTools {
+			exported, err := exportTools(cfg, name, a)
+			if err != nil {
+				return nil, fmt.Errorf("agent %q: %w", name, err)
+			}
+			tools = append(tools, exported...)
+		}
+		for _, t := range tools {
+			if other, ok := names[t.tool.Name]; ok {
+				return nil, fmt.Errorf("MCP tool name %q of agent %q is already used by agent %q", t.tool.Name, name, other)
+			}
+			names[t.tool.Name] = name
+			server.AddTool(t.tool, t.handler)
+		}
+	}
+	return server, nil
+}
+
+// NewHandler creates an http.Handler serving the agents of the loader with
+// the MCP streamable HTTP transport.
+func NewHandler(cfg Config) (http.Handler, error) {
+	server, err := NewServer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, nil), nil
+}
+
+// mcpTool is a tool registered in the MCP server.
+type mcpTool struct {
+	tool    *mcp.Tool
+	handler mcp.ToolHandler
+}
+
+func defaultSessionMapper(ctx context.Context, req *mcp.CallToolRequest, appName string) (string, string) {
+	if req.Session != nil && req.Session.ID() != "" {
+		return defaultUserID, req.Session.ID()
+	}
+	return defaultUserID, defaultSessionID
+}
+
+// getOrCreateSession returns the session, creating it if it doesn't exist.
+func getOrCreateSession(ctx context.Context, service session.Service, appName, userID, sessionID string) (session.Session, error) {
+	resp, err := service.Get(ctx, &session.GetRequest{AppName: appName, UserID: userID, SessionID: sessionID})
+	if err == nil {
+		return resp.Session, nil
+	}
+	if !errors.Is(err, session.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	created, err := service.Create(ctx, &session.CreateRequest{AppName: appName, UserID: userID, SessionID: sessionID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	return created.Session, nil
+}
+
+// errorResult returns the result of a failed tool call.
+func errorResult(err error) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}},
+		IsError: true,
+	}
+}
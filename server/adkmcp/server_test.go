@@ -0,0 +1,330 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adkmcp_test
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"maps"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/server/adkmcp"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// newEchoAgent returns an agent replying with the user message and the number
+// of user messages in its session.
+func newEchoAgent(t *testing.T) agent.Agent {
+	t.Helper()
+
+	a, err := agent.New(agent.Config{
+		Name:        "echo",
+		Description: "Echoes the message.",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				messages := 0
+				for e := range ctx.Session().Events().All() {
+					if e.Author == "user" {
+						messages++
+					}
+				}
+				event := session.NewEvent(ctx.InvocationID())
+				event.Author = ctx.Agent().Name()
+				event.Content = genai.NewContentFromText(fmt.Sprintf("%s (#%d)", ctx.UserContent().Parts[0].Text, messages), genai.RoleModel)
+				yield(event, nil)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+type counterArgs struct {
+	Step int `json:"step"`
+}
+
+type counterResult struct {
+	Count int `json:"count"`
+}
+
+// newCounterAgent returns an LLM agent with a tool incrementing a counter in
+// the session state.
+func newCounterAgent(t *testing.T) agent.Agent {
+	t.Helper()
+
+	increment, err := functiontool.New(functiontool.Config{Name: "increment", Description: "Increments the counter."},
+		func(ctx tool.Context, args counterArgs) (counterResult, error) {
+			count := 0
+			if v, err := ctx.State().Get("count"); err == nil {
+				count = v.(int)
+			}
+			count += args.Step
+			if err := ctx.State().Set("count", count); err != nil {
+				return counterResult{}, err
+			}
+			return counterResult{Count: count}, nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := llmagent.New(llmagent.Config{Name: "counter", Tools: []tool.Tool{increment}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func connect(t *testing.T, cfg adkmcp.Config) *mcp.ClientSession {
+	t.Helper()
+
+	server, err := adkmcp.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(t.Context(), serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test_client", Version: "v1.0.0"}, nil)
+	cs, err := client.Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = cs.Close() })
+	return cs
+}
+
+func callTool(t *testing.T, cs *mcp.ClientSession, name string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+
+	res, err := cs.CallTool(t.Context(), &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatalf("CallTool(%q) error = %v", name, err)
+	}
+	return res
+}
+
+func text(res *mcp.CallToolResult) string {
+	if len(res.Content) == 0 {
+		return ""
+	}
+	c, _ := res.Content[0].(*mcp.TextContent)
+	return c.Text
+}
+
+func TestServer_ListTools(t *testing.T) {
+	loader, err := agent.NewMultiLoader(newEchoAgent(t), newCounterAgent(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs := connect(t, adkmcp.Config{
+		AgentLoader: loader,
+		ExportTools: true,
+	})
+
+	res, err := cs.ListTools(t.Context(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string][]string)
+	for _, tl := range res.Tools {
+		got[tl.Name] = slices.Sorted(maps.Keys(tl.InputSchema.Properties))
+	}
+	want := map[string][]string{
+		"echo":              {"message", "session_id"},
+		"counter":           {"message", "session_id"},
+		"counter_increment": {"step"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("tools mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestServer_AgentTool(t *testing.T) {
+	cs := connect(t, adkmcp.Config{AgentLoader: agent.NewSingleLoader(newEchoAgent(t))})
+
+	res := callTool(t, cs, "echo", map[string]any{"message": "hello"})
+	if got, want := text(res), "hello (#1)"; got != want || res.IsError {
+		t.Errorf("first call = %q (error: %v), want %q", got, res.IsError, want)
+	}
+
+	// Calls from the same MCP session continue the same ADK session.
+	res = callTool(t, cs, "echo", map[string]any{"message": "again"})
+	if got, want := text(res), "again (#2)"; got != want {
+		t.Errorf("second call = %q, want %q", got, want)
+	}
+
+	// The session_id argument selects another session.
+	res = callTool(t, cs, "echo", map[string]any{"message": "other", "session_id": "s2"})
+	if got, want := text(res), "other (#1)"; got != want {
+		t.Errorf("call in new session = %q, want %q", got, want)
+	}
+	if diff := cmp.Diff(map[string]any{"response": "other (#1)", "session_id": "s2"}, res.StructuredContent); diff != "" {
+		t.Errorf("structured content mismatch (-want +got):\n%s", diff)
+	}
+
+	// The session_id of a response continues its conversation.
+	res = callTool(t, cs, "echo", map[string]any{"message": "more", "session_id": "s2"})
+	if got, want := text(res), "more (#2)"; got != want {
+		t.Errorf("call in session s2 = %q, want %q", got, want)
+	}
+	res = callTool(t, cs, "echo", map[string]any{"message": "hi"})
+	defaultSessionID, _ := res.StructuredContent.(map[string]any)["session_id"].(string)
+	res = callTool(t, cs, "echo", map[string]any{"message": "last", "session_id": defaultSessionID})
+	if got, want := text(res), "last (#4)"; got != want {
+		t.Errorf("call in default session = %q, want %q", got, want)
+	}
+
+	res = callTool(t, cs, "echo", map[string]any{"message": "other", "session_id": "../s2"})
+	if !res.IsError {
+		t.Errorf("call with invalid session_id succeeded, want error")
+	}
+
+	res = callTool(t, cs, "echo", map[string]any{})
+	if !res.IsError {
+		t.Errorf("call without message succeeded, want error")
+	}
+}
+
+func TestServer_SessionIDScopedToCaller(t *testing.T) {
+	sessionService := session.InMemoryService()
+	loader := agent.NewSingleLoader(newEchoAgent(t))
+	newClient := func(user string) *mcp.ClientSession {
+		return connect(t, adkmcp.Config{
+			AgentLoader:    loader,
+			SessionService: sessionService,
+			SessionMapper: func(context.Context, *mcp.CallToolRequest, string) (string, string) {
+				return "mcp_user", user
+			},
+		})
+	}
+	alice, bob := newClient("alice"), newClient("bob")
+
+	callTool(t, alice, "echo", map[string]any{"message": "secret", "session_id": "shared"})
+	res := callTool(t, bob, "echo", map[string]any{"message": "peek", "session_id": "shared"})
+	if got, want := text(res), "peek (#1)"; got != want {
+		t.Errorf("call of another caller = %q, want %q in a new session", got, want)
+	}
+	res = callTool(t, bob, "echo", map[string]any{"message": "peek", "session_id": "alice"})
+	if got, want := text(res), "peek (#1)"; got != want {
+		t.Errorf("call with the session of another caller = %q, want %q in a new session", got, want)
+	}
+}
+
+func TestServer_ExportedTool(t *testing.T) {
+	sessionService := session.InMemoryService()
+	cs := connect(t, adkmcp.Config{
+		AgentLoader:    agent.NewSingleLoader(newCounterAgent(t)),
+		SessionService: sessionService,
+		SessionMapper: func(context.Context, *mcp.CallToolRequest, string) (string, string) {
+			return "user", "session"
+		},
+		ExportTools: true,
+	})
+
+	callTool(t, cs, "counter_increment", map[string]any{"step": 2})
+	res := callTool(t, cs, "counter_increment", map[string]any{"step": 3})
+	if diff := cmp.Diff(map[string]any{"count": 5.0}, res.StructuredContent); diff != "" {
+		t.Errorf("structured content mismatch (-want +got):\n%s", diff)
+	}
+
+	resp, err := sessionService.Get(t.Context(), &session.GetRequest{AppName: "counter", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := resp.Session.State().Get("count"); got != 5 {
+		t.Errorf("state count = %v, want 5", got)
+	}
+}
+
+func TestServer_ExportedToolCallbacks(t *testing.T) {
+	increment, err := functiontool.New(functiontool.Config{Name: "increment"}, func(tool.Context, counterArgs) (counterResult, error) {
+		t.Error("the tool ran, want the callback to block it")
+		return counterResult{}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "counter",
+		Tools: []tool.Tool{increment},
+		BeforeToolCallbacks: []llmagent.BeforeToolCallback{
+			func(tool.Context, tool.Tool, map[string]any) (map[string]any, error) {
+				return map[string]any{"error": "blocked"}, nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs := connect(t, adkmcp.Config{AgentLoader: agent.NewSingleLoader(a), ExportTools: true})
+
+	res := callTool(t, cs, "counter_increment", map[string]any{"step": 2})
+	if diff := cmp.Diff(map[string]any{"error": "blocked"}, res.StructuredContent); diff != "" {
+		t.Errorf("structured content mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewHandler(t *testing.T) {
+	handler, err := adkmcp.NewHandler(adkmcp.Config{AgentLoader: agent.NewSingleLoader(newEchoAgent(t))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test_client", Version: "v1.0.0"}, nil)
+	cs, err := client.Connect(t.Context(), &mcp.StreamableClientTransport{Endpoint: server.URL}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cs.Close()
+
+	res := callTool(t, cs, "echo", map[string]any{"message": "hello"})
+	if got, want := text(res), "hello (#1)"; got != want {
+		t.Errorf("call = %q, want %q", got, want)
+	}
+}
+
+func TestNewServer_DuplicateToolName(t *testing.T) {
+	clash, err := agent.New(agent.Config{Name: "counter_increment"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader, err := agent.NewMultiLoader(newCounterAgent(t), clash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = adkmcp.NewServer(adkmcp.Config{
+		AgentLoader: loader,
+		ExportTools: true,
+	})
+	if err == nil {
+		t.Error("NewServer() succeeded, want duplicate tool name error")
+	}
+}
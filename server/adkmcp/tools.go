@@ -0,0 +1,222 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adkmcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	artifactinternal "google.golang.org/adk/internal/artifact"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/llminternal"
+	imemory "google.golang.org/adk/internal/memory"
+	"google.golang.org/adk/internal/sessioninternal"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/session"
+)
+
+// exportTools returns the MCP tools calling the function tools of the agent
+// a, loaded with the name appName.
+func exportTools(cfg Config, appName string, a agent.Agent) ([]*mcpTool, error) {
+	llmAgent, ok := a.(llminternal.Agent)
+	if !ok {
+		return nil, nil
+	}
+
+	var tools []*mcpTool
+	for _, t := range llminternal.Reveal(llmAgent).Tools {
+		fnTool, ok := t.(toolinternal.FunctionTool)
+		if !ok || t.IsLongRunning() {
+			continue
+		}
+		decl := fnTool.Declaration()
+		if decl == nil {
+			continue
+		}
+		schema, err := inputSchema(decl)
+		if err != nil {
+			return nil, fmt.Errorf("tool %q: %w", t.Name(), err)
+		}
+		tools = append(tools, &mcpTool{
+			tool: &mcp.Tool{
+				Name:        appName + "_" + t.Name(),
+				Description: t.Description(),
+				InputSchema: schema,
+			},
+			handler: func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				var args map[string]any
+				if len(req.Params.Arguments) > 0 {
+					if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+						return errorResult(fmt.Errorf("invalid arguments: %w", err)), nil
+					}
+				}
+				if args == nil {
+					args = map[string]any{}
+				}
+
+				userID, sessionID := cfg.SessionMapper(ctx, req, appName)
+				result, err := runTool(ctx, cfg, appName, a, fnTool, userID, sessionID, args)
+				if err != nil {
+					return errorResult(err), nil
+				}
+				text, err := json.Marshal(result)
+				if err != nil {
+					return errorResult(fmt.Errorf("failed to encode tool result: %w", err)), nil
+				}
+				return &mcp.CallToolResult{
+					Content:           []mcp.Content{&mcp.TextContent{Text: string(text)}},
+					StructuredContent: result,
+				}, nil
+			},
+		})
+	}
+	return tools, nil
+}
+
+// runTool runs the tool in the session of the agent, through the tool
+// callbacks of the agent. The state and artifact changes of the tool are
+// saved in an event authored by the agent.
+func runTool(ctx context.Context, cfg Config, appName string, a agent.Agent, t toolinternal.FunctionTool, userID, sessionID string, args map[string]any) (map[string]any, error) {
+	storedSession, err := getOrCreateSession(ctx, cfg.SessionService, appName, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := icontext.InvocationContextParams{
+		Session: sessioninternal.NewMutableSession(cfg.SessionService, storedSession),
+		Agent:   a,
+	}
+	if cfg.ArtifactService != nil {
+		params.Artifacts = &artifactinternal.Artifacts{
+			Service:   cfg.ArtifactService,
+			AppName:   appName,
+			UserID:    userID,
+			SessionID: sessionID,
+		}
+	}
+	if cfg.MemoryService != nil {
+		params.Memory = &imemory.Memory{
+			Service:   cfg.MemoryService,
+			AppName:   appName,
+			UserID:    userID,
+			SessionID: sessionID,
+		}
+	}
+	invocationCtx := icontext.NewInvocationContext(ctx, params)
+	actions := &session.EventActions{StateDelta: make(map[string]any)}
+
+	var result map[string]any
+	toolCtx := toolinternal.NewToolContext(invocationCtx, "", actions)
+	if llmAgent, ok := a.(llminternal.Agent); ok {
+		result, err = llminternal.RunTool(llmAgent, t, args, toolCtx)
+	} else {
+		result, err = t.Run(toolCtx, args)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tool %q failed: %w", t.Name(), err)
+	}
+
+	if len(actions.StateDelta) > 0 || len(actions.ArtifactDelta) > 0 {
+		event := session.NewEvent(invocationCtx.InvocationID())
+		event.Author = a.Name()
+		event.Actions = *actions
+		if err := cfg.SessionService.AppendEvent(ctx, storedSession, event); err != nil {
+			return nil, fmt.Errorf("failed to save tool changes: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// inputSchema converts the parameters of the function declaration to the
+// input schema of an MCP tool.
+func inputSchema(decl *genai.FunctionDeclaration) (*jsonschema.Schema, error) {
+	var params any
+	switch {
+	case decl.ParametersJsonSchema != nil:
+		params = decl.ParametersJsonSchema
+	case decl.Parameters != nil:
+		data, err := json.Marshal(decl.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode parameters schema: %w", err)
+		}
+		var m map[string]any
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("failed to decode parameters schema: %w", err)
+		}
+		params = fromGenaiSchema(m)
+	default:
+		return &jsonschema.Schema{Type: "object"}, nil
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode parameters schema: %w", err)
+	}
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("invalid parameters schema: %w", err)
+	}
+	if schema.Type == "" && schema.Types == nil {
+		schema.Type = "object"
+	}
+	if schema.Type != "object" {
+		return nil, fmt.Errorf("parameters schema must have type \"object\", got %q", schema.Type)
+	}
+	return &schema, nil
+}
+
+// fromGenaiSchema converts a genai.Schema, encoded as a map, to a JSON schema:
+// types are lower case and nullable schemas have the "null" type.
+func fromGenaiSchema(m map[string]any) map[string]any {
+	if t, ok := m["type"].(string); ok {
+		t = strings.ToLower(t)
+		if t == "type_unspecified" {
+			delete(m, "type")
+		} else if nullable, _ := m["nullable"].(bool); nullable {
+			m["type"] = []any{t, "null"}
+		} else {
+			m["type"] = t
+		}
+	}
+	delete(m, "nullable")
+	delete(m, "example")
+	delete(m, "propertyOrdering")
+
+	if items, ok := m["items"].(map[string]any); ok {
+		m["items"] = fromGenaiSchema(items)
+	}
+	if props, ok := m["properties"].(map[string]any); ok {
+		for name, prop := range props {
+			if p, ok := prop.(map[string]any); ok {
+				props[name] = fromGenaiSchema(p)
+			}
+		}
+	}
+	if anyOf, ok := m["anyOf"].([]any); ok {
+		for i, s := range anyOf {
+			if sm, ok := s.(map[string]any); ok {
+				anyOf[i] = fromGenaiSchema(sm)
+			}
+		}
+	}
+	return m
+}
@@ -17,6 +17,7 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -61,7 +62,7 @@ func (c *RuntimeAPIController) RunHandler(rw http.ResponseWriter, req *http.Requ
 
 // RunAgent executes a non-streaming agent run for a given session and message.
 func (c *RuntimeAPIController) runAgent(ctx context.Context, runAgentRequest models.RunAgentRequest) ([]*session.Event, error) {
-	err := c.validateSessionExists(ctx, runAgentRequest.AppName, runAgentRequest.UserId, runAgentRequest.SessionId)
+	err := c.prepareSession(ctx, runAgentRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -102,7 +103,7 @@ func (c *RuntimeAPIController) RunSSEHandler(rw http.ResponseWriter, req *http.R
 		return err
 	}
 
-	err = c.validateSessionExists(req.Context(), runAgentRequest.AppName, runAgentRequest.UserId, runAgentRequest.SessionId)
+	err = c.prepareSession(req.Context(), runAgentRequest)
 	if err != nil {
 		return err
 	}
@@ -156,15 +157,29 @@ func flashEvent(rc *http.ResponseController, rw http.ResponseWriter, event sessi
 	return nil
 }
 
-func (c *RuntimeAPIController) validateSessionExists(ctx context.Context, appName, userID, sessionID string) error {
-	_, err := c.sessionService.Get(ctx, &session.GetRequest{
-		AppName:   appName,
-		UserID:    userID,
-		SessionID: sessionID,
+// prepareSession validates that the session exists and applies the state
+// delta of the request to it.
+func (c *RuntimeAPIController) prepareSession(ctx context.Context, req models.RunAgentRequest) error {
+	resp, err := c.sessionService.Get(ctx, &session.GetRequest{
+		AppName:   req.AppName,
+		UserID:    req.UserId,
+		SessionID: req.SessionId,
 	})
-	if err != nil {
+	if errors.Is(err, session.ErrNotFound) {
 		return newStatusError(fmt.Errorf("failed to get session: %w", err), http.StatusNotFound)
 	}
+	if err != nil {
+		return newStatusError(fmt.Errorf("failed to get session: %w", err), http.StatusInternalServerError)
+	}
+	if req.StateDelta == nil || len(*req.StateDelta) == 0 {
+		return nil
+	}
+	event := session.NewEvent("")
+	event.Author = "user"
+	event.Actions.StateDelta = *req.StateDelta
+	if err := c.sessionService.AppendEvent(ctx, resp.Session, event); err != nil {
+		return newStatusError(fmt.Errorf("failed to apply state delta: %w", err), http.StatusInternalServerError)
+	}
 	return nil
 }
 
@@ -0,0 +1,158 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/server/adkrest/controllers"
+	"google.golang.org/adk/server/adkrest/internal/models"
+	"google.golang.org/adk/session"
+)
+
+// eventSummary holds the fields of an event relevant for the tests.
+type eventSummary struct {
+	Author     string
+	Text       string
+	StateDelta map[string]any
+}
+
+func TestRunHandler_StateDelta(t *testing.T) {
+	weather, err := agent.New(agent.Config{
+		Name: "weather",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				city, _ := ctx.Session().State().Get("city")
+				event := session.NewEvent(ctx.InvocationID())
+				event.Author = ctx.Agent().Name()
+				event.Content = genai.NewContentFromText(fmt.Sprintf("sunny in %v", city), genai.RoleModel)
+				yield(event, nil)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		stateDelta *map[string]any
+		wantEvents []eventSummary
+	}{
+		{
+			name: "no state delta",
+			wantEvents: []eventSummary{
+				{Author: "user", Text: "hi"},
+				{Author: "weather", Text: "sunny in Paris"},
+			},
+		},
+		{
+			name:       "state delta",
+			stateDelta: &map[string]any{"city": "Rome"},
+			wantEvents: []eventSummary{
+				{Author: "user", StateDelta: map[string]any{"city": "Rome"}},
+				{Author: "user", Text: "hi"},
+				{Author: "weather", Text: "sunny in Rome"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionService := session.InMemoryService()
+			_, err := sessionService.Create(t.Context(), &session.CreateRequest{
+				AppName:   "weather",
+				UserID:    "user",
+				SessionID: "session",
+				State:     map[string]any{"city": "Paris"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			apiController := controllers.NewRuntimeAPIController(sessionService, agent.NewSingleLoader(weather), nil, time.Minute)
+
+			rr := httptest.NewRecorder()
+			err = apiController.RunHandler(rr, newRunRequest(t, models.RunAgentRequest{
+				AppName:    "weather",
+				UserId:     "user",
+				SessionId:  "session",
+				NewMessage: *genai.NewContentFromText("hi", genai.RoleUser),
+				StateDelta: tt.stateDelta,
+			}))
+			if err != nil {
+				t.Fatalf("RunHandler() error = %v", err)
+			}
+
+			resp, err := sessionService.Get(t.Context(), &session.GetRequest{AppName: "weather", UserID: "user", SessionID: "session"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []eventSummary
+			for event := range resp.Session.Events().All() {
+				s := eventSummary{Author: event.Author}
+				if event.Content != nil {
+					s.Text = event.Content.Parts[0].Text
+				}
+				if len(event.Actions.StateDelta) > 0 {
+					s.StateDelta = event.Actions.StateDelta
+				}
+				got = append(got, s)
+			}
+			if diff := cmp.Diff(tt.wantEvents, got); diff != "" {
+				t.Errorf("session events mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRunHandler_SessionNotFound(t *testing.T) {
+	apiController := controllers.NewRuntimeAPIController(session.InMemoryService(), nil, nil, time.Minute)
+
+	err := apiController.RunHandler(httptest.NewRecorder(), newRunRequest(t, models.RunAgentRequest{
+		AppName:    "weather",
+		UserId:     "user",
+		SessionId:  "missing",
+		NewMessage: *genai.NewContentFromText("hi", genai.RoleUser),
+		StateDelta: &map[string]any{"city": "Rome"},
+	}))
+	var statusErr interface{ Status() int }
+	if !errors.As(err, &statusErr) || statusErr.Status() != http.StatusNotFound {
+		t.Errorf("RunHandler() error = %v, want status %d", err, http.StatusNotFound)
+	}
+}
+
+func newRunRequest(t *testing.T, runReq models.RunAgentRequest) *http.Request {
+	t.Helper()
+	body, err := json.Marshal(runReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, "/run", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
@@ -17,6 +17,7 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -123,6 +124,10 @@ func (c *SessionsAPIController) GetSessionHandler(rw http.ResponseWriter, req *h
 		UserID:    sessionID.UserID,
 		SessionID: sessionID.ID,
 	})
+	if errors.Is(err, session.ErrNotFound) {
+		http.Error(rw, err.Error(), http.StatusNotFound)
+		return
+	}
 	if err != nil {
 		http.Error(rw, err.Error(), http.StatusInternalServerError)
 		return
@@ -43,6 +43,7 @@ func TestGetSession(t *testing.T) {
 	tc := []struct {
 		name           string
 		storedSessions map[fakes.SessionKey]fakes.TestSession
+		getErr         error
 		sessionID      fakes.SessionKey
 		wantSession    models.Session
 		wantErr        error
@@ -75,7 +76,15 @@ func TestGetSession(t *testing.T) {
 			name:           "session does not exist",
 			storedSessions: map[fakes.SessionKey]fakes.TestSession{},
 			sessionID:      id,
-			wantErr:        fmt.Errorf("not found"),
+			wantErr:        fmt.Errorf("session not found"),
+			wantStatus:     http.StatusNotFound,
+		},
+		{
+			name:           "session service failure",
+			storedSessions: map[fakes.SessionKey]fakes.TestSession{},
+			getErr:         fmt.Errorf("connection refused"),
+			sessionID:      id,
+			wantErr:        fmt.Errorf("connection refused"),
 			wantStatus:     http.StatusInternalServerError,
 		},
 		{
@@ -116,7 +125,7 @@ func TestGetSession(t *testing.T) {
 
 	for _, tt := range tc {
 		t.Run(tt.name, func(t *testing.T) {
-			sessionService := fakes.FakeSessionService{Sessions: tt.storedSessions}
+			sessionService := fakes.FakeSessionService{Sessions: tt.storedSessions, GetErr: tt.getErr}
 			apiController := controllers.NewSessionsAPIController(&sessionService)
 			req, err := http.NewRequest(http.MethodGet, "/apps/testApp/users/testUser/sessions/testSession", nil)
 			if err != nil {
@@ -99,6 +99,8 @@ func (s TestSession) LastUpdateTime() time.Time {
 
 type FakeSessionService struct {
 	Sessions map[SessionKey]TestSession
+	// GetErr, if set, is returned by Get for the sessions which don't exist.
+	GetErr error
 }
 
 type SessionKey struct {
@@ -145,7 +147,10 @@ func (s *FakeSessionService) Get(ctx context.Context, req *session.GetRequest) (
 			Session: &sess,
 		}, nil
 	}
-	return nil, fmt.Errorf("not found")
+	if s.GetErr != nil {
+		return nil, s.GetErr
+	}
+	return nil, session.ErrNotFound
 }
 
 func (s *FakeSessionService) List(ctx context.Context, req *session.ListRequest) (*session.ListResponse, error) {
@@ -22,6 +22,7 @@ import (
 	"github.com/awalterschulze/gographviz"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/workflowagents/graphagent"
 	agentinternal "google.golang.org/adk/internal/agent"
 	llmagentinternal "google.golang.org/adk/internal/llminternal"
 	"google.golang.org/adk/tool"
@@ -39,6 +40,9 @@ var supportedClusterAgents = []agentinternal.Type{
 	agentinternal.TypeLoopAgent,
 	agentinternal.TypeSequentialAgent,
 	agentinternal.TypeParallelAgent,
+	agentinternal.TypeRouterAgent,
+	agentinternal.TypeGraphAgent,
+	agentinternal.TypeMapAgent,
 }
 
 type namedInstance interface {
@@ -169,6 +173,21 @@ func drawCluster(parentGraph, cluster *gographviz.Graph, agent agent.Agent, high
 			}
 		}
 		// Parallel sub-agents shouldn't be connected, they will be a part of the sub graph.
+		// Router sub-agents are alternative branches, so they aren't connected either.
+	}
+	// Graph sub-agents are connected according to their declared dependencies.
+	if cfg, ok := agentinternal.Reveal(agentInternal).Config.(graphagent.Config); ok {
+		for _, edge := range cfg.Edges {
+			if err := drawEdge(parentGraph, edge.From, edge.To, highlightedPairs); err != nil {
+				return fmt.Errorf("draw cluster: draw edge: %w", err)
+			}
+			// Conditional edges are dashed.
+			if edge.Condition != nil {
+				for _, e := range parentGraph.Edges.SrcToDsts[edge.From][edge.To] {
+					e.Attrs["style"] = "dashed"
+				}
+			}
+		}
 	}
 	return nil
 }
@@ -25,8 +25,10 @@ import (
 
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/agent/workflowagents/graphagent"
 	"google.golang.org/adk/agent/workflowagents/loopagent"
 	"google.golang.org/adk/agent/workflowagents/parallelagent"
+	"google.golang.org/adk/agent/workflowagents/routeragent"
 	"google.golang.org/adk/agent/workflowagents/sequentialagent"
 	agentinternal "google.golang.org/adk/internal/agent"
 	"google.golang.org/adk/model"
@@ -82,6 +84,14 @@ func newTestAgent(t *testing.T, name, description string, agentType agentinterna
 				SubAgents:   subAgents,
 			},
 		})
+	case agentinternal.TypeRouterAgent:
+		a, err = routeragent.New(routeragent.Config{
+			AgentConfig: agent.Config{
+				Name:        name,
+				Description: description,
+				SubAgents:   subAgents,
+			},
+		})
 	case agentinternal.TypeCustomAgent, agentinternal.TypeLLMAgent:
 		a, err = llmagent.New(llmagent.Config{
 			Name:        name,
@@ -248,6 +258,11 @@ func TestShouldBuildAgentCluster(t *testing.T) {
 			instance: newTestAgent(t, "ParAgent", "", agentinternal.TypeParallelAgent, nil, nil),
 			expected: true,
 		},
+		{
+			name:     "router agent",
+			instance: newTestAgent(t, "RouterAgent", "", agentinternal.TypeRouterAgent, nil, nil),
+			expected: true,
+		},
 		{
 			name:     "tool",
 			instance: &mockTool{name: "TestTool"},
@@ -591,6 +606,10 @@ func TestDrawCluster(t *testing.T) {
 			name:      "loop agent cluster",
 			agentType: agentinternal.TypeLoopAgent,
 		},
+		{
+			name:      "router agent cluster",
+			agentType: agentinternal.TypeRouterAgent,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -633,6 +652,11 @@ func TestDrawCluster(t *testing.T) {
 				if lookupEdge(t, parentGraph, "SubAgent1", "SubAgent2") != nil || lookupEdge(t, parentGraph, "ParSubAgent2", "ParSubAgent1") != nil {
 					t.Error("Unexpected edge found between parallel sub-agents")
 				}
+			case agentinternal.TypeRouterAgent:
+				// Check that no edges exist between alternative router branches
+				if lookupEdge(t, parentGraph, "SubAgent1", "SubAgent2") != nil || lookupEdge(t, parentGraph, "SubAgent2", "SubAgent1") != nil {
+					t.Error("Unexpected edge found between router sub-agents")
+				}
 			case agentinternal.TypeLoopAgent:
 				// Check if edges exist between sub-agents and back to the first
 				if lookupEdge(t, parentGraph, "SubAgent1", "SubAgent2") == nil {
@@ -648,6 +672,55 @@ func TestDrawCluster(t *testing.T) {
 	}
 }
 
+func TestDrawCluster_GraphAgent(t *testing.T) {
+	parentGraph := gographviz.NewGraph()
+	if err := parentGraph.SetName("ParentG"); err != nil {
+		t.Fatalf("failed to set parent graph name: %v", err)
+	}
+
+	subAgent1 := newTestAgent(t, "SubAgent1", "", agentinternal.TypeLLMAgent, nil, nil)
+	subAgent2 := newTestAgent(t, "SubAgent2", "", agentinternal.TypeLLMAgent, nil, nil)
+	subAgent3 := newTestAgent(t, "SubAgent3", "", agentinternal.TypeLLMAgent, nil, nil)
+	parentAgent, err := graphagent.New(graphagent.Config{
+		AgentConfig: agent.Config{
+			Name:      "ParentAgent",
+			SubAgents: []agent.Agent{subAgent1, subAgent2, subAgent3},
+		},
+		Edges: []graphagent.Edge{
+			{From: "SubAgent1", To: "SubAgent3"},
+			{From: "SubAgent2", To: "SubAgent3", Condition: func(agent.ReadonlyContext) (bool, error) { return true, nil }},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	err = drawCluster(parentGraph, gographviz.NewGraph(), parentAgent, [][]string{}, make(map[string]bool))
+	if err != nil {
+		t.Fatalf("drawCluster failed: %v", err)
+	}
+
+	if lookupEdge(t, parentGraph, "SubAgent1", "SubAgent2") != nil {
+		t.Error("Unexpected edge found between independent graph nodes")
+	}
+	edge := lookupEdge(t, parentGraph, "SubAgent1", "SubAgent3")
+	if edge == nil {
+		t.Fatal("Edge between SubAgent1 and SubAgent3 not found")
+		return
+	}
+	if _, ok := edge.Attrs["style"]; ok {
+		t.Errorf("Unconditional edge has style %q", edge.Attrs["style"])
+	}
+	edge = lookupEdge(t, parentGraph, "SubAgent2", "SubAgent3")
+	if edge == nil {
+		t.Fatal("Edge between SubAgent2 and SubAgent3 not found")
+		return
+	}
+	if edge.Attrs["style"] != "dashed" {
+		t.Errorf("Conditional edge style mismatch: got %q", edge.Attrs["style"])
+	}
+}
+
 func TestBuildGraph(t *testing.T) {
 	graph := gographviz.NewGraph()
 	err := graph.SetName("G")
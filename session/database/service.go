@@ -154,8 +154,10 @@ func (s *databaseService) Get(ctx context.Context, req *session.GetRequest) (*se
 			ID:      sessionID,
 		}).
 		First(&foundSession).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("session %q: %w", sessionID, session.ErrNotFound)
+	}
 	if err != nil {
-		// For any error including ErrRecordNotFound, return it as a system error.
 		return nil, fmt.Errorf("database error while fetching session: %w", err)
 	}
 
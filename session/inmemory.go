@@ -109,7 +109,7 @@ func (s *inMemoryService) Get(ctx context.Context, req *GetRequest) (*GetRespons
 
 	res, ok := s.sessions.Get(id.Encode())
 	if !ok {
-		return nil, fmt.Errorf("session %+v not found", req.SessionID)
+		return nil, fmt.Errorf("session %q: %w", req.SessionID, ErrNotFound)
 	}
 
 	copiedSession := copySessionWithoutStateAndEvents(res)
@@ -314,7 +314,12 @@ func (s *session) State() State {
 }
 
 func (s *session) Events() Events {
-	return events(s.events)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Events may be appended concurrently, e.g. by parallel branches of a
+	// workflow agent, so callers get a snapshot instead of the live slice.
+	return events(slices.Clone(s.events))
 }
 
 func (s *session) LastUpdateTime() time.Time {
@@ -334,6 +339,9 @@ func (s *session) appendEvent(event *Event) error {
 		return fmt.Errorf("error on appendEvent: %w", err)
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.events = append(s.events, event)
 	s.updatedAt = event.Timestamp
 	return nil
@@ -1049,3 +1049,42 @@ func Test_inMemoryService_CreateConcurrentAccess(t *testing.T) {
 		t.Errorf("expected %d 'already exists' errors, but got %d", expectedErrors, errorCount.Load())
 	}
 }
+
+func Test_inMemorySession_EventsSnapshot(t *testing.T) {
+	s := InMemoryService()
+	resp, err := s.Create(t.Context(), &CreateRequest{AppName: "app", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	sess := resp.Session
+
+	const goroutines = 8
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := range goroutines {
+		go func() {
+			defer wg.Done()
+			event := &Event{ID: strconv.Itoa(i), Timestamp: time.Now()}
+			if err := s.AppendEvent(t.Context(), sess, event); err != nil {
+				t.Errorf("AppendEvent() error = %v", err)
+			}
+			for range sess.Events().All() {
+			}
+		}()
+	}
+	wg.Wait()
+
+	snapshot := sess.Events()
+	if got := snapshot.Len(); got != goroutines {
+		t.Fatalf("Events().Len() = %d, want %d", got, goroutines)
+	}
+	if err := s.AppendEvent(t.Context(), sess, &Event{ID: "last", Timestamp: time.Now()}); err != nil {
+		t.Fatalf("AppendEvent() error = %v", err)
+	}
+	if got := snapshot.Len(); got != goroutines {
+		t.Errorf("snapshot Len() after AppendEvent = %d, want %d", got, goroutines)
+	}
+	if got := sess.Events().Len(); got != goroutines+1 {
+		t.Errorf("Events().Len() after AppendEvent = %d, want %d", got, goroutines+1)
+	}
+}
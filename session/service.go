@@ -16,9 +16,14 @@ package session
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrNotFound is returned, possibly wrapped, by Service.Get when the session
+// doesn't exist.
+var ErrNotFound = errors.New("session not found")
+
 // Service is a session storage service.
 //
 // It provides a set of methods for managing sessions and events.
@@ -114,6 +114,12 @@ type Event struct {
 	// Agent client will know from this field about which function call is long running.
 	// Only valid for function call event.
 	LongRunningToolIDs []string
+
+	// Forwarded marks events which are streamed to the client without being
+	// part of the session history, e.g. the events of an agent run by an
+	// agent tool. The runner doesn't commit them to the session and workflow
+	// agents don't act on them.
+	Forwarded bool
 }
 
 // IsFinalResponse returns whether the event is the final response of an agent.
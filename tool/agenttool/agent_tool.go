@@ -20,17 +20,20 @@ package agenttool
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
-	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/internal/agent/parentmap"
+	"google.golang.org/adk/internal/agent/runconfig"
+	icontext "google.golang.org/adk/internal/context"
 	"google.golang.org/adk/internal/llminternal"
+	"google.golang.org/adk/internal/toolinternal"
 	"google.golang.org/adk/internal/utils"
-	"google.golang.org/adk/memory"
 	"google.golang.org/adk/model"
-	"google.golang.org/adk/runner"
 	"google.golang.org/adk/session"
 	"google.golang.org/adk/tool"
 )
@@ -39,6 +42,8 @@ import (
 type agentTool struct {
 	agent             agent.Agent
 	skipSummarization bool
+	forwardEvents     bool
+	eventCallback     func(tool.Context, *session.Event)
 }
 
 // Config holds the configuration for an agent tool.
@@ -46,10 +51,25 @@ type Config struct {
 	// SkipSummarization, if true, will cause the agent to skip summarization
 	// after the sub-agent finishes execution.
 	SkipSummarization bool
+	// ForwardEvents, if true, forwards the events of the sub-agent to the
+	// event stream of the calling agent as they are produced. Forwarded
+	// events are marked with session.Event.Forwarded, so they are not
+	// committed to the session of the calling agent, whose history only
+	// records the function response. Their actions are cleared, so that
+	// they don't escalate or transfer on behalf of the calling agent.
+	ForwardEvents bool
+	// EventCallback, if set, is called for every event of the sub-agent, e.g.
+	// to log or trace them.
+	EventCallback func(ctx tool.Context, event *session.Event)
 }
 
 // New creates a new agent tool.
 // If cfg is nil, skipSummarization defaults to false.
+//
+// The sub-agent runs in the invocation of the calling agent, with its session,
+// artifacts and memory. It shares the state of the calling agent's session,
+// its changes being recorded in the state delta of the tool call, but it has
+// its own conversation, which starts with the request of the tool call.
 func New(agent agent.Agent, cfg *Config) tool.Tool {
 	if cfg == nil {
 		return &agentTool{
@@ -60,6 +80,8 @@ func New(agent agent.Agent, cfg *Config) tool.Tool {
 	return &agentTool{
 		agent:             agent,
 		skipSummarization: cfg.SkipSummarization,
+		forwardEvents:     cfg.ForwardEvents,
+		eventCallback:     cfg.EventCallback,
 	}
 }
 
@@ -115,8 +137,8 @@ func (t *agentTool) Declaration() *genai.FunctionDeclaration {
 }
 
 // Run executes the wrapped agent with the provided arguments.
-// It creates a new session for the sub-agent, runs the agent, and returns
-// the final result.
+// It runs the agent in the session of the calling agent, propagates its
+// state deltas and returns the final result.
 func (t *agentTool) Run(toolCtx tool.Context, args any) (map[string]any, error) {
 	margs, ok := args.(map[string]any)
 	if !ok {
@@ -164,48 +186,67 @@ func (t *agentTool) Run(toolCtx tool.Context, args any) (map[string]any, error)
 		content = genai.NewContentFromText(inputText, genai.RoleUser)
 	}
 
-	sessionService := session.InMemoryService()
-
-	r, err := runner.New(runner.Config{
-		AppName:        t.agent.Name(),
-		Agent:          t.agent,
-		SessionService: sessionService,
-		// TODO - use forwarding_artifact_service as in python.
-		ArtifactService: artifact.InMemoryService(),
-		MemoryService:   memory.InMemoryService(),
-	})
+	// The sub-agent runs in the invocation of the calling agent, with its
+	// artifacts, memory and state, but with its own conversation.
+	subSession := newSubSession(toolCtx)
+	parents, err := parentmap.New(t.agent)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create runner")
+		return nil, fmt.Errorf("invalid sub-agent %s: %w", t.agent.Name(), err)
 	}
-
-	stateMap := make(map[string]any)
-
-	for k, v := range toolCtx.State().All() {
-		// Filter out adk internal states.
-		if !strings.HasPrefix(k, "_adk") {
-			stateMap[k] = v
-		}
-	}
-
-	subSession, err := sessionService.Create(toolCtx, &session.CreateRequest{
-		AppName: t.agent.Name(),
-		UserID:  toolCtx.UserID(),
-		State:   stateMap,
+	ctx := parentmap.ToContext(toolCtx, parents)
+	ctx = runconfig.ToContext(ctx, &runconfig.RunConfig{
+		StreamingMode: runconfig.StreamingModeSSE,
 	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create session for sub-agent %s: %w", t.agent.Name(), err)
-	}
+	invocationCtx := icontext.NewInvocationContext(ctx, icontext.InvocationContextParams{
+		Artifacts:   toolCtx.Artifacts(),
+		Memory:      &callerMemory{toolCtx: toolCtx},
+		Session:     subSession,
+		Agent:       t.agent,
+		UserContent: content,
+		RunConfig:   &agent.RunConfig{StreamingMode: agent.StreamingModeSSE},
+	})
+	userEvent := session.NewEvent(invocationCtx.InvocationID())
+	userEvent.Author = "user"
+	userEvent.Content = content
+	subSession.appendEvent(userEvent)
 
 	// TODO(dpasiukevich): verify agent loop termination.
-	eventCh := r.Run(toolCtx, subSession.Session.UserID(), subSession.Session.ID(), content, agent.RunConfig{
-		StreamingMode: agent.StreamingModeSSE,
-	})
+	eventCh := t.agent.Run(invocationCtx)
 
 	var lastEvent *session.Event
 	for event, err := range eventCh {
 		if err != nil {
 			return nil, fmt.Errorf("error during execution of sub-agent %s: %w", t.agent.Name(), err)
 		}
+		if t.eventCallback != nil {
+			t.eventCallback(toolCtx, event)
+		}
+		if t.forwardEvents {
+			forwarded := *event
+			forwarded.Branch = t.agent.Name()
+			if toolCtx.Branch() != "" {
+				forwarded.Branch = toolCtx.Branch() + "." + t.agent.Name()
+			}
+			forwarded.Forwarded = true
+			// The forwarded copy is only displayed: its actions, e.g. an
+			// escalation or a state delta, must not be taken by the agents
+			// of the caller, which get the sub-agent's state through the
+			// tool context. It must not share the maps and slices of the
+			// event either.
+			forwarded.Actions = session.EventActions{}
+			forwarded.CustomMetadata = maps.Clone(event.CustomMetadata)
+			forwarded.LongRunningToolIDs = slices.Clone(event.LongRunningToolIDs)
+			toolinternal.ForwardEvent(toolCtx, &forwarded)
+		}
+		if event.Partial {
+			continue
+		}
+		subSession.appendEvent(event)
+		for k, v := range event.Actions.StateDelta {
+			if err := toolCtx.State().Set(k, v); err != nil {
+				return nil, fmt.Errorf("failed to propagate state of sub-agent %s: %w", t.agent.Name(), err)
+			}
+		}
 		if event.LLMResponse.Content != nil {
 			lastEvent = event
 		}
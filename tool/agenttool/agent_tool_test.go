@@ -15,6 +15,8 @@
 package agenttool_test
 
 import (
+	"fmt"
+	"iter"
 	"log"
 	"testing"
 
@@ -23,12 +25,14 @@ import (
 
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/artifact"
 	icontext "google.golang.org/adk/internal/context"
 	"google.golang.org/adk/internal/sessioninternal"
 	"google.golang.org/adk/internal/testutil"
 	"google.golang.org/adk/internal/toolinternal"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/model/gemini"
+	"google.golang.org/adk/runner"
 	"google.golang.org/adk/session"
 	"google.golang.org/adk/tool"
 	"google.golang.org/adk/tool/agenttool"
@@ -309,6 +313,126 @@ func TestAgentTool_Run_SkipSummarization(t *testing.T) {
 	}
 }
 
+func TestAgentTool_Run_SharesServices(t *testing.T) {
+	for _, forward := range []bool{false, true} {
+		t.Run(fmt.Sprintf("forward=%v", forward), func(t *testing.T) {
+			ctx := t.Context()
+
+			worker, err := agent.New(agent.Config{
+				Name:        "worker",
+				Description: "Writes reports.",
+				Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+					return func(yield func(*session.Event, error) bool) {
+						// The sub-agent sees the session and state of the
+						// caller, but only its own conversation.
+						if got := ctx.Session().ID(); got != "testSession" {
+							t.Errorf("sub-agent session ID = %q, want testSession", got)
+						}
+						if got, err := ctx.Session().State().Get("topic"); err != nil || got != "birds" {
+							t.Errorf("sub-agent state topic = %v, %v, want birds", got, err)
+						}
+						if got := ctx.Session().Events().Len(); got != 1 {
+							t.Errorf("sub-agent session has %d events, want the request only", got)
+						}
+						if _, err := ctx.Artifacts().Save(ctx, "report.txt", genai.NewPartFromText("report")); err != nil {
+							yield(nil, err)
+							return
+						}
+						event := session.NewEvent(ctx.InvocationID())
+						event.Author = ctx.Agent().Name()
+						event.Content = genai.NewContentFromText("report written", genai.RoleModel)
+						event.Actions.StateDelta["report_status"] = "done"
+						yield(event, nil)
+					}
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var callbackAuthors []string
+			testLLM := &testutil.MockModel{
+				Responses: []*genai.Content{
+					genai.NewContentFromFunctionCall("worker", map[string]any{"request": "write a report"}, genai.RoleModel),
+					genai.NewContentFromText("the report is ready", genai.RoleModel),
+				},
+			}
+			root, err := llmagent.New(llmagent.Config{
+				Name:  "root",
+				Model: testLLM,
+				Tools: []tool.Tool{agenttool.New(worker, &agenttool.Config{
+					ForwardEvents: forward,
+					EventCallback: func(_ tool.Context, event *session.Event) {
+						callbackAuthors = append(callbackAuthors, event.Author)
+					},
+				})},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sessionService := session.InMemoryService()
+			artifactService := artifact.InMemoryService()
+			r, err := runner.New(runner.Config{
+				AppName:         "testApp",
+				Agent:           root,
+				SessionService:  sessionService,
+				ArtifactService: artifactService,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := sessionService.Create(ctx, &session.CreateRequest{AppName: "testApp", UserID: "testUser", SessionID: "testSession", State: map[string]any{"topic": "birds"}}); err != nil {
+				t.Fatal(err)
+			}
+
+			var streamed []string
+			for event, err := range r.Run(ctx, "testUser", "testSession", genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{}) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				streamed = append(streamed, fmt.Sprintf("%s@%s forwarded=%v", event.Author, event.Branch, event.Forwarded))
+				if event.Forwarded {
+					if diff := cmp.Diff(session.EventActions{}, event.Actions); diff != "" {
+						t.Errorf("forwarded event actions mismatch (-want +got):\n%s", diff)
+					}
+				}
+			}
+
+			want := []string{"root@ forwarded=false", "root@ forwarded=false", "root@ forwarded=false"}
+			if forward {
+				want = []string{"root@ forwarded=false", "worker@worker forwarded=true", "root@ forwarded=false", "root@ forwarded=false"}
+			}
+			if diff := cmp.Diff(want, streamed); diff != "" {
+				t.Errorf("streamed events mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff([]string{"worker"}, callbackAuthors); diff != "" {
+				t.Errorf("EventCallback authors mismatch (-want +got):\n%s", diff)
+			}
+
+			resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "testApp", UserID: "testUser", SessionID: "testSession"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, err := resp.Session.State().Get("report_status"); err != nil || got != "done" {
+				t.Errorf("state report_status = %v, %v, want done", got, err)
+			}
+			for event := range resp.Session.Events().All() {
+				if event.Author == "worker" {
+					t.Errorf("forwarded event was committed to the parent session: %v", event)
+				}
+			}
+			// The function response event records the artifact saved by the sub-agent.
+			if got := resp.Session.Events().At(2).Actions.ArtifactDelta; got["report.txt"] == 0 {
+				t.Errorf("function response artifact delta = %v, want report.txt", got)
+			}
+			if _, err := artifactService.Load(ctx, &artifact.LoadRequest{AppName: "testApp", UserID: "testUser", SessionID: "testSession", FileName: "report.txt"}); err != nil {
+				t.Errorf("artifact of the sub-agent was not saved in the parent session: %v", err)
+			}
+		})
+	}
+}
+
 func createAgent(t *testing.T, inputSchema, outputSchema *genai.Schema) agent.Agent {
 	t.Helper()
 
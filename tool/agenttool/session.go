@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agenttool
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/memory"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+)
+
+// subSession is the session of the sub-agent. It is the session of the
+// calling agent, whose state it reads and writes through the tool context,
+// but it holds only the events of the sub-agent, which starts a new
+// conversation with the request of the tool call.
+type subSession struct {
+	toolCtx tool.Context
+
+	mu     sync.RWMutex
+	events []*session.Event
+}
+
+func newSubSession(toolCtx tool.Context) *subSession {
+	return &subSession{toolCtx: toolCtx}
+}
+
+func (s *subSession) ID() string {
+	return s.toolCtx.SessionID()
+}
+
+func (s *subSession) AppName() string {
+	return s.toolCtx.AppName()
+}
+
+func (s *subSession) UserID() string {
+	return s.toolCtx.UserID()
+}
+
+// appendEvent adds an event of the sub-agent. Like the runner, it skips
+// partial and forwarded events.
+func (s *subSession) appendEvent(event *session.Event) {
+	if event.Partial || event.Forwarded {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+func (s *subSession) State() session.State {
+	return s.toolCtx.State()
+}
+
+func (s *subSession) Events() session.Events {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return subEvents(slices.Clone(s.events))
+}
+
+func (s *subSession) LastUpdateTime() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.events) == 0 {
+		return time.Time{}
+	}
+	return s.events[len(s.events)-1].Timestamp
+}
+
+type subEvents []*session.Event
+
+func (e subEvents) All() iter.Seq[*session.Event] {
+	return slices.Values(e)
+}
+
+func (e subEvents) Len() int {
+	return len(e)
+}
+
+func (e subEvents) At(i int) *session.Event {
+	return e[i]
+}
+
+// callerMemory searches the memory of the calling agent.
+type callerMemory struct {
+	toolCtx tool.Context
+}
+
+func (m *callerMemory) AddSession(ctx context.Context, _ session.Session) error {
+	return fmt.Errorf("adding the sessions of sub-agents to memory is not supported in agent tools")
+}
+
+func (m *callerMemory) Search(ctx context.Context, query string) (*memory.SearchResponse, error) {
+	return m.toolCtx.SearchMemory(ctx, query)
+}
+
+var (
+	_ session.Session = (*subSession)(nil)
+	_ agent.Memory    = (*callerMemory)(nil)
+)
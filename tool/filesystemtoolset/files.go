@@ -0,0 +1,373 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystemtoolset
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/tool"
+)
+
+func newListDirectoryTool(s *set) *fsTool {
+	return &fsTool{
+		name:        "list_directory",
+		description: "Lists the entries of a directory, with their type and size.",
+		parameters: objectSchema(map[string]any{
+			"path": property("string", "The directory, relative to the root directory. Defaults to the root directory."),
+		}),
+		run: s.listDirectory,
+		set: s,
+	}
+}
+
+func (s *set) listDirectory(ctx tool.Context, root *os.Root, args map[string]any) (map[string]any, error) {
+	p, err := stringArg(args, "path", false)
+	if err != nil {
+		return nil, err
+	}
+	name, err := localPath(p)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := root.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open directory: %w", err)
+	}
+	defer dir.Close()
+	dirEntries, err := dir.ReadDir(-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+	slices.SortFunc(dirEntries, func(a, b os.DirEntry) int { return strings.Compare(a.Name(), b.Name()) })
+
+	entries := []any{}
+	result := map[string]any{}
+	for _, e := range dirEntries {
+		if len(entries) == s.maxResults {
+			result["truncated"] = true
+			break
+		}
+		entry := map[string]any{"name": e.Name()}
+		switch {
+		case e.Type()&os.ModeSymlink != 0:
+			entry["type"] = "symlink"
+		case e.IsDir():
+			entry["type"] = "directory"
+		default:
+			entry["type"] = "file"
+			if info, err := e.Info(); err == nil {
+				entry["size"] = info.Size()
+			}
+		}
+		entries = append(entries, entry)
+	}
+	result["entries"] = entries
+	return result, nil
+}
+
+func newReadFileTool(s *set) *fsTool {
+	return &fsTool{
+		name:        "read_file",
+		description: "Reads a text file. Use start_line and end_line to read a range of lines of large files.",
+		parameters: objectSchema(map[string]any{
+			"path":       property("string", "The file, relative to the root directory."),
+			"start_line": property("integer", "The first line to read, starting at 1. Defaults to 1."),
+			"end_line":   property("integer", "The last line to read, included. Defaults to the end of the file."),
+		}, "path"),
+		run: s.readFile,
+		set: s,
+	}
+}
+
+// readFile returns the lines of the file in the requested range. If the
+// lines exceed the maximum file size, the content ends at the last line
+// which fits and "truncated" is set.
+func (s *set) readFile(ctx tool.Context, root *os.Root, args map[string]any) (map[string]any, error) {
+	p, err := stringArg(args, "path", true)
+	if err != nil {
+		return nil, err
+	}
+	start, err := toolutils.IntArg(args, "start_line", 1)
+	if err != nil {
+		return nil, err
+	}
+	end, err := toolutils.IntArg(args, "end_line", 0)
+	if err != nil {
+		return nil, err
+	}
+	if start < 1 {
+		return nil, fmt.Errorf("start_line must be at least 1, got %d", start)
+	}
+	if end != 0 && end < start {
+		return nil, fmt.Errorf("end_line %d is before start_line %d", end, start)
+	}
+
+	name, err := localPath(p)
+	if err != nil {
+		return nil, err
+	}
+	f, err := openFile(root, name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var content strings.Builder
+	r := bufio.NewReader(f)
+	line, lastLine, truncated := 0, start-1, false
+	for end == 0 || line < end {
+		// Lines before start_line are only counted, and lines within the
+		// range are kept only as long as they fit in the maximum file size.
+		var limit int64
+		if line+1 >= start {
+			limit = s.maxFileSize - int64(content.Len())
+		}
+		text, n, err := readLine(r, limit)
+		if n > 0 {
+			line++
+			if line >= start {
+				if len(text) < n {
+					truncated = true
+					break
+				}
+				if bytes.IndexByte(text, 0) >= 0 {
+					return nil, fmt.Errorf("file %q is not a text file", p)
+				}
+				content.Write(text)
+				lastLine = line
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+	}
+	if line < start && start > 1 {
+		return nil, fmt.Errorf("start_line %d is past the end of the file, which has %d lines", start, line)
+	}
+
+	result := map[string]any{
+		"path":       p,
+		"content":    content.String(),
+		"start_line": start,
+		"end_line":   lastLine,
+	}
+	if truncated {
+		result["truncated"] = true
+	}
+	return result, nil
+}
+
+// readLine reads the next line of r, including the newline, and returns
+// the number of bytes read. The line is returned only if it is at most limit
+// bytes long, so that long lines are never buffered in full.
+func readLine(r *bufio.Reader, limit int64) ([]byte, int, error) {
+	var line []byte
+	n := 0
+	for {
+		chunk, err := r.ReadSlice('\n')
+		n += len(chunk)
+		if int64(n) <= limit {
+			line = append(line, chunk...)
+		} else {
+			line = nil
+		}
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			return line, n, err
+		}
+	}
+}
+
+func newWriteFileTool(s *set) *fsTool {
+	return &fsTool{
+		name:        "write_file",
+		description: "Writes a text file, replacing its content if it exists. Missing parent directories are created.",
+		parameters: objectSchema(map[string]any{
+			"path":    property("string", "The file, relative to the root directory."),
+			"content": property("string", "The content of the file."),
+		}, "path", "content"),
+		run: s.writeFile,
+		set: s,
+	}
+}
+
+func (s *set) writeFile(ctx tool.Context, root *os.Root, args map[string]any) (map[string]any, error) {
+	p, err := stringArg(args, "path", true)
+	if err != nil {
+		return nil, err
+	}
+	content, err := stringArg(args, "content", true)
+	if err != nil {
+		return nil, err
+	}
+	name, err := localPath(p)
+	if err != nil {
+		return nil, err
+	}
+	if name == "." {
+		return nil, errors.New("path must name a file")
+	}
+	if err := s.write(ctx, root, name, []byte(content), 0o644); err != nil {
+		return nil, err
+	}
+
+	result := map[string]any{
+		"path":          p,
+		"bytes_written": len(content),
+	}
+	if err := s.mirrorArtifact(ctx, name, []byte(content), result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func newEditFileTool(s *set) *fsTool {
+	return &fsTool{
+		name:        "edit_file",
+		description: "Edits a text file by replacing old_text with new_text. old_text must occur exactly once in the file, unless replace_all is set.",
+		parameters: objectSchema(map[string]any{
+			"path":        property("string", "The file, relative to the root directory."),
+			"old_text":    property("string", "The text to replace. Include enough context to make it unique."),
+			"new_text":    property("string", "The replacement text."),
+			"replace_all": property("boolean", "Replace all the occurrences of old_text."),
+		}, "path", "old_text", "new_text"),
+		run: s.editFile,
+		set: s,
+	}
+}
+
+func (s *set) editFile(ctx tool.Context, root *os.Root, args map[string]any) (map[string]any, error) {
+	p, err := stringArg(args, "path", true)
+	if err != nil {
+		return nil, err
+	}
+	oldText, err := stringArg(args, "old_text", true)
+	if err != nil {
+		return nil, err
+	}
+	if oldText == "" {
+		return nil, errors.New("old_text must not be empty")
+	}
+	newText, err := stringArg(args, "new_text", true)
+	if err != nil {
+		return nil, err
+	}
+	replaceAll, err := boolArg(args, "replace_all")
+	if err != nil {
+		return nil, err
+	}
+	name, err := localPath(p)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := openFile(root, name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if info.Size() > s.maxFileSize {
+		f.Close()
+		return nil, fmt.Errorf("file %q is larger than the maximum size of %d bytes", p, s.maxFileSize)
+	}
+	data, err := io.ReadAll(f)
+	f.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	content := string(data)
+	count := strings.Count(content, oldText)
+	switch {
+	case count == 0:
+		return nil, fmt.Errorf("old_text not found in %q", p)
+	case count > 1 && !replaceAll:
+		return nil, fmt.Errorf("old_text occurs %d times in %q, add context to make it unique or set replace_all", count, p)
+	case replaceAll:
+		content = strings.ReplaceAll(content, oldText, newText)
+	default:
+		content = strings.Replace(content, oldText, newText, 1)
+	}
+	if err := s.write(ctx, root, name, []byte(content), info.Mode().Perm()); err != nil {
+		return nil, err
+	}
+
+	result := map[string]any{
+		"path":         p,
+		"replacements": count,
+	}
+	if err := s.mirrorArtifact(ctx, name, []byte(content), result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// write writes the file, creating its parent directories.
+func (s *set) write(ctx tool.Context, root *os.Root, name string, data []byte, perm os.FileMode) error {
+	if int64(len(data)) > s.maxFileSize {
+		return fmt.Errorf("content is larger than the maximum size of %d bytes", s.maxFileSize)
+	}
+	if s.mirrorArtifacts && ctx.Artifacts() == nil {
+		return errors.New("artifact mirroring is enabled but the artifact service is not configured")
+	}
+	if err := mkdirAll(root, name); err != nil {
+		return fmt.Errorf("failed to create parent directories: %w", err)
+	}
+	f, err := root.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// openFile opens the regular file name for reading.
+func openFile(root *os.Root, name string) (*os.File, error) {
+	f, err := root.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		f.Close()
+		return nil, fmt.Errorf("%q is not a regular file", filepath.ToSlash(name))
+	}
+	return f, nil
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystemtoolset
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"google.golang.org/adk/tool"
+)
+
+// maxLineLength limits the length of the lines returned by grep_files.
+const maxLineLength = 500
+
+func newGlobFilesTool(s *set) *fsTool {
+	return &fsTool{
+		name:        "glob_files",
+		description: "Finds the files whose path, relative to the root directory, matches a glob pattern. \"*\" matches any sequence of characters except \"/\", \"**\" matches any number of directories, e.g. \"src/**/*.go\".",
+		parameters: objectSchema(map[string]any{
+			"pattern": property("string", "The glob pattern."),
+		}, "pattern"),
+		run: s.globFiles,
+		set: s,
+	}
+}
+
+func (s *set) globFiles(ctx tool.Context, root *os.Root, args map[string]any) (map[string]any, error) {
+	pattern, err := stringArg(args, "pattern", true)
+	if err != nil {
+		return nil, err
+	}
+	segments, err := globSegments(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []any{}
+	result := map[string]any{}
+	err = walkFiles(root, ".", func(name string) bool {
+		if !matchGlob(segments, strings.Split(name, "/")) {
+			return true
+		}
+		if len(files) == s.maxResults {
+			result["truncated"] = true
+			return false
+		}
+		files = append(files, name)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	result["files"] = files
+	return result, nil
+}
+
+func newGrepFilesTool(s *set) *fsTool {
+	return &fsTool{
+		name:        "grep_files",
+		description: "Searches the lines of the text files matching a regular expression (RE2 syntax). Returns the path, line number and text of the matching lines.",
+		parameters: objectSchema(map[string]any{
+			"pattern": property("string", "The regular expression."),
+			"path":    property("string", "The directory to search in, or the file to search, relative to the root directory. Defaults to the root directory."),
+			"include": property("string", "A glob pattern the names of the searched files must match, e.g. \"*.go\"."),
+		}, "pattern"),
+		run: s.grepFiles,
+		set: s,
+	}
+}
+
+func (s *set) grepFiles(ctx tool.Context, root *os.Root, args map[string]any) (map[string]any, error) {
+	pattern, err := stringArg(args, "pattern", true)
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	p, err := stringArg(args, "path", false)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := localPath(p)
+	if err != nil {
+		return nil, err
+	}
+	include, err := stringArg(args, "include", false)
+	if err != nil {
+		return nil, err
+	}
+	if include != "" {
+		if _, err := path.Match(include, ""); err != nil {
+			return nil, fmt.Errorf("invalid include pattern %q: %w", include, err)
+		}
+	}
+
+	matches := []any{}
+	result := map[string]any{}
+	var grepErr error
+	err = walkFiles(root, dir, func(name string) bool {
+		if include != "" {
+			if ok, _ := path.Match(include, path.Base(name)); !ok {
+				return true
+			}
+		}
+		more, err := s.grepFile(root, name, re, func(line int, text string) bool {
+			if len(matches) == s.maxResults {
+				result["truncated"] = true
+				return false
+			}
+			if len(text) > maxLineLength {
+				text = text[:maxLineLength]
+			}
+			matches = append(matches, map[string]any{"path": name, "line": line, "text": text})
+			return true
+		})
+		if err != nil {
+			grepErr = err
+			return false
+		}
+		return more
+	})
+	if err != nil {
+		return nil, err
+	}
+	if grepErr != nil {
+		return nil, grepErr
+	}
+	result["matches"] = matches
+	return result, nil
+}
+
+// grepFile calls match for the lines of the file matching re, until it
+// returns false. Binary files and files larger than the maximum file size
+// are skipped.
+func (s *set) grepFile(root *os.Root, name string, re *regexp.Regexp, match func(line int, text string) bool) (bool, error) {
+	f, err := root.Open(name)
+	if err != nil {
+		// The file may have been removed or may be a symbolic link leading
+		// outside of the root.
+		return true, nil
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil || !info.Mode().IsRegular() || info.Size() > s.maxFileSize {
+		return true, nil
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, int(s.maxFileSize)+1)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.IndexByte(text, 0) >= 0 {
+			// Binary file.
+			return true, nil
+		}
+		if re.MatchString(text) && !match(line, text) {
+			return false, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read %q: %w", name, err)
+	}
+	return true, nil
+}
+
+// walkFiles calls fn with the slash-separated path of the files in the
+// local directory dir, or with dir if it's a file, in lexical order, until
+// fn returns false. Symbolic links to directories are not followed, and
+// symbolic links leading outside of the root are skipped.
+func walkFiles(root *os.Root, dir string, fn func(name string) bool) error {
+	fsys := root.FS()
+	start := filepath.ToSlash(dir)
+	if _, err := fs.Stat(fsys, start); err != nil {
+		return fmt.Errorf("failed to open %q: %w", start, err)
+	}
+	err := fs.WalkDir(fsys, start, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if name == start {
+				return err
+			}
+			// Skip the entries which can't be read.
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			// Only report the links to files inside of the root.
+			if info, err := fs.Stat(fsys, name); err != nil || !info.Mode().IsRegular() {
+				return nil
+			}
+		}
+		if !fn(name) {
+			return fs.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to walk %q: %w", start, err)
+	}
+	return nil
+}
+
+// globSegments splits the glob pattern in path segments, and checks them.
+func globSegments(pattern string) ([]string, error) {
+	pattern = strings.TrimPrefix(path.Clean(pattern), "./")
+	if strings.HasPrefix(pattern, "/") || pattern == ".." || strings.HasPrefix(pattern, "../") {
+		return nil, fmt.Errorf("pattern %q is outside of the root directory", pattern)
+	}
+	segments := strings.Split(pattern, "/")
+	for _, segment := range segments {
+		if _, err := path.Match(segment, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return segments, nil
+}
+
+// matchGlob reports whether the path segments of name match the segments of
+// a glob pattern. The "**" segment matches any number of segments.
+func matchGlob(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchGlob(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], name[0]); !ok {
+		return false
+	}
+	return matchGlob(pattern[1:], name[1:])
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filesystemtoolset provides a tool set giving agents access to the
+// files of a directory.
+//
+// All paths are relative to the root directory of the tool set. Paths
+// leading outside of the root, including through symbolic links, are
+// rejected.
+package filesystemtoolset
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/tool"
+)
+
+// Config provides initial configuration for the filesystem ToolSet.
+type Config struct {
+	// Root is the directory the tools operate in. It must exist.
+	Root string
+	// ReadOnly removes the tools modifying files, write_file and edit_file.
+	ReadOnly bool
+	// MaxFileSize is the maximum size in bytes of the contents returned by
+	// read_file and written by write_file and edit_file. grep_files skips
+	// larger files. Defaults to 1 MiB.
+	MaxFileSize int64
+	// MaxResults is the maximum number of entries returned by
+	// list_directory, glob_files and grep_files. Defaults to 1000.
+	MaxResults int
+	// MirrorArtifacts saves the files written by write_file and edit_file as
+	// artifacts as well, named after their path, so that they can be
+	// displayed by the UI. The artifact service must be configured.
+	MirrorArtifacts bool
+	// ToolFilter selects tools for which tool.Predicate returns true.
+	// If ToolFilter is nil, then all tools are returned.
+	// tool.StringPredicate can be convenient if there's a known fixed list of tool names.
+	ToolFilter tool.Predicate
+}
+
+const (
+	defaultMaxFileSize = 1 << 20
+	defaultMaxResults  = 1000
+)
+
+// New returns filesystem ToolSet.
+// It provides the tools list_directory, read_file, glob_files and
+// grep_files, and, unless the tool set is read-only, write_file and
+// edit_file.
+//
+// Example:
+//
+//	fsToolSet, err := filesystemtoolset.New(filesystemtoolset.Config{
+//		Root:     "/path/to/workspace",
+//		ReadOnly: true,
+//	})
+//	...
+//	llmagent.New(llmagent.Config{
+//		Name:        "agent_name",
+//		Model:       model,
+//		Description: "...",
+//		Instruction: "...",
+//		Toolsets:    []tool.Toolset{fsToolSet},
+//	})
+func New(cfg Config) (tool.Toolset, error) {
+	if cfg.Root == "" {
+		return nil, errors.New("root directory is required")
+	}
+	root, err := filepath.Abs(cfg.Root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid root directory %q: %w", cfg.Root, err)
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid root directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("root %q is not a directory", cfg.Root)
+	}
+
+	s := &set{
+		root:            root,
+		maxFileSize:     cfg.MaxFileSize,
+		maxResults:      cfg.MaxResults,
+		mirrorArtifacts: cfg.MirrorArtifacts,
+		toolFilter:      cfg.ToolFilter,
+	}
+	if s.maxFileSize <= 0 {
+		s.maxFileSize = defaultMaxFileSize
+	}
+	if s.maxResults <= 0 {
+		s.maxResults = defaultMaxResults
+	}
+
+	s.tools = []*fsTool{
+		newListDirectoryTool(s),
+		newReadFileTool(s),
+		newGlobFilesTool(s),
+		newGrepFilesTool(s),
+	}
+	if !cfg.ReadOnly {
+		s.tools = append(s.tools, newWriteFileTool(s), newEditFileTool(s))
+	}
+	return s, nil
+}
+
+type set struct {
+	root            string
+	maxFileSize     int64
+	maxResults      int
+	mirrorArtifacts bool
+	toolFilter      tool.Predicate
+	tools           []*fsTool
+}
+
+func (*set) Name() string {
+	return "filesystem_tool_set"
+}
+
+// Tools returns the tools selected by the filter.
+func (s *set) Tools(ctx agent.ReadonlyContext) ([]tool.Tool, error) {
+	var tools []tool.Tool
+	for _, t := range s.tools {
+		if s.toolFilter != nil && !s.toolFilter(ctx, t) {
+			continue
+		}
+		tools = append(tools, t)
+	}
+	return tools, nil
+}
+
+// openRoot opens the root directory. The returned os.Root rejects paths
+// escaping the root, including through symbolic links.
+func (s *set) openRoot() (*os.Root, error) {
+	root, err := os.OpenRoot(s.root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open root directory: %w", err)
+	}
+	return root, nil
+}
+
+// localPath converts the slash-separated path p, relative to the root, to a
+// local path. Absolute paths and paths leading outside of the root are
+// rejected.
+func localPath(p string) (string, error) {
+	if p == "" {
+		return ".", nil
+	}
+	local := filepath.Clean(filepath.FromSlash(p))
+	if !filepath.IsLocal(local) {
+		return "", fmt.Errorf("path %q is outside of the root directory", p)
+	}
+	return local, nil
+}
+
+// mkdirAll creates the parent directories of the local path name in root.
+func mkdirAll(root *os.Root, name string) error {
+	dir := filepath.Dir(name)
+	if dir == "." {
+		return nil
+	}
+	if err := mkdirAll(root, dir); err != nil {
+		return err
+	}
+	if err := root.Mkdir(dir, 0o755); err != nil && !errors.Is(err, os.ErrExist) {
+		return err
+	}
+	return nil
+}
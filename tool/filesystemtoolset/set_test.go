@@ -0,0 +1,382 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystemtoolset_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/adk/artifact"
+	artifactinternal "google.golang.org/adk/internal/artifact"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/filesystemtoolset"
+)
+
+// newWorkspace creates a root directory with files, and a directory outside
+// of it linked from the root.
+func newWorkspace(t *testing.T) string {
+	t.Helper()
+	base := t.TempDir()
+	root := filepath.Join(base, "root")
+	files := map[string]string{
+		"root/README.md":         "# Project\nSome text.\n",
+		"root/src/main.go":       "package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n",
+		"root/src/util/util.go":  "package util\n\n// Hello says hello.\nfunc Hello() {}\n",
+		"root/src/data.bin":      "hello\x00world\n",
+		"root/notes/todo.txt":    "one\ntwo\nthree\nfour\nfive\n",
+		"root/notes/long.txt":    strings.Repeat("a line\n", 10),
+		"root/notes/wide.txt":    strings.Repeat("x", 100) + "\nend\n",
+		"outside/secret.txt":     "secret\n",
+		"outside/dir/hidden.txt": "hidden\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(base, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	links := map[string]string{
+		"root/secret.txt":  "../outside/secret.txt",
+		"root/outside_dir": "../outside/dir",
+		"root/todo.txt":    "notes/todo.txt",
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(base, filepath.FromSlash(name))); err != nil {
+			t.Skipf("symbolic links are not supported: %v", err)
+		}
+	}
+	return root
+}
+
+func loadTools(t *testing.T, cfg filesystemtoolset.Config) map[string]toolinternal.FunctionTool {
+	t.Helper()
+	ts, err := filesystemtoolset.New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	tools, err := ts.Tools(icontext.NewReadonlyContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{})))
+	if err != nil {
+		t.Fatalf("Tools() error = %v", err)
+	}
+	m := make(map[string]toolinternal.FunctionTool)
+	for _, tl := range tools {
+		m[tl.Name()] = tl.(toolinternal.FunctionTool)
+	}
+	return m
+}
+
+func createToolContext(t *testing.T, artifacts artifact.Service) tool.Context {
+	t.Helper()
+	params := icontext.InvocationContextParams{}
+	if artifacts != nil {
+		params.Artifacts = &artifactinternal.Artifacts{
+			Service:   artifacts,
+			AppName:   "app",
+			UserID:    "user",
+			SessionID: "session",
+		}
+	}
+	return toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), params), "", nil)
+}
+
+func TestNew(t *testing.T) {
+	root := newWorkspace(t)
+	for _, cfg := range []filesystemtoolset.Config{
+		{},
+		{Root: filepath.Join(root, "missing")},
+		{Root: filepath.Join(root, "README.md")},
+	} {
+		if _, err := filesystemtoolset.New(cfg); err == nil {
+			t.Errorf("New(%+v) succeeded, want error", cfg)
+		}
+	}
+}
+
+func TestToolset_Tools(t *testing.T) {
+	root := newWorkspace(t)
+	tests := []struct {
+		name string
+		cfg  filesystemtoolset.Config
+		want []string
+	}{
+		{
+			name: "read write",
+			cfg:  filesystemtoolset.Config{Root: root},
+			want: []string{"list_directory", "read_file", "glob_files", "grep_files", "write_file", "edit_file"},
+		},
+		{
+			name: "read only",
+			cfg:  filesystemtoolset.Config{Root: root, ReadOnly: true},
+			want: []string{"list_directory", "read_file", "glob_files", "grep_files"},
+		},
+		{
+			name: "filter",
+			cfg:  filesystemtoolset.Config{Root: root, ToolFilter: tool.StringPredicate([]string{"read_file"})},
+			want: []string{"read_file"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, err := filesystemtoolset.New(tt.cfg)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			tools, err := ts.Tools(icontext.NewReadonlyContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{})))
+			if err != nil {
+				t.Fatalf("Tools() error = %v", err)
+			}
+			var got []string
+			for _, tl := range tools {
+				got = append(got, tl.Name())
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("tool names mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTools_Run(t *testing.T) {
+	root := newWorkspace(t)
+	tools := loadTools(t, filesystemtoolset.Config{Root: root, MaxFileSize: 64, MaxResults: 3})
+
+	tests := []struct {
+		name string
+		tool string
+		args map[string]any
+		want map[string]any
+	}{
+		{
+			name: "list root",
+			tool: "list_directory",
+			args: map[string]any{},
+			want: map[string]any{
+				"entries": []any{
+					map[string]any{"name": "README.md", "type": "file", "size": int64(21)},
+					map[string]any{"name": "notes", "type": "directory"},
+					map[string]any{"name": "outside_dir", "type": "symlink"},
+				},
+				"truncated": true,
+			},
+		},
+		{
+			name: "list subdirectory",
+			tool: "list_directory",
+			args: map[string]any{"path": "src/util"},
+			want: map[string]any{
+				"entries": []any{
+					map[string]any{"name": "util.go", "type": "file", "size": int64(51)},
+				},
+			},
+		},
+		{
+			name: "read file",
+			tool: "read_file",
+			args: map[string]any{"path": "README.md"},
+			want: map[string]any{"path": "README.md", "content": "# Project\nSome text.\n", "start_line": 1, "end_line": 2},
+		},
+		{
+			name: "read line range",
+			tool: "read_file",
+			args: map[string]any{"path": "notes/todo.txt", "start_line": float64(2), "end_line": float64(3)},
+			want: map[string]any{"path": "notes/todo.txt", "content": "two\nthree\n", "start_line": 2, "end_line": 3},
+		},
+		{
+			name: "read through symlink inside root",
+			tool: "read_file",
+			args: map[string]any{"path": "todo.txt", "start_line": float64(5)},
+			want: map[string]any{"path": "todo.txt", "content": "five\n", "start_line": 5, "end_line": 5},
+		},
+		{
+			name: "read truncated",
+			tool: "read_file",
+			args: map[string]any{"path": "notes/long.txt"},
+			want: map[string]any{"path": "notes/long.txt", "content": strings.Repeat("a line\n", 9), "start_line": 1, "end_line": 9, "truncated": true},
+		},
+		{
+			name: "read line longer than maximum size",
+			tool: "read_file",
+			args: map[string]any{"path": "notes/wide.txt"},
+			want: map[string]any{"path": "notes/wide.txt", "content": "", "start_line": 1, "end_line": 0, "truncated": true},
+		},
+		{
+			name: "read after line longer than maximum size",
+			tool: "read_file",
+			args: map[string]any{"path": "notes/wide.txt", "start_line": float64(2)},
+			want: map[string]any{"path": "notes/wide.txt", "content": "end\n", "start_line": 2, "end_line": 2},
+		},
+		{
+			name: "glob recursive",
+			tool: "glob_files",
+			args: map[string]any{"pattern": "src/**/*.go"},
+			want: map[string]any{"files": []any{"src/main.go", "src/util/util.go"}},
+		},
+		{
+			name: "glob truncated",
+			tool: "glob_files",
+			args: map[string]any{"pattern": "**"},
+			want: map[string]any{"files": []any{"README.md", "notes/long.txt", "notes/todo.txt"}, "truncated": true},
+		},
+		{
+			name: "grep",
+			tool: "grep_files",
+			args: map[string]any{"pattern": "[Hh]ello", "include": "*.go"},
+			want: map[string]any{"matches": []any{
+				map[string]any{"path": "src/main.go", "line": 4, "text": "\tprintln(\"hello\")"},
+				map[string]any{"path": "src/util/util.go", "line": 3, "text": "// Hello says hello."},
+				map[string]any{"path": "src/util/util.go", "line": 4, "text": "func Hello() {}"},
+			}},
+		},
+		{
+			name: "grep skips binary files and links outside of root",
+			tool: "grep_files",
+			args: map[string]any{"pattern": "hello|secret"},
+			want: map[string]any{"matches": []any{
+				map[string]any{"path": "src/main.go", "line": 4, "text": "\tprintln(\"hello\")"},
+				map[string]any{"path": "src/util/util.go", "line": 3, "text": "// Hello says hello."},
+			}},
+		},
+		{
+			name: "grep file",
+			tool: "grep_files",
+			args: map[string]any{"pattern": "^t", "path": "notes/todo.txt"},
+			want: map[string]any{"matches": []any{
+				map[string]any{"path": "notes/todo.txt", "line": 2, "text": "two"},
+				map[string]any{"path": "notes/todo.txt", "line": 3, "text": "three"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tools[tt.tool].Run(createToolContext(t, nil), tt.args)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTools_Errors(t *testing.T) {
+	root := newWorkspace(t)
+	tools := loadTools(t, filesystemtoolset.Config{Root: root, MaxFileSize: 64})
+
+	tests := []struct {
+		name string
+		tool string
+		args map[string]any
+	}{
+		{"read parent", "read_file", map[string]any{"path": "../outside/secret.txt"}},
+		{"read absolute", "read_file", map[string]any{"path": filepath.Join(root, "README.md")}},
+		{"read symlink outside", "read_file", map[string]any{"path": "secret.txt"}},
+		{"read through symlinked directory outside", "read_file", map[string]any{"path": "outside_dir/hidden.txt"}},
+		{"read binary", "read_file", map[string]any{"path": "src/data.bin"}},
+		{"read directory", "read_file", map[string]any{"path": "src"}},
+		{"read past end", "read_file", map[string]any{"path": "notes/todo.txt", "start_line": float64(10)}},
+		{"read invalid range", "read_file", map[string]any{"path": "notes/todo.txt", "start_line": float64(3), "end_line": float64(2)}},
+		{"list parent", "list_directory", map[string]any{"path": ".."}},
+		{"list symlinked directory outside", "list_directory", map[string]any{"path": "outside_dir"}},
+		{"write parent", "write_file", map[string]any{"path": "../evil.txt", "content": "evil"}},
+		{"write through symlink outside", "write_file", map[string]any{"path": "secret.txt", "content": "evil"}},
+		{"write into symlinked directory outside", "write_file", map[string]any{"path": "outside_dir/evil.txt", "content": "evil"}},
+		{"write too large", "write_file", map[string]any{"path": "big.txt", "content": strings.Repeat("x", 65)}},
+		{"edit not found", "edit_file", map[string]any{"path": "README.md", "old_text": "missing", "new_text": "x"}},
+		{"glob parent", "glob_files", map[string]any{"pattern": "../**"}},
+		{"grep parent", "grep_files", map[string]any{"pattern": "secret", "path": ".."}},
+		{"grep invalid pattern", "grep_files", map[string]any{"pattern": "("}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tools[tt.tool].Run(createToolContext(t, nil), tt.args); err == nil {
+				t.Errorf("Run() = %v, want error", got)
+			}
+		})
+	}
+
+	for _, name := range []string{"../outside/evil.txt", "../outside/dir/evil.txt", "../evil.txt"} {
+		if _, err := os.Stat(filepath.Join(root, name)); err == nil {
+			t.Errorf("file %q was written outside of the root", name)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(root, "../outside/secret.txt"))
+	if err != nil || string(data) != "secret\n" {
+		t.Errorf("file outside of the root was modified: %q, %v", data, err)
+	}
+}
+
+func TestWriteAndEdit(t *testing.T) {
+	root := newWorkspace(t)
+	artifacts := artifact.InMemoryService()
+	tools := loadTools(t, filesystemtoolset.Config{Root: root, MirrorArtifacts: true})
+	ctx := createToolContext(t, artifacts)
+
+	got, err := tools["write_file"].Run(ctx, map[string]any{"path": "docs/guide/intro.md", "content": "a b a\n"})
+	if err != nil {
+		t.Fatalf("write_file error = %v", err)
+	}
+	want := map[string]any{"path": "docs/guide/intro.md", "bytes_written": 6, "artifact": "docs/guide/intro.md", "artifact_version": int64(1)}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("write_file mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := tools["edit_file"].Run(ctx, map[string]any{"path": "docs/guide/intro.md", "old_text": "a", "new_text": "c"}); err == nil {
+		t.Error("edit_file of ambiguous text succeeded, want error")
+	}
+	got, err = tools["edit_file"].Run(ctx, map[string]any{"path": "docs/guide/intro.md", "old_text": "b", "new_text": "d"})
+	if err != nil {
+		t.Fatalf("edit_file error = %v", err)
+	}
+	want = map[string]any{"path": "docs/guide/intro.md", "replacements": 1, "artifact": "docs/guide/intro.md", "artifact_version": int64(2)}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("edit_file mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := tools["edit_file"].Run(ctx, map[string]any{"path": "docs/guide/intro.md", "old_text": "a", "new_text": "e", "replace_all": true}); err != nil {
+		t.Fatalf("edit_file error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "docs/guide/intro.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "e d e\n"; got != want {
+		t.Errorf("file content = %q, want %q", got, want)
+	}
+	resp, err := artifacts.Load(t.Context(), &artifact.LoadRequest{AppName: "app", UserID: "user", SessionID: "session", FileName: "docs/guide/intro.md"})
+	if err != nil {
+		t.Fatalf("failed to load artifact: %v", err)
+	}
+	if got, want := string(resp.Part.InlineData.Data), "e d e\n"; got != want {
+		t.Errorf("artifact content = %q, want %q", got, want)
+	}
+
+	// Mirroring requires the artifact service.
+	if _, err := tools["write_file"].Run(createToolContext(t, nil), map[string]any{"path": "other.txt", "content": "x"}); err == nil {
+		t.Error("write_file without artifact service succeeded, want error")
+	}
+	if _, err := os.Stat(filepath.Join(root, "other.txt")); err == nil {
+		t.Error("file was written although mirroring failed")
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystemtoolset
+
+import (
+	"fmt"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// fsTool is a tool of the filesystem tool set. run is called with the opened
+// root directory.
+type fsTool struct {
+	name        string
+	description string
+	parameters  map[string]any
+	run         func(ctx tool.Context, root *os.Root, args map[string]any) (map[string]any, error)
+
+	set *set
+}
+
+// Name implements tool.Tool.
+func (t *fsTool) Name() string {
+	return t.name
+}
+
+// Description implements tool.Tool.
+func (t *fsTool) Description() string {
+	return t.description
+}
+
+// IsLongRunning implements tool.Tool.
+func (t *fsTool) IsLongRunning() bool {
+	return false
+}
+
+func (t *fsTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return toolutils.PackTool(req, t)
+}
+
+func (t *fsTool) Declaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{
+		Name:                 t.name,
+		Description:          t.description,
+		ParametersJsonSchema: t.parameters,
+	}
+}
+
+func (t *fsTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	margs, ok := args.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected args type, got: %T", args)
+	}
+	root, err := t.set.openRoot()
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+	return t.run(ctx, root, margs)
+}
+
+// objectSchema returns the JSON schema of the arguments of a tool.
+func objectSchema(properties map[string]any, required ...string) map[string]any {
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func property(typ, description string) map[string]any {
+	return map[string]any{"type": typ, "description": description}
+}
+
+// stringArg returns the string argument name.
+func stringArg(args map[string]any, name string, required bool) (string, error) {
+	v, ok := args[name]
+	if !ok || v == nil {
+		if required {
+			return "", fmt.Errorf("missing required argument %q", name)
+		}
+		return "", nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("argument %q must be a string, got: %T", name, v)
+	}
+	return s, nil
+}
+
+// boolArg returns the boolean argument name, false if it isn't set.
+func boolArg(args map[string]any, name string) (bool, error) {
+	switch v := args[name].(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	default:
+		return false, fmt.Errorf("argument %q must be a boolean, got: %T", name, v)
+	}
+}
+
+// mirrorArtifact saves the data written to the local path name as an
+// artifact, if enabled, and adds its version to the result.
+func (s *set) mirrorArtifact(ctx tool.Context, name string, data []byte, result map[string]any) error {
+	if !s.mirrorArtifacts {
+		return nil
+	}
+	artifactName := filepath.ToSlash(name)
+	mimeType, _, _ := mime.ParseMediaType(mime.TypeByExtension(path.Ext(artifactName)))
+	if mimeType == "" {
+		mimeType = "text/plain"
+	}
+	resp, err := ctx.Artifacts().Save(ctx, artifactName, genai.NewPartFromBytes(data, mimeType))
+	if err != nil {
+		return fmt.Errorf("file was written but saving artifact %q failed: %w", artifactName, err)
+	}
+	result["artifact"] = artifactName
+	result["artifact_version"] = resp.Version
+	return nil
+}
+
+var (
+	_ toolinternal.FunctionTool     = (*fsTool)(nil)
+	_ toolinternal.RequestProcessor = (*fsTool)(nil)
+)
@@ -46,6 +46,10 @@ type Config struct {
 	OutputSchema *jsonschema.Schema
 	// IsLongRunning makes a FunctionTool a long-running operation.
 	IsLongRunning bool
+	// Limits control the execution of the calls of the tool: their timeout,
+	// the number of concurrent calls and the rate of the calls. Use
+	// WithLimits for tools not created by New.
+	Limits
 }
 
 // Func represents a Go function that can be wrapped in a tool.
@@ -61,13 +65,8 @@ func New[TArgs, TResults any](cfg Config, handler Func[TArgs, TResults]) (tool.T
 	// TODO: How can we improve UX for functions that does not require an argument, returns a simple type value, or returns a no result?
 	//  https://github.com/modelcontextprotocol/go-sdk/discussions/37
 
-	var zeroArgs TArgs
-	argsType := reflect.TypeOf(zeroArgs)
-	for argsType != nil && argsType.Kind() == reflect.Ptr {
-		argsType = argsType.Elem()
-	}
-	if argsType == nil || (argsType.Kind() != reflect.Struct && argsType.Kind() != reflect.Map) {
-		return nil, fmt.Errorf("input must be a struct or a map or a pointer to those types, but received: %v: %w", argsType, ErrInvalidArgument)
+	if err := checkArgsType(reflect.TypeFor[TArgs]()); err != nil {
+		return nil, err
 	}
 
 	ischema, err := resolvedSchema[TArgs](cfg.InputSchema)
@@ -79,8 +78,14 @@ func New[TArgs, TResults any](cfg Config, handler Func[TArgs, TResults]) (tool.T
 		return nil, fmt.Errorf("failed to infer output schema: %w", err)
 	}
 
+	lim, err := newLimiter(cfg.Limits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid limits: %w", err)
+	}
+
 	return &functionTool[TArgs, TResults]{
 		cfg:          cfg,
+		limiter:      lim,
 		inputSchema:  ischema,
 		outputSchema: oschema,
 		handler:      handler,
@@ -98,6 +103,8 @@ type functionTool[TArgs, TResults any] struct {
 
 	// handler is the Go function.
 	handler Func[TArgs, TResults]
+	// limiter enforces cfg.Limits.
+	limiter *limiter
 }
 
 // Description implements tool.Tool.
@@ -146,11 +153,41 @@ func (f *functionTool[TArgs, TResults]) Declaration() *genai.FunctionDeclaration
 }
 
 // Run executes the tool with the provided context and yields events.
-func (f *functionTool[TArgs, TResults]) Run(ctx tool.Context, args any) (result map[string]any, err error) {
+func (f *functionTool[TArgs, TResults]) Run(ctx tool.Context, args any) (map[string]any, error) {
+	return f.limiter.run(ctx, f.Name(), args, f.run)
+}
+
+func (f *functionTool[TArgs, TResults]) run(ctx tool.Context, args any) (map[string]any, error) {
 	// TODO: Handle function call request from tc.InvocationContext.
+	return runHandler(f.Name(), args, f.outputSchema, func(m map[string]any) (any, error) {
+		input, err := typeutil.ConvertToWithJSONSchema[map[string]any, TArgs](m, f.inputSchema)
+		if err != nil {
+			return nil, err
+		}
+		return f.handler(ctx, input)
+	})
+}
+
+// checkArgsType checks that the arguments of a handler are a struct or a map,
+// possibly behind pointers.
+func checkArgsType(argsType reflect.Type) error {
+	elemType := argsType
+	for elemType != nil && elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType == nil || (elemType.Kind() != reflect.Struct && elemType.Kind() != reflect.Map) {
+		return fmt.Errorf("input must be a struct or a map or a pointer to those types, but received: %v: %w", elemType, ErrInvalidArgument)
+	}
+	return nil
+}
+
+// runHandler calls a tool handler with the arguments of the model and
+// converts its output to the result of the tool. Panics of the handler are
+// returned as errors.
+func runHandler(name string, args any, outputSchema *jsonschema.Resolved, handler func(map[string]any) (any, error)) (result map[string]any, err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			err = fmt.Errorf("panic in tool %q: %v\nstack: %s", f.Name(), r, debug.Stack())
+			err = fmt.Errorf("panic in tool %q: %v\nstack: %s", name, r, debug.Stack())
 		}
 	}()
 
@@ -158,15 +195,16 @@ func (f *functionTool[TArgs, TResults]) Run(ctx tool.Context, args any) (result
 	if !ok {
 		return nil, fmt.Errorf("unexpected args type, got: %T", args)
 	}
-	input, err := typeutil.ConvertToWithJSONSchema[map[string]any, TArgs](m, f.inputSchema)
-	if err != nil {
-		return nil, err
-	}
-	output, err := f.handler(ctx, input)
+	output, err := handler(m)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := typeutil.ConvertToWithJSONSchema[TResults, map[string]any](output, f.outputSchema)
+	return toResult(output, outputSchema)
+}
+
+// toResult converts the output of a tool handler to the result of the tool.
+func toResult(output any, outputSchema *jsonschema.Resolved) (map[string]any, error) {
+	resp, err := typeutil.ConvertToWithJSONSchema[any, map[string]any](output, outputSchema)
 	if err == nil { // all good
 		return resp, nil
 	}
@@ -175,8 +213,8 @@ func (f *functionTool[TArgs, TResults]) Run(ctx tool.Context, args any) (result
 	// functions.py __build_response_event does the following
 	// if not isinstance(function_result, dict):
 	// 		function_result = {'result': function_result}
-	if f.outputSchema != nil {
-		if err1 := f.outputSchema.Validate(output); err1 != nil {
+	if outputSchema != nil {
+		if err1 := outputSchema.Validate(output); err1 != nil {
 			return resp, err // if it fails propagate original err.
 		}
 	}
@@ -0,0 +1,312 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/time/rate"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// Limits control the execution of the calls of a tool. The zero value
+// doesn't limit anything.
+//
+// The context of a limited tool is cancelled when its call times out or the
+// invocation is cancelled. The call then returns right away, even if the
+// tool is still running: tools should stop when their context is done. The
+// changes of the state, artifacts and actions made through the tool context
+// are only kept when the call returns in time.
+type Limits struct {
+	// Timeout limits the duration of a call, including the time spent
+	// waiting for MaxConcurrent and RateLimit. When a call times out, the
+	// model receives a result with an "error" message and "timed_out" set to
+	// true.
+	Timeout time.Duration
+	// MaxConcurrent limits the number of calls of the tool running at the
+	// same time. Other calls wait for a running call to return.
+	MaxConcurrent int
+	// RateLimit limits the number of calls of the tool per second. Calls
+	// beyond the limit wait.
+	RateLimit float64
+	// RateBurst is the number of calls which can start at once before
+	// RateLimit applies. Defaults to 1.
+	RateBurst int
+}
+
+func (l Limits) validate() error {
+	if l.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative, got %v", l.Timeout)
+	}
+	if l.MaxConcurrent < 0 {
+		return fmt.Errorf("max concurrent calls must not be negative, got %d", l.MaxConcurrent)
+	}
+	if l.RateLimit < 0 {
+		return fmt.Errorf("rate limit must not be negative, got %v", l.RateLimit)
+	}
+	if l.RateBurst < 0 {
+		return fmt.Errorf("rate burst must not be negative, got %d", l.RateBurst)
+	}
+	return nil
+}
+
+// limiter enforces the Limits of a tool. A nil limiter doesn't limit
+// anything.
+type limiter struct {
+	timeout time.Duration
+	sem     *semaphore.Weighted
+	rate    *rate.Limiter
+}
+
+func newLimiter(l Limits) (*limiter, error) {
+	if err := l.validate(); err != nil {
+		return nil, err
+	}
+	if l == (Limits{}) {
+		return nil, nil
+	}
+	lim := &limiter{timeout: l.Timeout}
+	if l.MaxConcurrent > 0 {
+		lim.sem = semaphore.NewWeighted(int64(l.MaxConcurrent))
+	}
+	if l.RateLimit > 0 {
+		lim.rate = rate.NewLimiter(rate.Limit(l.RateLimit), max(l.RateBurst, 1))
+	}
+	return lim, nil
+}
+
+// run calls the tool named name within the limits.
+func (l *limiter) run(ctx tool.Context, name string, args any, run func(tool.Context, any) (map[string]any, error)) (map[string]any, error) {
+	if l == nil {
+		return run(ctx, args)
+	}
+
+	runCtx, cancel := context.Context(ctx), context.CancelFunc(func() {})
+	if l.timeout > 0 {
+		runCtx, cancel = context.WithTimeout(ctx, l.timeout)
+	}
+	defer cancel()
+
+	if l.sem != nil {
+		if err := l.sem.Acquire(runCtx, 1); err != nil {
+			return l.stopped(ctx, name)
+		}
+	}
+	if l.rate != nil {
+		// Wait fails right away if the wait would exceed the deadline.
+		if err := l.rate.Wait(runCtx); err != nil {
+			if l.sem != nil {
+				l.sem.Release(1)
+			}
+			return l.stopped(ctx, name)
+		}
+	}
+
+	type outcome struct {
+		result map[string]any
+		err    error
+	}
+	// The tool runs with its own copy of the actions of the call, which
+	// are only kept if it returns in time: a tool still running after the
+	// call stopped doesn't change the event of the call.
+	toolCtx, finish := toolinternal.WithContext(ctx, runCtx)
+	done := make(chan outcome, 1)
+	go func() {
+		var o outcome
+		defer func() {
+			if l.sem != nil {
+				l.sem.Release(1)
+			}
+			if r := recover(); r != nil {
+				o = outcome{err: fmt.Errorf("panic in tool %q: %v\nstack: %s", name, r, debug.Stack())}
+			}
+			done <- o
+		}()
+		o.result, o.err = run(toolCtx, args)
+	}()
+
+	select {
+	case o := <-done:
+		finish(true)
+		return o.result, o.err
+	case <-runCtx.Done():
+		finish(false)
+		return l.stopped(ctx, name)
+	}
+}
+
+// stopped returns the result of a call stopped before the tool returned,
+// because the invocation was cancelled or the call timed out.
+func (l *limiter) stopped(ctx tool.Context, name string) (map[string]any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("tool %q was cancelled: %w", name, err)
+	}
+	return map[string]any{
+		"error":     fmt.Sprintf("tool %q timed out after %v", name, l.timeout),
+		"timed_out": true,
+	}, nil
+}
+
+// WithLimits returns a tool calling t within the limits, e.g. a tool created
+// by mcptoolset or agenttool. t must be a function tool, i.e. a tool the model
+// can call, unlike built-in tools such as geminitool.GoogleSearch.
+//
+// Each call of WithLimits creates separate limits: wrap a tool once and use
+// the returned tool in all the agents sharing the limits.
+func WithLimits(t tool.Tool, limits Limits) (tool.Tool, error) {
+	fnTool, ok := t.(toolinternal.FunctionTool)
+	if !ok {
+		return nil, fmt.Errorf("tool %q is not a function tool", t.Name())
+	}
+	lim, err := newLimiter(limits)
+	if err != nil {
+		return nil, err
+	}
+	return &limitedTool{tool: fnTool, limiter: lim}, nil
+}
+
+// limitedTool calls a function tool within limits.
+type limitedTool struct {
+	tool    toolinternal.FunctionTool
+	limiter *limiter
+}
+
+// Name implements tool.Tool.
+func (t *limitedTool) Name() string {
+	return t.tool.Name()
+}
+
+// Description implements tool.Tool.
+func (t *limitedTool) Description() string {
+	return t.tool.Description()
+}
+
+// IsLongRunning implements tool.Tool.
+func (t *limitedTool) IsLongRunning() bool {
+	return t.tool.IsLongRunning()
+}
+
+// Declaration implements toolinternal.FunctionTool.
+func (t *limitedTool) Declaration() *genai.FunctionDeclaration {
+	return t.tool.Declaration()
+}
+
+// Run implements toolinternal.FunctionTool.
+func (t *limitedTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	return t.limiter.run(ctx, t.Name(), args, t.tool.Run)
+}
+
+// Unwrap implements toolinternal.Wrapper.
+func (t *limitedTool) Unwrap() tool.Tool {
+	return t.tool
+}
+
+// ProcessRequest lets the wrapped tool process the request, and makes the
+// model calls go through the limits.
+func (t *limitedTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	p, ok := t.tool.(toolinternal.RequestProcessor)
+	if !ok {
+		return toolutils.PackTool(req, t)
+	}
+	if err := p.ProcessRequest(ctx, req); err != nil {
+		return err
+	}
+	if _, ok := req.Tools[t.Name()]; ok {
+		req.Tools[t.Name()] = t
+	}
+	return nil
+}
+
+// ToolsetWithLimits returns a toolset whose function tools are called within
+// the limits, e.g. a toolset created by mcptoolset. Each tool has its own
+// limits, shared by the tools of the same name returned by the calls of
+// Tools.
+func ToolsetWithLimits(ts tool.Toolset, limits Limits) (tool.Toolset, error) {
+	if err := limits.validate(); err != nil {
+		return nil, err
+	}
+	return &limitedToolset{
+		toolset:  ts,
+		limits:   limits,
+		limiters: make(map[string]*limiter),
+	}, nil
+}
+
+// limitedToolset calls the function tools of a toolset within limits.
+type limitedToolset struct {
+	toolset tool.Toolset
+	limits  Limits
+
+	mu       sync.Mutex
+	limiters map[string]*limiter
+}
+
+// Name implements tool.Toolset.
+func (s *limitedToolset) Name() string {
+	return s.toolset.Name()
+}
+
+// Tools implements tool.Toolset.
+func (s *limitedToolset) Tools(ctx agent.ReadonlyContext) ([]tool.Tool, error) {
+	tools, err := s.toolset.Tools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	limited := make([]tool.Tool, 0, len(tools))
+	for _, t := range tools {
+		fnTool, ok := t.(toolinternal.FunctionTool)
+		if !ok {
+			limited = append(limited, t)
+			continue
+		}
+		lim, err := s.limiter(t.Name())
+		if err != nil {
+			return nil, err
+		}
+		limited = append(limited, &limitedTool{tool: fnTool, limiter: lim})
+	}
+	return limited, nil
+}
+
+func (s *limitedToolset) limiter(name string) (*limiter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if lim, ok := s.limiters[name]; ok {
+		return lim, nil
+	}
+	lim, err := newLimiter(s.limits)
+	if err != nil {
+		return nil, err
+	}
+	s.limiters[name] = lim
+	return lim, nil
+}
+
+var (
+	_ toolinternal.FunctionTool     = (*limitedTool)(nil)
+	_ toolinternal.RequestProcessor = (*limitedTool)(nil)
+	_ toolinternal.Wrapper          = (*limitedTool)(nil)
+)
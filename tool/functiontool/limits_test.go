@@ -0,0 +1,318 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/adk/agent"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+type Empty struct{}
+
+func newToolContext(ctx context.Context) tool.Context {
+	return toolinternal.NewToolContext(icontext.NewInvocationContext(ctx, icontext.InvocationContextParams{}), "", nil)
+}
+
+// blockingHandler blocks until its context is done.
+func blockingHandler(ctx tool.Context, _ Empty) (map[string]any, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func newTool(t *testing.T, cfg functiontool.Config, handler functiontool.Func[Empty, map[string]any]) toolinternal.FunctionTool {
+	t.Helper()
+	if cfg.Name == "" {
+		cfg.Name = "test_tool"
+	}
+	tl, err := functiontool.New(cfg, handler)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return tl.(toolinternal.FunctionTool)
+}
+
+func TestLimits_Timeout(t *testing.T) {
+	fnTool := newTool(t, functiontool.Config{Limits: functiontool.Limits{Timeout: 10 * time.Millisecond}}, blockingHandler)
+
+	got, err := fnTool.Run(newToolContext(t.Context()), map[string]any{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want := map[string]any{
+		"error":     `tool "test_tool" timed out after 10ms`,
+		"timed_out": true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLimits_TimeoutOfIgnoringHandler(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	fnTool := newTool(t, functiontool.Config{Limits: functiontool.Limits{Timeout: 10 * time.Millisecond}}, func(tool.Context, Empty) (map[string]any, error) {
+		<-release
+		return map[string]any{}, nil
+	})
+
+	got, err := fnTool.Run(newToolContext(t.Context()), map[string]any{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got["timed_out"] != true {
+		t.Errorf("Run() = %v, want a timed out result", got)
+	}
+}
+
+func TestLimits_LateWrites(t *testing.T) {
+	lateErr := make(chan error, 1)
+	fnTool := newTool(t, functiontool.Config{Limits: functiontool.Limits{Timeout: 10 * time.Millisecond}}, func(ctx tool.Context, _ Empty) (map[string]any, error) {
+		ctx.Actions().StateDelta["early"] = true
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		ctx.Actions().StateDelta["late"] = true
+		lateErr <- ctx.State().Set("late", true)
+		return nil, nil
+	})
+
+	actions := &session.EventActions{StateDelta: map[string]any{}}
+	ctx := toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}), "", actions)
+	if got, err := fnTool.Run(ctx, map[string]any{}); err != nil || got["timed_out"] != true {
+		t.Fatalf("Run() = %v, %v, want a timed out result", got, err)
+	}
+	if err := <-lateErr; err == nil {
+		t.Error("State().Set() after the timeout succeeded, want an error")
+	}
+	if len(actions.StateDelta) > 0 {
+		t.Errorf("StateDelta = %v, want the changes of the timed out call to be dropped", actions.StateDelta)
+	}
+}
+
+func TestLimits_NoTimeout(t *testing.T) {
+	fnTool := newTool(t, functiontool.Config{Limits: functiontool.Limits{Timeout: time.Minute}}, func(ctx tool.Context, _ Empty) (map[string]any, error) {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("tool context has no deadline")
+		}
+		ctx.Actions().StateDelta["done"] = true
+		ctx.Actions().SkipSummarization = true
+		return map[string]any{"ok": true}, nil
+	})
+
+	actions := &session.EventActions{StateDelta: map[string]any{}}
+	ctx := toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}), "", actions)
+	got, err := fnTool.Run(ctx, map[string]any{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if diff := cmp.Diff(map[string]any{"ok": true}, got); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+	wantActions := &session.EventActions{StateDelta: map[string]any{"done": true}, SkipSummarization: true}
+	if diff := cmp.Diff(wantActions, actions); diff != "" {
+		t.Errorf("actions mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLimits_Cancellation(t *testing.T) {
+	fnTool := newTool(t, functiontool.Config{Limits: functiontool.Limits{MaxConcurrent: 1}}, blockingHandler)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	if _, err := fnTool.Run(newToolContext(ctx), map[string]any{}); err == nil {
+		t.Error("Run() succeeded, want an error")
+	}
+}
+
+func TestLimits_MaxConcurrent(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	fnTool := newTool(t, functiontool.Config{Limits: functiontool.Limits{MaxConcurrent: 2}}, func(tool.Context, Empty) (map[string]any, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return map[string]any{}, nil
+	})
+
+	var wg sync.WaitGroup
+	for range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := fnTool.Run(newToolContext(t.Context()), map[string]any{}); err != nil {
+				t.Errorf("Run() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := maxRunning.Load(); got != 2 {
+		t.Errorf("max concurrent calls = %d, want 2", got)
+	}
+}
+
+func TestLimits_RateLimit(t *testing.T) {
+	fnTool := newTool(t, functiontool.Config{Limits: functiontool.Limits{RateLimit: 20, RateBurst: 2}}, func(tool.Context, Empty) (map[string]any, error) {
+		return map[string]any{}, nil
+	})
+
+	start := time.Now()
+	for range 4 {
+		if _, err := fnTool.Run(newToolContext(t.Context()), map[string]any{}); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	}
+	// 2 calls in the burst, then 2 calls at 50ms intervals.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("4 calls took %v, want at least 100ms", elapsed)
+	}
+}
+
+func TestLimits_RateLimitTimeout(t *testing.T) {
+	fnTool := newTool(t, functiontool.Config{Limits: functiontool.Limits{RateLimit: 1, Timeout: 10 * time.Millisecond}}, func(tool.Context, Empty) (map[string]any, error) {
+		return map[string]any{}, nil
+	})
+
+	ctx := newToolContext(t.Context())
+	if _, err := fnTool.Run(ctx, map[string]any{}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	got, err := fnTool.Run(ctx, map[string]any{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got["timed_out"] != true {
+		t.Errorf("Run() = %v, want a timed out result", got)
+	}
+}
+
+func TestLimits_Invalid(t *testing.T) {
+	for _, limits := range []functiontool.Limits{
+		{Timeout: -time.Second},
+		{MaxConcurrent: -1},
+		{RateLimit: -1},
+		{RateBurst: -1},
+	} {
+		if _, err := functiontool.New(functiontool.Config{Name: "test_tool", Limits: limits}, blockingHandler); err == nil {
+			t.Errorf("New() with limits %+v succeeded, want an error", limits)
+		}
+		if _, err := functiontool.WithLimits(newTool(t, functiontool.Config{}, blockingHandler), limits); err == nil {
+			t.Errorf("WithLimits() with limits %+v succeeded, want an error", limits)
+		}
+	}
+}
+
+func TestWithLimits(t *testing.T) {
+	inner := newTool(t, functiontool.Config{Description: "blocks"}, blockingHandler)
+	limited, err := functiontool.WithLimits(inner, functiontool.Limits{Timeout: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("WithLimits() error = %v", err)
+	}
+	if limited.Name() != "test_tool" || limited.Description() != "blocks" {
+		t.Errorf("WithLimits() = tool %q (%q), want tool %q (%q)", limited.Name(), limited.Description(), "test_tool", "blocks")
+	}
+
+	ctx := newToolContext(t.Context())
+	req := &model.LLMRequest{}
+	if err := limited.(toolinternal.RequestProcessor).ProcessRequest(ctx, req); err != nil {
+		t.Fatalf("ProcessRequest() error = %v", err)
+	}
+	if req.Tools["test_tool"] != limited {
+		t.Errorf("ProcessRequest() registered tool %v, want the limited tool", req.Tools["test_tool"])
+	}
+	if len(req.Config.Tools) != 1 || len(req.Config.Tools[0].FunctionDeclarations) != 1 {
+		t.Errorf("ProcessRequest() declared tools %v, want one function declaration", req.Config.Tools)
+	}
+
+	got, err := limited.(toolinternal.FunctionTool).Run(ctx, map[string]any{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got["timed_out"] != true {
+		t.Errorf("Run() = %v, want a timed out result", got)
+	}
+}
+
+// staticToolset returns new tools on each call of Tools, like MCP toolsets.
+type staticToolset struct {
+	newTool func() tool.Tool
+}
+
+func (s *staticToolset) Name() string {
+	return "static"
+}
+
+func (s *staticToolset) Tools(agent.ReadonlyContext) ([]tool.Tool, error) {
+	return []tool.Tool{s.newTool()}, nil
+}
+
+func TestToolsetWithLimits(t *testing.T) {
+	release := make(chan struct{})
+	var started atomic.Int32
+	ts, err := functiontool.ToolsetWithLimits(&staticToolset{newTool: func() tool.Tool {
+		return newTool(t, functiontool.Config{}, func(tool.Context, Empty) (map[string]any, error) {
+			started.Add(1)
+			<-release
+			return map[string]any{}, nil
+		})
+	}}, functiontool.Limits{MaxConcurrent: 1, Timeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("ToolsetWithLimits() error = %v", err)
+	}
+
+	ctx := newToolContext(t.Context())
+	var results [2]map[string]any
+	var wg sync.WaitGroup
+	for i := range results {
+		tools, err := ts.Tools(ctx)
+		if err != nil {
+			t.Fatalf("Tools() error = %v", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = tools[0].(toolinternal.FunctionTool).Run(ctx, map[string]any{})
+		}()
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The tools share the limits: the second call waits for the first one,
+	// which never returns, and times out.
+	wg.Wait()
+	close(release)
+
+	if results[0]["timed_out"] != true || results[1]["timed_out"] != true {
+		t.Errorf("results = %v, want both calls to time out", results)
+	}
+	if got := started.Load(); got != 1 {
+		t.Errorf("%d calls started, want 1", got)
+	}
+}
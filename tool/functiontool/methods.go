@@ -0,0 +1,296 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/internal/typeutil"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// ToolsetConfig is the input to the NewToolset function.
+type ToolsetConfig struct {
+	// Name of the toolset. Defaults to the name of the struct type in snake
+	// case.
+	Name string
+	// Prefix is prepended to the names of the tools, e.g. "calendar_".
+	Prefix string
+	// Descriptions of the tools, by method name. They take precedence over
+	// the descriptions in struct tags.
+	Descriptions map[string]string
+	// ToolFilter selects the tools exposed to the model. Defaults to all the
+	// tools.
+	ToolFilter tool.Predicate
+	// Limits control the execution of the calls of each tool, see
+	// Config.Limits. Each tool has its own limits.
+	Limits
+}
+
+// NewToolset creates a toolset with a tool for each exported method of
+// receiver with the signature
+//
+//	func(tool.Context, Args) (Result, error)
+//
+// where Args and Result follow the rules of New. Other methods are ignored.
+// Pass a pointer to include the methods with a pointer receiver.
+//
+// The tools are named after the methods, in snake case and prefixed with
+// cfg.Prefix: the method GetEvent becomes the tool "get_event". Their
+// descriptions come from cfg.Descriptions, or from the "description" tags of
+// blank fields whose "tool" tags name the methods, and are empty for the
+// other methods; naming a method which isn't a tool is an error:
+//
+//	type Calendar struct {
+//		_ struct{} `tool:"GetEvent" description:"Returns the event with the given ID."`
+//		_ struct{} `tool:"ListEvents" description:"Lists the events of a day."`
+//
+//		client *calendar.Client
+//	}
+//
+//	func (c *Calendar) GetEvent(ctx tool.Context, args GetEventArgs) (*Event, error)
+//	func (c *Calendar) ListEvents(ctx tool.Context, args ListEventsArgs) (*EventList, error)
+//
+//	ts, err := functiontool.NewToolset(functiontool.ToolsetConfig{Prefix: "calendar_"}, &Calendar{client: client})
+func NewToolset(cfg ToolsetConfig, receiver any) (tool.Toolset, error) {
+	v := reflect.ValueOf(receiver)
+	if !v.IsValid() {
+		return nil, fmt.Errorf("receiver must not be nil: %w", ErrInvalidArgument)
+	}
+	for p := v; p.Kind() == reflect.Pointer; p = p.Elem() {
+		if p.IsNil() {
+			return nil, fmt.Errorf("receiver must not be a nil %v: %w", v.Type(), ErrInvalidArgument)
+		}
+	}
+	structType := v.Type()
+	for structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if cfg.Name == "" {
+		cfg.Name = snakeCase(structType.Name())
+	}
+
+	descriptions := make(map[string]string)
+	if structType.Kind() == reflect.Struct {
+		for i := range structType.NumField() {
+			field := structType.Field(i)
+			if method, ok := field.Tag.Lookup("tool"); ok && field.Name == "_" {
+				descriptions[method] = field.Tag.Get("description")
+			}
+		}
+	}
+	for method, description := range cfg.Descriptions {
+		descriptions[method] = description
+	}
+
+	s := &methodSet{name: cfg.Name, filter: cfg.ToolFilter}
+	for i := range v.NumMethod() {
+		method := v.Type().Method(i)
+		if !isToolMethod(method.Type) {
+			continue
+		}
+		t, err := newMethodTool(cfg.Prefix+snakeCase(method.Name), descriptions[method.Name], v.Method(i), cfg.Limits)
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %w", method.Name, err)
+		}
+		s.tools = append(s.tools, t)
+		delete(descriptions, method.Name)
+	}
+	if len(s.tools) == 0 {
+		return nil, fmt.Errorf("type %s has no method with the signature func(tool.Context, Args) (Result, error): %w", v.Type(), ErrInvalidArgument)
+	}
+	// Catch the descriptions of misspelled methods, which would otherwise
+	// leave the tools without description.
+	if len(descriptions) > 0 {
+		return nil, fmt.Errorf("descriptions of unknown methods %v of %s: %w", slices.Sorted(maps.Keys(descriptions)), v.Type(), ErrInvalidArgument)
+	}
+	return s, nil
+}
+
+var (
+	toolContextType = reflect.TypeFor[tool.Context]()
+	errorType       = reflect.TypeFor[error]()
+)
+
+// isToolMethod reports whether the method, whose first input is the
+// receiver, has the signature of a tool.
+func isToolMethod(methodType reflect.Type) bool {
+	return methodType.NumIn() == 3 && methodType.In(1) == toolContextType &&
+		methodType.NumOut() == 2 && methodType.Out(1) == errorType
+}
+
+// methodSet is the toolset created by NewToolset.
+type methodSet struct {
+	name   string
+	tools  []tool.Tool
+	filter tool.Predicate
+}
+
+// Name implements tool.Toolset.
+func (s *methodSet) Name() string {
+	return s.name
+}
+
+// Tools implements tool.Toolset.
+func (s *methodSet) Tools(ctx agent.ReadonlyContext) ([]tool.Tool, error) {
+	if s.filter == nil {
+		return slices.Clone(s.tools), nil
+	}
+	var tools []tool.Tool
+	for _, t := range s.tools {
+		if s.filter(ctx, t) {
+			tools = append(tools, t)
+		}
+	}
+	return tools, nil
+}
+
+// methodTool calls a method with the signature of a Func.
+type methodTool struct {
+	name        string
+	description string
+	method      reflect.Value
+	argsType    reflect.Type
+
+	inputSchema  *jsonschema.Resolved
+	outputSchema *jsonschema.Resolved
+	limiter      *limiter
+}
+
+func newMethodTool(name, description string, method reflect.Value, limits Limits) (*methodTool, error) {
+	argsType := method.Type().In(1)
+	if err := checkArgsType(argsType); err != nil {
+		return nil, err
+	}
+
+	ischema, err := resolvedSchemaForType(argsType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer input schema: %w", err)
+	}
+	oschema, err := resolvedSchemaForType(method.Type().Out(0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer output schema: %w", err)
+	}
+	lim, err := newLimiter(limits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid limits: %w", err)
+	}
+	return &methodTool{
+		name:         name,
+		description:  description,
+		method:       method,
+		argsType:     argsType,
+		inputSchema:  ischema,
+		outputSchema: oschema,
+		limiter:      lim,
+	}, nil
+}
+
+// Name implements tool.Tool.
+func (t *methodTool) Name() string {
+	return t.name
+}
+
+// Description implements tool.Tool.
+func (t *methodTool) Description() string {
+	return t.description
+}
+
+// IsLongRunning implements tool.Tool.
+func (t *methodTool) IsLongRunning() bool {
+	return false
+}
+
+// ProcessRequest packs the method tool's declaration into the LLM request.
+func (t *methodTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return toolutils.PackTool(req, t)
+}
+
+// Declaration implements toolinternal.FunctionTool.
+func (t *methodTool) Declaration() *genai.FunctionDeclaration {
+	decl := &genai.FunctionDeclaration{
+		Name:        t.Name(),
+		Description: t.Description(),
+	}
+	if t.inputSchema != nil {
+		decl.ParametersJsonSchema = t.inputSchema.Schema()
+	}
+	if t.outputSchema != nil {
+		decl.ResponseJsonSchema = t.outputSchema.Schema()
+	}
+	return decl
+}
+
+// Run implements toolinternal.FunctionTool.
+func (t *methodTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	return t.limiter.run(ctx, t.Name(), args, t.run)
+}
+
+func (t *methodTool) run(ctx tool.Context, args any) (map[string]any, error) {
+	return runHandler(t.Name(), args, t.outputSchema, func(m map[string]any) (any, error) {
+		input := reflect.New(t.argsType)
+		if err := typeutil.ConvertIntoWithJSONSchema(m, input.Interface(), t.inputSchema); err != nil {
+			return nil, err
+		}
+		out := t.method.Call([]reflect.Value{reflect.ValueOf(&ctx).Elem(), input.Elem()})
+		err, _ := out[1].Interface().(error)
+		return out[0].Interface(), err
+	})
+}
+
+func resolvedSchemaForType(t reflect.Type) (*jsonschema.Resolved, error) {
+	schema, err := jsonschema.ForType(t, &jsonschema.ForOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return schema.Resolve(nil)
+}
+
+// snakeCase converts a Go identifier to snake case, e.g. "GetHTTPStatus" to
+// "get_http_status".
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a word at the first upper case letter after a lower case
+			// letter or a digit, and at the last upper case letter of an
+			// acronym followed by a lower case letter.
+			if i > 0 && (!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) && runes[i-1] != '_' {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var (
+	_ toolinternal.FunctionTool     = (*methodTool)(nil)
+	_ toolinternal.RequestProcessor = (*methodTool)(nil)
+)
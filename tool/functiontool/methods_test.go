@@ -0,0 +1,223 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+type Calculator struct {
+	_ struct{} `tool:"Add" description:"Adds two numbers."`
+	_ struct{} `tool:"Divide" description:"Divides two numbers."`
+
+	calls int
+}
+
+type BinaryArgs struct {
+	A float64 `json:"a"`
+	B float64 `json:"b"`
+}
+
+type Quotient struct {
+	Value float64 `json:"value"`
+}
+
+func (c *Calculator) Add(ctx tool.Context, args BinaryArgs) (float64, error) {
+	c.calls++
+	return args.A + args.B, nil
+}
+
+func (c *Calculator) Divide(ctx tool.Context, args *BinaryArgs) (Quotient, error) {
+	c.calls++
+	if args.B == 0 {
+		return Quotient{}, errors.New("division by zero")
+	}
+	return Quotient{Value: args.A / args.B}, nil
+}
+
+func (c *Calculator) GetHTTPStatus(ctx tool.Context, args map[string]any) (map[string]any, error) {
+	return map[string]any{"status": 200}, nil
+}
+
+// Reset doesn't have the signature of a tool.
+func (c *Calculator) Reset() {
+	c.calls = 0
+}
+
+func TestNewToolset(t *testing.T) {
+	calc := &Calculator{}
+	ts, err := functiontool.NewToolset(functiontool.ToolsetConfig{
+		Prefix:       "calc_",
+		Descriptions: map[string]string{"Add": "Returns the sum of a and b."},
+	}, calc)
+	if err != nil {
+		t.Fatalf("NewToolset() error = %v", err)
+	}
+	if got, want := ts.Name(), "calculator"; got != want {
+		t.Errorf("Name() = %q, want %q", got, want)
+	}
+
+	ctx := toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}), "", nil)
+	tools, err := ts.Tools(ctx)
+	if err != nil {
+		t.Fatalf("Tools() error = %v", err)
+	}
+	got := map[string]string{}
+	byName := map[string]toolinternal.FunctionTool{}
+	for _, tl := range tools {
+		got[tl.Name()] = tl.Description()
+		byName[tl.Name()] = tl.(toolinternal.FunctionTool)
+	}
+	want := map[string]string{
+		"calc_add":             "Returns the sum of a and b.",
+		"calc_divide":          "Divides two numbers.",
+		"calc_get_http_status": "",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("Tools() mismatch (-want +got):\n%s", diff)
+	}
+
+	tests := []struct {
+		name    string
+		tool    string
+		args    map[string]any
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name: "basic result",
+			tool: "calc_add",
+			args: map[string]any{"a": 1.0, "b": 2.0},
+			want: map[string]any{"result": 3.0},
+		},
+		{
+			name: "struct result and pointer args",
+			tool: "calc_divide",
+			args: map[string]any{"a": 1.0, "b": 4.0},
+			want: map[string]any{"value": 0.25},
+		},
+		{
+			name: "map args",
+			tool: "calc_get_http_status",
+			args: map[string]any{},
+			want: map[string]any{"status": 200.0},
+		},
+		{
+			name:    "method error",
+			tool:    "calc_divide",
+			args:    map[string]any{"a": 1.0, "b": 0.0},
+			wantErr: true,
+		},
+		{
+			name:    "invalid args",
+			tool:    "calc_add",
+			args:    map[string]any{"a": "one", "b": 2.0},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := byName[tc.tool].Run(ctx, tc.args)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+	if calc.calls != 3 {
+		t.Errorf("methods were called %d times, want 3", calc.calls)
+	}
+
+	decl := byName["calc_add"].Declaration()
+	if decl.ParametersJsonSchema == nil || decl.ResponseJsonSchema == nil {
+		t.Errorf("Declaration() = %+v, want parameters and response schemas", decl)
+	}
+}
+
+func TestNewToolset_Filter(t *testing.T) {
+	ts, err := functiontool.NewToolset(functiontool.ToolsetConfig{
+		Name:       "math",
+		ToolFilter: tool.StringPredicate([]string{"add", "divide"}),
+	}, &Calculator{})
+	if err != nil {
+		t.Fatalf("NewToolset() error = %v", err)
+	}
+	tools, err := ts.Tools(icontext.NewReadonlyContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{})))
+	if err != nil {
+		t.Fatalf("Tools() error = %v", err)
+	}
+	var names []string
+	for _, tl := range tools {
+		names = append(names, tl.Name())
+	}
+	if diff := cmp.Diff([]string{"add", "divide"}, names); diff != "" {
+		t.Errorf("Tools() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type noTools struct{}
+
+func (noTools) Hello() string {
+	return "hello"
+}
+
+type invalidArgs struct{}
+
+func (invalidArgs) Echo(ctx tool.Context, s string) (string, error) {
+	return s, nil
+}
+
+func TestNewToolset_Invalid(t *testing.T) {
+	for _, receiver := range []any{nil, (*Calculator)(nil), noTools{}, invalidArgs{}, Calculator{}} {
+		if _, err := functiontool.NewToolset(functiontool.ToolsetConfig{}, receiver); !errors.Is(err, functiontool.ErrInvalidArgument) {
+			t.Errorf("NewToolset(%T) error = %v, want %v", receiver, err, functiontool.ErrInvalidArgument)
+		}
+	}
+
+	cfg := functiontool.ToolsetConfig{Descriptions: map[string]string{"Subtract": "Subtracts two numbers."}}
+	if _, err := functiontool.NewToolset(cfg, &Calculator{}); !errors.Is(err, functiontool.ErrInvalidArgument) {
+		t.Errorf("NewToolset() with the description of an unknown method error = %v, want %v", err, functiontool.ErrInvalidArgument)
+	}
+}
+
+func TestNewToolset_ToolsNotShared(t *testing.T) {
+	ts, err := functiontool.NewToolset(functiontool.ToolsetConfig{}, &Calculator{})
+	if err != nil {
+		t.Fatalf("NewToolset() error = %v", err)
+	}
+	ctx := icontext.NewReadonlyContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}))
+	tools, err := ts.Tools(ctx)
+	if err != nil {
+		t.Fatalf("Tools() error = %v", err)
+	}
+	tools[0] = nil
+	again, err := ts.Tools(ctx)
+	if err != nil {
+		t.Fatalf("Tools() error = %v", err)
+	}
+	if again[0] == nil {
+		t.Error("Tools() returned the slice modified by a previous caller")
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geminitool
+
+import (
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// CodeExecution is a built-in tool that lets Gemini models generate and run
+// Python code to compute their responses.
+// The code runs in a sandbox of the model provider, not locally.
+type CodeExecution struct{}
+
+// Name implements tool.Tool.
+func (CodeExecution) Name() string {
+	return "code_execution"
+}
+
+// Description implements tool.Tool.
+func (CodeExecution) Description() string {
+	return "Generates and runs Python code to solve problems."
+}
+
+// ProcessRequest adds the CodeExecution tool to the LLM request.
+func (CodeExecution) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return setTool(req, &genai.Tool{
+		CodeExecution: &genai.ToolCodeExecution{},
+	})
+}
+
+// IsLongRunning implements tool.Tool.
+func (CodeExecution) IsLongRunning() bool {
+	return false
+}
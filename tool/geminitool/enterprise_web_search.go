@@ -0,0 +1,54 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geminitool
+
+import (
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// EnterpriseWebSearch is a built-in tool that grounds the responses of
+// Gemini models with a web index suited for enterprises, e.g. one which
+// doesn't log customer data. It's only available on Vertex AI.
+// The tool operates internally within the model and does not require or
+// perform local code execution.
+type EnterpriseWebSearch struct {
+	// ExcludeDomains lists the domains excluded from the search results.
+	ExcludeDomains []string
+}
+
+// Name implements tool.Tool.
+func (EnterpriseWebSearch) Name() string {
+	return "enterprise_web_search"
+}
+
+// Description implements tool.Tool.
+func (EnterpriseWebSearch) Description() string {
+	return "Performs an enterprise-compliant web search to retrieve information from the web."
+}
+
+// ProcessRequest adds the EnterpriseWebSearch tool to the LLM request.
+func (s EnterpriseWebSearch) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return setTool(req, &genai.Tool{
+		EnterpriseWebSearch: &genai.EnterpriseWebSearch{ExcludeDomains: s.ExcludeDomains},
+	})
+}
+
+// IsLongRunning implements tool.Tool.
+func (EnterpriseWebSearch) IsLongRunning() bool {
+	return false
+}
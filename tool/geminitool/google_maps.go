@@ -0,0 +1,58 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geminitool
+
+import (
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// GoogleMaps is a built-in tool that grounds the responses of Gemini models
+// with data from Google Maps, e.g. places and their reviews.
+// The tool operates internally within the model and does not require or
+// perform local code execution.
+type GoogleMaps struct {
+	// EnableWidget requests a context token in the grounding metadata, to
+	// render a Google Maps widget with the places of the response.
+	EnableWidget bool
+}
+
+// Name implements tool.Tool.
+func (GoogleMaps) Name() string {
+	return "google_maps"
+}
+
+// Description implements tool.Tool.
+func (GoogleMaps) Description() string {
+	return "Retrieves places and geographic information from Google Maps."
+}
+
+// ProcessRequest adds the GoogleMaps tool to the LLM request.
+func (m GoogleMaps) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	googleMaps := &genai.GoogleMaps{}
+	if m.EnableWidget {
+		googleMaps.EnableWidget = genai.Ptr(true)
+	}
+	return setTool(req, &genai.Tool{
+		GoogleMaps: googleMaps,
+	})
+}
+
+// IsLongRunning implements tool.Tool.
+func (GoogleMaps) IsLongRunning() bool {
+	return false
+}
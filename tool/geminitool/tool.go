@@ -26,7 +26,13 @@
 //		},
 //	})
 //
-// Package also provides default tools like GoogleSearch.
+// Package also provides default tools: GoogleSearch, URLContext,
+// CodeExecution, GoogleMaps, EnterpriseWebSearch and VertexAISearch.
+//
+// Not all built-in tools can be used together, or together with function
+// tools, depending on the model and on whether it's served by the Gemini API
+// or Vertex AI. Incompatible tools are reported when the agent calls the
+// model.
 package geminitool
 
 import (
@@ -62,9 +68,36 @@ func (t *geminiTool) Name() string {
 	return t.name
 }
 
-// Description implements tool.Tool.
+// Description implements tool.Tool. It describes the built-in tool set in
+// the genai.Tool.
 func (t *geminiTool) Description() string {
-	return "Performs a Google search to retrieve information from the web."
+	switch {
+	case t.value == nil:
+		return "Gemini built-in tool."
+	case t.value.GoogleSearch != nil:
+		return GoogleSearch{}.Description()
+	case t.value.GoogleSearchRetrieval != nil:
+		return "Performs a Google search to ground the response with dynamic retrieval."
+	case t.value.URLContext != nil:
+		return URLContext{}.Description()
+	case t.value.CodeExecution != nil:
+		return CodeExecution{}.Description()
+	case t.value.GoogleMaps != nil:
+		return GoogleMaps{}.Description()
+	case t.value.EnterpriseWebSearch != nil:
+		return EnterpriseWebSearch{}.Description()
+	case t.value.Retrieval != nil && t.value.Retrieval.VertexAISearch != nil:
+		return VertexAISearch{}.Description()
+	case t.value.Retrieval != nil:
+		return "Retrieves documents to ground the response."
+	case t.value.ComputerUse != nil:
+		return "Operates a computer environment."
+	case t.value.FileSearch != nil:
+		return "Searches uploaded files."
+	case len(t.value.FunctionDeclarations) > 0:
+		return "Calls declared functions."
+	}
+	return "Gemini built-in tool."
 }
 
 // IsLongRunning implements tool.Tool.
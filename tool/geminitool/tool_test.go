@@ -22,6 +22,7 @@ import (
 
 	"google.golang.org/adk/internal/toolinternal"
 	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
 	"google.golang.org/adk/tool/geminitool"
 )
 
@@ -91,3 +92,114 @@ func TestGeminiTool_ProcessRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestBuiltInTools_ProcessRequest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tool     tool.Tool
+		wantName string
+		wantTool *genai.Tool
+		wantErr  bool
+	}{
+		{
+			name:     "google search",
+			tool:     geminitool.GoogleSearch{},
+			wantName: "google_search",
+			wantTool: &genai.Tool{GoogleSearch: &genai.GoogleSearch{}},
+		},
+		{
+			name:     "url context",
+			tool:     geminitool.URLContext{},
+			wantName: "url_context",
+			wantTool: &genai.Tool{URLContext: &genai.URLContext{}},
+		},
+		{
+			name:     "code execution",
+			tool:     geminitool.CodeExecution{},
+			wantName: "code_execution",
+			wantTool: &genai.Tool{CodeExecution: &genai.ToolCodeExecution{}},
+		},
+		{
+			name:     "google maps",
+			tool:     geminitool.GoogleMaps{EnableWidget: true},
+			wantName: "google_maps",
+			wantTool: &genai.Tool{GoogleMaps: &genai.GoogleMaps{EnableWidget: genai.Ptr(true)}},
+		},
+		{
+			name:     "enterprise web search",
+			tool:     geminitool.EnterpriseWebSearch{ExcludeDomains: []string{"example.com"}},
+			wantName: "enterprise_web_search",
+			wantTool: &genai.Tool{EnterpriseWebSearch: &genai.EnterpriseWebSearch{ExcludeDomains: []string{"example.com"}}},
+		},
+		{
+			name:     "vertex ai search",
+			tool:     geminitool.VertexAISearch{DataStore: "projects/p/locations/global/collections/c/dataStores/d", MaxResults: 5},
+			wantName: "vertex_ai_search",
+			wantTool: &genai.Tool{Retrieval: &genai.Retrieval{VertexAISearch: &genai.VertexAISearch{
+				Datastore:  "projects/p/locations/global/collections/c/dataStores/d",
+				MaxResults: genai.Ptr[int32](5),
+			}}},
+		},
+		{
+			name:     "vertex ai search without data store",
+			tool:     geminitool.VertexAISearch{},
+			wantName: "vertex_ai_search",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tool.Name(); got != tt.wantName {
+				t.Errorf("Name() = %q, want %q", got, tt.wantName)
+			}
+			if tt.tool.Description() == "" {
+				t.Error("Description() is empty")
+			}
+
+			req := &model.LLMRequest{}
+			err := tt.tool.(toolinternal.RequestProcessor).ProcessRequest(nil, req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ProcessRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff([]*genai.Tool{tt.wantTool}, req.Config.Tools); diff != "" {
+				t.Errorf("ProcessRequest returned unexpected tools (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGeminiTool_Description(t *testing.T) {
+	testCases := []struct {
+		name string
+		tool *genai.Tool
+		want string
+	}{
+		{
+			name: "google search",
+			tool: &genai.Tool{GoogleSearch: &genai.GoogleSearch{}},
+			want: geminitool.GoogleSearch{}.Description(),
+		},
+		{
+			name: "url context",
+			tool: &genai.Tool{URLContext: &genai.URLContext{}},
+			want: geminitool.URLContext{}.Description(),
+		},
+		{
+			name: "vertex ai search",
+			tool: &genai.Tool{Retrieval: &genai.Retrieval{VertexAISearch: &genai.VertexAISearch{}}},
+			want: geminitool.VertexAISearch{}.Description(),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := geminitool.New("test_tool", tt.tool).Description(); got != tt.want {
+				t.Errorf("Description() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geminitool
+
+import (
+	"fmt"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// VertexAISearch is a built-in tool that grounds the responses of Gemini
+// models with the documents of a Vertex AI Search data store or engine. It's
+// only available on Vertex AI.
+// The tool operates internally within the model and does not require or
+// perform local code execution.
+type VertexAISearch struct {
+	// DataStore is the resource name of the data store, e.g.
+	// "projects/{project}/locations/{location}/collections/{collection}/dataStores/{dataStore}".
+	// Exactly one of DataStore and Engine must be set.
+	DataStore string
+	// Engine is the resource name of the search engine, e.g.
+	// "projects/{project}/locations/{location}/collections/{collection}/engines/{engine}".
+	Engine string
+	// Filter is an optional filter of the documents, in the Vertex AI
+	// Search filter syntax.
+	Filter string
+	// MaxResults limits the number of search results. Defaults to the
+	// server-side limit when zero.
+	MaxResults int32
+}
+
+// Name implements tool.Tool.
+func (VertexAISearch) Name() string {
+	return "vertex_ai_search"
+}
+
+// Description implements tool.Tool.
+func (VertexAISearch) Description() string {
+	return "Searches the documents of a Vertex AI Search data store."
+}
+
+// ProcessRequest adds the VertexAISearch tool to the LLM request.
+func (s VertexAISearch) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	if (s.DataStore == "") == (s.Engine == "") {
+		return fmt.Errorf("exactly one of DataStore and Engine must be set for the vertex_ai_search tool")
+	}
+	search := &genai.VertexAISearch{
+		Datastore: s.DataStore,
+		Engine:    s.Engine,
+		Filter:    s.Filter,
+	}
+	if s.MaxResults > 0 {
+		search.MaxResults = genai.Ptr(s.MaxResults)
+	}
+	return setTool(req, &genai.Tool{
+		Retrieval: &genai.Retrieval{VertexAISearch: search},
+	})
+}
+
+// IsLongRunning implements tool.Tool.
+func (VertexAISearch) IsLongRunning() bool {
+	return false
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package loadmemorytool defines tools giving the model access to the memory
+// of the agent: a tool the model calls to search the memory, and a tool
+// preloading the memories relevant to the user message in the instructions.
+package loadmemorytool
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/internal/utils"
+	"google.golang.org/adk/memory"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// memoryTool is a tool the model calls to search the memory.
+type memoryTool struct {
+	name        string
+	description string
+}
+
+// New creates a tool which searches the memory of the agent with the query
+// given by the model, and returns the matching entries with their authors
+// and timestamps. The memory service must be configured in the runner.
+func New() tool.Tool {
+	return &memoryTool{
+		name:        "load_memory",
+		description: "Loads the memory for the current user. Returns the past conversation entries matching the query.",
+	}
+}
+
+// Name implements tool.Tool.
+func (t *memoryTool) Name() string {
+	return t.name
+}
+
+// Description implements tool.Tool.
+func (t *memoryTool) Description() string {
+	return t.description
+}
+
+// IsLongRunning implements tool.Tool.
+func (t *memoryTool) IsLongRunning() bool {
+	return false
+}
+
+// Declaration returns the GenAI FunctionDeclaration for the load_memory tool.
+func (t *memoryTool) Declaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{
+		Name:        t.name,
+		Description: t.description,
+		ParametersJsonSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{
+					"type":        "string",
+					"description": "The query to search the memory with.",
+				},
+			},
+			"required": []string{"query"},
+		},
+	}
+}
+
+// Run implements tool.Tool.
+func (t *memoryTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	m, ok := args.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected args type, got: %T", args)
+	}
+	query, _ := m["query"].(string)
+	if query == "" {
+		return nil, errors.New("missing required argument \"query\"")
+	}
+
+	resp, err := ctx.SearchMemory(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search memory: %w", err)
+	}
+	memories := []any{}
+	for _, e := range resp.Memories {
+		text := entryText(e)
+		if text == "" {
+			continue
+		}
+		memory := map[string]any{
+			"author": e.Author,
+			"text":   text,
+		}
+		if !e.Timestamp.IsZero() {
+			memory["timestamp"] = e.Timestamp.Format(time.RFC3339)
+		}
+		memories = append(memories, memory)
+	}
+	return map[string]any{"memories": memories}, nil
+}
+
+// ProcessRequest packs the tool and tells the model that it can search the
+// memory.
+func (t *memoryTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	if err := toolutils.PackTool(req, t); err != nil {
+		return err
+	}
+	utils.AppendInstructions(req, fmt.Sprintf(
+		"You have memory. You can use it to answer questions. If any questions"+
+			" need you to look up the memory, you should call the `%s` function"+
+			" with a query.", t.name))
+	return nil
+}
+
+// entryText returns the text parts of the content of the memory entry.
+func entryText(e memory.Entry) string {
+	if e.Content == nil {
+		return ""
+	}
+	var texts []string
+	for _, part := range e.Content.Parts {
+		if part != nil && part.Text != "" && !part.Thought {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+var (
+	_ toolinternal.FunctionTool     = (*memoryTool)(nil)
+	_ toolinternal.RequestProcessor = (*memoryTool)(nil)
+)
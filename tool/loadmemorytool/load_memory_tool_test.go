@@ -0,0 +1,248 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadmemorytool_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	icontext "google.golang.org/adk/internal/context"
+	imemory "google.golang.org/adk/internal/memory"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/memory"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/loadmemorytool"
+)
+
+var testTime = time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+
+// fakeMemory returns the entries whose text contains the query.
+type fakeMemory struct {
+	entries []memory.Entry
+}
+
+func (m *fakeMemory) AddSession(context.Context, session.Session) error {
+	return nil
+}
+
+func (m *fakeMemory) Search(ctx context.Context, req *memory.SearchRequest) (*memory.SearchResponse, error) {
+	resp := &memory.SearchResponse{}
+	for _, e := range m.entries {
+		for _, p := range e.Content.Parts {
+			if strings.Contains(req.Query, p.Text) || strings.Contains(p.Text, req.Query) {
+				resp.Memories = append(resp.Memories, e)
+				break
+			}
+		}
+	}
+	return resp, nil
+}
+
+func newFakeMemory() *fakeMemory {
+	return &fakeMemory{entries: []memory.Entry{
+		{Content: genai.NewContentFromText("my dog is called Rex", genai.RoleUser), Author: "user", Timestamp: testTime},
+		{Content: genai.NewContentFromText("Rex is a nice name", genai.RoleModel), Author: "agent", Timestamp: testTime.Add(time.Minute)},
+		{Content: genai.NewContentFromText("I like cats", genai.RoleUser), Author: "user"},
+	}}
+}
+
+func createToolContext(t *testing.T, service memory.Service, userContent *genai.Content) tool.Context {
+	t.Helper()
+
+	params := icontext.InvocationContextParams{UserContent: userContent}
+	if service != nil {
+		params.Memory = &imemory.Memory{
+			Service:   service,
+			AppName:   "app",
+			UserID:    "user",
+			SessionID: "session",
+		}
+	}
+	return toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), params), "", nil)
+}
+
+func TestLoadMemoryTool_Run(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name: "matches",
+			args: map[string]any{"query": "Rex"},
+			want: map[string]any{"memories": []any{
+				map[string]any{"author": "user", "text": "my dog is called Rex", "timestamp": "2025-06-01T10:00:00Z"},
+				map[string]any{"author": "agent", "text": "Rex is a nice name", "timestamp": "2025-06-01T10:01:00Z"},
+			}},
+		},
+		{
+			name: "no timestamp",
+			args: map[string]any{"query": "cats"},
+			want: map[string]any{"memories": []any{
+				map[string]any{"author": "user", "text": "I like cats"},
+			}},
+		},
+		{
+			name: "no match",
+			args: map[string]any{"query": "birds"},
+			want: map[string]any{"memories": []any{}},
+		},
+		{
+			name:    "missing query",
+			args:    map[string]any{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loadMemoryTool := loadmemorytool.New().(toolinternal.FunctionTool)
+			got, err := loadMemoryTool.Run(createToolContext(t, newFakeMemory(), nil), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadMemoryTool_NoMemoryService(t *testing.T) {
+	loadMemoryTool := loadmemorytool.New().(toolinternal.FunctionTool)
+	if _, err := loadMemoryTool.Run(createToolContext(t, nil, nil), map[string]any{"query": "Rex"}); err == nil {
+		t.Error("Run() succeeded without memory service, want error")
+	}
+}
+
+func TestLoadMemoryTool_ProcessRequest(t *testing.T) {
+	loadMemoryTool := loadmemorytool.New()
+	req := &model.LLMRequest{}
+	if err := loadMemoryTool.(toolinternal.RequestProcessor).ProcessRequest(createToolContext(t, newFakeMemory(), nil), req); err != nil {
+		t.Fatalf("ProcessRequest() error = %v", err)
+	}
+	if _, ok := req.Tools["load_memory"]; !ok {
+		t.Errorf("req.Tools = %v, want load_memory", req.Tools)
+	}
+	instruction := req.Config.SystemInstruction.Parts[0].Text
+	if !strings.Contains(instruction, "`load_memory`") {
+		t.Errorf("instruction = %q, want it to mention load_memory", instruction)
+	}
+}
+
+func TestPreloadMemoryTool_ProcessRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             loadmemorytool.PreloadConfig
+		userContent     *genai.Content
+		wantInstruction string
+	}{
+		{
+			name:        "matches",
+			userContent: genai.NewContentFromText("Rex", genai.RoleUser),
+			wantInstruction: "The following content is from your previous conversations with the user." +
+				" They may be useful for answering the user's current query.\n" +
+				"<PAST_CONVERSATIONS>\n" +
+				"Time: 2025-06-01T10:00:00Z\nuser: my dog is called Rex\n" +
+				"Time: 2025-06-01T10:01:00Z\nagent: Rex is a nice name\n" +
+				"</PAST_CONVERSATIONS>",
+		},
+		{
+			name:        "max entries",
+			cfg:         loadmemorytool.PreloadConfig{MaxEntries: 1},
+			userContent: genai.NewContentFromText("Rex", genai.RoleUser),
+			wantInstruction: "The following content is from your previous conversations with the user." +
+				" They may be useful for answering the user's current query.\n" +
+				"<PAST_CONVERSATIONS>\n" +
+				"Time: 2025-06-01T10:00:00Z\nuser: my dog is called Rex\n" +
+				"</PAST_CONVERSATIONS>",
+		},
+		{
+			name:        "no match",
+			userContent: genai.NewContentFromText("birds", genai.RoleUser),
+		},
+		{
+			name: "no user content",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preloadTool := loadmemorytool.NewPreload(tt.cfg)
+			if _, ok := preloadTool.(toolinternal.FunctionTool); ok {
+				t.Error("preload tool is a function tool, want it hidden from the model")
+			}
+
+			req := &model.LLMRequest{}
+			if err := preloadTool.(toolinternal.RequestProcessor).ProcessRequest(createToolContext(t, newFakeMemory(), tt.userContent), req); err != nil {
+				t.Fatalf("ProcessRequest() error = %v", err)
+			}
+			var got string
+			if req.Config != nil && req.Config.SystemInstruction != nil {
+				got = req.Config.SystemInstruction.Parts[0].Text
+			}
+			if diff := cmp.Diff(tt.wantInstruction, got); diff != "" {
+				t.Errorf("instruction mismatch (-want +got):\n%s", diff)
+			}
+			if len(req.Tools) != 0 {
+				t.Errorf("req.Tools = %v, want none", req.Tools)
+			}
+		})
+	}
+}
+
+// failingMemory fails every search.
+type failingMemory struct{}
+
+func (failingMemory) AddSession(context.Context, session.Session) error {
+	return nil
+}
+
+func (failingMemory) Search(context.Context, *memory.SearchRequest) (*memory.SearchResponse, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestPreloadMemoryTool_BestEffort(t *testing.T) {
+	tests := []struct {
+		name    string
+		service memory.Service
+	}{
+		{name: "no memory service"},
+		{name: "search fails", service: failingMemory{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preloadTool := loadmemorytool.NewPreload(loadmemorytool.PreloadConfig{})
+			req := &model.LLMRequest{}
+			userContent := genai.NewContentFromText("Rex", genai.RoleUser)
+			if err := preloadTool.(toolinternal.RequestProcessor).ProcessRequest(createToolContext(t, tt.service, userContent), req); err != nil {
+				t.Fatalf("ProcessRequest() error = %v, want nil", err)
+			}
+			if req.Config != nil && req.Config.SystemInstruction != nil {
+				t.Errorf("SystemInstruction = %v, want none", req.Config.SystemInstruction)
+			}
+		})
+	}
+}
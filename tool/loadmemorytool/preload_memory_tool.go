@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadmemorytool
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/utils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// PreloadConfig allows to configure the tool created with NewPreload.
+type PreloadConfig struct {
+	// MaxEntries is the maximum number of memory entries added to the
+	// instructions. The memory service returns the most relevant entries
+	// first. Defaults to 5.
+	MaxEntries int
+}
+
+const defaultMaxEntries = 5
+
+// preloadMemoryTool adds the memories relevant to the user message to the
+// instructions. It is not called by the model.
+type preloadMemoryTool struct {
+	maxEntries int
+}
+
+// NewPreload creates a tool which, on every LLM request, searches the memory
+// of the agent with the user message which started the invocation and
+// appends the matching entries to the system instruction. The model doesn't
+// call the tool, so it doesn't need to decide to look up the memory.
+func NewPreload(cfg PreloadConfig) tool.Tool {
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = defaultMaxEntries
+	}
+	return &preloadMemoryTool{maxEntries: cfg.MaxEntries}
+}
+
+// Name implements tool.Tool.
+func (t *preloadMemoryTool) Name() string {
+	return "preload_memory"
+}
+
+// Description implements tool.Tool.
+func (t *preloadMemoryTool) Description() string {
+	return "Preloads the memory for the current user."
+}
+
+// IsLongRunning implements tool.Tool.
+func (t *preloadMemoryTool) IsLongRunning() bool {
+	return false
+}
+
+// ProcessRequest searches the memory with the user message and appends the
+// matching entries to the instructions. Preloading is best-effort: the request
+// is left untouched when there is no memory service or the search fails.
+func (t *preloadMemoryTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	userContent := ctx.UserContent()
+	if userContent == nil {
+		return nil
+	}
+	var texts []string
+	for _, part := range userContent.Parts {
+		if part != nil && part.Text != "" {
+			texts = append(texts, part.Text)
+		}
+	}
+	query := strings.Join(texts, "\n")
+	if query == "" {
+		return nil
+	}
+
+	resp, err := ctx.SearchMemory(ctx, query)
+	if err != nil {
+		if !errors.Is(err, toolinternal.ErrMemoryNotConfigured) {
+			log.Printf("Failed to preload memory: %v", err)
+		}
+		return nil
+	}
+
+	var lines []string
+	count := 0
+	for _, e := range resp.Memories {
+		if count == t.maxEntries {
+			break
+		}
+		text := entryText(e)
+		if text == "" {
+			continue
+		}
+		count++
+		if !e.Timestamp.IsZero() {
+			lines = append(lines, "Time: "+e.Timestamp.Format(time.RFC3339))
+		}
+		if e.Author != "" {
+			text = e.Author + ": " + text
+		}
+		lines = append(lines, text)
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	utils.AppendInstructions(req, "The following content is from your previous conversations with the user."+
+		" They may be useful for answering the user's current query.\n"+
+		"<PAST_CONVERSATIONS>\n"+strings.Join(lines, "\n")+"\n</PAST_CONVERSATIONS>")
+	return nil
+}
+
+var _ toolinternal.RequestProcessor = (*preloadMemoryTool)(nil)
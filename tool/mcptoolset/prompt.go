@@ -0,0 +1,67 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcptoolset
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/tool"
+)
+
+// PromptInstructionProvider returns an instruction provider which gets the
+// MCP prompt with the given name and arguments from the server of the MCP
+// ToolSet ts, created with New. The instruction is the text of the prompt
+// messages, separated by blank lines. The prompt is fetched on every LLM
+// request, so changes on the server are picked up.
+//
+// Example:
+//
+//	provider, err := mcptoolset.PromptInstructionProvider(ts, "code_review", map[string]string{"language": "go"})
+//	...
+//	llmagent.New(llmagent.Config{
+//		Name:                "agent_name",
+//		Model:               model,
+//		InstructionProvider: provider,
+//		Toolsets:            []tool.Toolset{ts},
+//	})
+func PromptInstructionProvider(ts tool.Toolset, name string, args map[string]string) (llmagent.InstructionProvider, error) {
+	s, ok := ts.(*set)
+	if !ok {
+		return nil, fmt.Errorf("tool set %q is not a MCP tool set", ts.Name())
+	}
+	return func(ctx agent.ReadonlyContext) (string, error) {
+		session, err := s.getSession(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get MCP session: %w", err)
+		}
+		res, err := session.GetPrompt(ctx, &mcp.GetPromptParams{Name: name, Arguments: args})
+		if err != nil {
+			s.sessionFailed(session, err)
+			return "", fmt.Errorf("failed to get MCP prompt %q: %w", name, err)
+		}
+		var texts []string
+		for _, m := range res.Messages {
+			if c, ok := m.Content.(*mcp.TextContent); ok && c.Text != "" {
+				texts = append(texts, c.Text)
+			}
+		}
+		return strings.Join(texts, "\n\n"), nil
+	}, nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcptoolset
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// listResourcesTool lists the resources of the MCP server.
+type listResourcesTool struct {
+	set *set
+}
+
+// Name implements the tool.Tool.
+func (t *listResourcesTool) Name() string {
+	return "list_mcp_resources"
+}
+
+// Description implements the tool.Tool.
+func (t *listResourcesTool) Description() string {
+	return "Lists the resources available on the MCP server, with their URI, name, description and MIME type."
+}
+
+// IsLongRunning implements the tool.Tool.
+func (t *listResourcesTool) IsLongRunning() bool {
+	return false
+}
+
+func (t *listResourcesTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return toolutils.PackTool(req, t)
+}
+
+func (t *listResourcesTool) Declaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{
+		Name:        t.Name(),
+		Description: t.Description(),
+	}
+}
+
+func (t *listResourcesTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	session, err := t.set.getSession(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	resources := []any{}
+	for r, err := range session.Resources(ctx, nil) {
+		if err != nil {
+			t.set.sessionFailed(session, err)
+			return nil, fmt.Errorf("failed to list MCP resources: %w", err)
+		}
+		resource := map[string]any{
+			"uri":  r.URI,
+			"name": r.Name,
+		}
+		if r.Description != "" {
+			resource["description"] = r.Description
+		}
+		if r.MIMEType != "" {
+			resource["mime_type"] = r.MIMEType
+		}
+		resources = append(resources, resource)
+	}
+	return map[string]any{"resources": resources}, nil
+}
+
+// readResourceTool reads a resource of the MCP server. Text contents are
+// returned to the model, binary contents are saved as artifacts.
+type readResourceTool struct {
+	set *set
+}
+
+// Name implements the tool.Tool.
+func (t *readResourceTool) Name() string {
+	return "read_mcp_resource"
+}
+
+// Description implements the tool.Tool.
+func (t *readResourceTool) Description() string {
+	return "Reads the resource with the given URI from the MCP server. Binary contents are saved as artifacts and their artifact names are returned."
+}
+
+// IsLongRunning implements the tool.Tool.
+func (t *readResourceTool) IsLongRunning() bool {
+	return false
+}
+
+func (t *readResourceTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return toolutils.PackTool(req, t)
+}
+
+func (t *readResourceTool) Declaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{
+		Name:        t.Name(),
+		Description: t.Description(),
+		ParametersJsonSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"uri": map[string]any{
+					"type":        "string",
+					"description": "The URI of the resource, as returned by list_mcp_resources.",
+				},
+			},
+			"required": []string{"uri"},
+		},
+	}
+}
+
+func (t *readResourceTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	margs, ok := args.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected args type, got: %T", args)
+	}
+	uri, _ := margs["uri"].(string)
+	if uri == "" {
+		return nil, errors.New("missing required argument \"uri\"")
+	}
+
+	session, err := t.set.getSession(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	res, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
+	if err != nil {
+		t.set.sessionFailed(session, err)
+		return nil, fmt.Errorf("failed to read MCP resource %q: %w", uri, err)
+	}
+
+	contents := []any{}
+	for _, c := range res.Contents {
+		content := map[string]any{"uri": c.URI}
+		if c.MIMEType != "" {
+			content["mime_type"] = c.MIMEType
+		}
+		if c.Blob == nil {
+			content["text"] = c.Text
+			contents = append(contents, content)
+			continue
+		}
+
+		if ctx.Artifacts() == nil {
+			return nil, fmt.Errorf("MCP resource %q has binary content but the artifact service is not configured", c.URI)
+		}
+		mimeType := c.MIMEType
+		if mimeType == "" {
+			mimeType = "application/octet-stream"
+		}
+		name := c.URI
+		if u, err := url.Parse(c.URI); err == nil {
+			name = toolutils.ArtifactName(u, c.MIMEType)
+		}
+		resp, err := ctx.Artifacts().Save(ctx, name, genai.NewPartFromBytes(c.Blob, mimeType))
+		if err != nil {
+			return nil, fmt.Errorf("failed to save MCP resource %q as artifact: %w", c.URI, err)
+		}
+		content["artifact"] = name
+		content["artifact_version"] = resp.Version
+		contents = append(contents, content)
+	}
+	return map[string]any{"contents": contents}, nil
+}
+
+var (
+	_ toolinternal.FunctionTool     = (*listResourcesTool)(nil)
+	_ toolinternal.RequestProcessor = (*listResourcesTool)(nil)
+	_ toolinternal.FunctionTool     = (*readResourceTool)(nil)
+	_ toolinternal.RequestProcessor = (*readResourceTool)(nil)
+)
@@ -17,8 +17,11 @@ package mcptoolset
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"sync"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
@@ -33,6 +36,14 @@ import (
 // It uses https://github.com/modelcontextprotocol/go-sdk for MCP communication.
 // MCP session is created lazily on the first request to LLM.
 //
+// The tool list is cached and invalidated when the server sends a
+// tools/list_changed notification. When the session with the server is lost,
+// the call which noticed it fails, and the next one reconnects with
+// exponential backoff. Tool calls aren't retried, as the server may already
+// have run them.
+//
+// End the MCP session with [Close] once the agent is no longer used.
+//
 // Usage: create MCP ToolSet with mcptoolset.New() and provide it to the
 // LLMAgent in the llmagent.Config.
 //
@@ -50,36 +61,86 @@ import (
 //		},
 //	})
 func New(cfg Config) (tool.Toolset, error) {
-	client := cfg.Client
-	if client == nil {
-		client = mcp.NewClient(&mcp.Implementation{Name: "adk-mcp-client", Version: version.Version}, nil)
+	if cfg.Transport == nil && cfg.NewTransport == nil {
+		return nil, fmt.Errorf("either Transport or NewTransport must be provided")
+	}
+	if cfg.MaxConnectAttempts < 0 || cfg.ConnectBackoff < 0 {
+		return nil, fmt.Errorf("MaxConnectAttempts and ConnectBackoff must not be negative")
+	}
+	if cfg.MaxConnectAttempts == 0 {
+		cfg.MaxConnectAttempts = 3
+	}
+	if cfg.ConnectBackoff == 0 {
+		cfg.ConnectBackoff = 500 * time.Millisecond
+	}
+
+	s := &set{
+		client:             cfg.Client,
+		transport:          cfg.Transport,
+		newTransport:       cfg.NewTransport,
+		toolFilter:         cfg.ToolFilter,
+		resourceTools:      cfg.ResourceTools,
+		maxConnectAttempts: cfg.MaxConnectAttempts,
+		connectBackoff:     cfg.ConnectBackoff,
+		connecting:         make(chan struct{}, 1),
+	}
+	if s.client == nil {
+		s.client = mcp.NewClient(&mcp.Implementation{Name: "adk-mcp-client", Version: version.Version}, &mcp.ClientOptions{
+			ToolListChangedHandler: s.onToolListChanged,
+		})
+		s.cacheTools = true
 	}
-	return &set{
-		client:     client,
-		transport:  cfg.Transport,
-		toolFilter: cfg.ToolFilter,
-	}, nil
+	return s, nil
 }
 
 // Config provides initial configuration for the MCP ToolSet.
 type Config struct {
 	// Client is an optional custom MCP client to use. If nil, a default client will be created.
+	// The tool list is only cached with the default client, as it relies on
+	// the client's handler of tools/list_changed notifications.
 	Client *mcp.Client
 	// Transport that will be used to connect to MCP server.
 	Transport mcp.Transport
+	// NewTransport creates the transport for each connection to the MCP
+	// server. It's used instead of Transport if set. Use it for transports
+	// which can't be reused to reconnect, e.g. mcp.CommandTransport.
+	NewTransport func(ctx context.Context) (mcp.Transport, error)
 	// ToolFilter selects tools for which tool.Predicate returns true.
 	// If ToolFilter is nil, then all tools are returned.
 	// tool.StringPredicate can be convenient if there's a known fixed list of tool names.
 	ToolFilter tool.Predicate
+	// ResourceTools adds the list_mcp_resources and read_mcp_resource tools,
+	// which list and read the resources of the MCP server. Binary resources
+	// are saved as artifacts.
+	ResourceTools bool
+	// MaxConnectAttempts is the number of attempts to connect to the MCP
+	// server before failing. Defaults to 3.
+	MaxConnectAttempts int
+	// ConnectBackoff is the delay before the second connection attempt,
+	// doubled for each further attempt. Defaults to 500ms.
+	ConnectBackoff time.Duration
 }
 
+var errClosed = errors.New("MCP tool set is closed")
+
 type set struct {
-	client     *mcp.Client
-	transport  mcp.Transport
-	toolFilter tool.Predicate
+	client             *mcp.Client
+	transport          mcp.Transport
+	newTransport       func(ctx context.Context) (mcp.Transport, error)
+	toolFilter         tool.Predicate
+	resourceTools      bool
+	cacheTools         bool
+	maxConnectAttempts int
+	connectBackoff     time.Duration
+	// connecting is held while connecting to the server, so that only one
+	// caller connects at a time without holding mu.
+	connecting chan struct{}
 
 	mu      sync.Mutex
 	session *mcp.ClientSession
+	// tools caches the tools of the session, nil if not listed yet.
+	tools  []tool.Tool
+	closed bool
 }
 
 func (*set) Name() string {
@@ -96,33 +157,55 @@ func (*set) IsLongRunning() bool {
 
 // Tools fetch MCP tools from the server, convert to adk tool.Tool and filter by name.
 func (s *set) Tools(ctx agent.ReadonlyContext) ([]tool.Tool, error) {
+	tools, err := s.listTools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.resourceTools {
+		tools = append(tools, &listResourcesTool{set: s}, &readResourceTool{set: s})
+	}
+
+	var adkTools []tool.Tool
+	for _, t := range tools {
+		if s.toolFilter != nil && !s.toolFilter(ctx, t) {
+			continue
+		}
+		adkTools = append(adkTools, t)
+	}
+	return adkTools, nil
+}
+
+// listTools returns the cached tools or lists them from the server.
+func (s *set) listTools(ctx context.Context) ([]tool.Tool, error) {
 	session, err := s.getSession(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get MCP session: %w", err)
 	}
 
-	var adkTools []tool.Tool
+	s.mu.Lock()
+	cached := s.tools
+	s.mu.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
 
+	tools := []tool.Tool{}
 	cursor := ""
 	for {
 		resp, err := session.ListTools(ctx, &mcp.ListToolsParams{
 			Cursor: cursor,
 		})
 		if err != nil {
+			s.sessionFailed(session, err)
 			return nil, fmt.Errorf("failed to list MCP tools: %w", err)
 		}
 
 		for _, mcpTool := range resp.Tools {
-			t, err := convertTool(mcpTool, s.getSession)
+			t, err := convertTool(mcpTool, s)
 			if err != nil {
 				return nil, fmt.Errorf("failed to convert MCP tool %q to adk tool: %w", mcpTool.Name, err)
 			}
-
-			if s.toolFilter != nil && !s.toolFilter(ctx, t) {
-				continue
-			}
-
-			adkTools = append(adkTools, t)
+			tools = append(tools, t)
 		}
 
 		if resp.NextCursor == "" {
@@ -131,22 +214,147 @@ func (s *set) Tools(ctx agent.ReadonlyContext) ([]tool.Tool, error) {
 		cursor = resp.NextCursor
 	}
 
-	return adkTools, nil
+	if s.cacheTools {
+		s.mu.Lock()
+		if s.session == session {
+			s.tools = tools
+		}
+		s.mu.Unlock()
+	}
+	return tools, nil
 }
 
-func (s *set) getSession(ctx context.Context) (*mcp.ClientSession, error) {
+func (s *set) onToolListChanged(ctx context.Context, req *mcp.ToolListChangedRequest) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	if req.Session == s.session {
+		s.tools = nil
+	}
+}
 
-	if s.session != nil {
-		return s.session, nil
+// getSession returns the MCP session, connecting to the server if there is
+// no session or it was lost.
+func (s *set) getSession(ctx context.Context) (*mcp.ClientSession, error) {
+	if session, err := s.currentSession(); session != nil || err != nil {
+		return session, err
 	}
 
-	session, err := s.client.Connect(ctx, s.transport, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to init MCP session: %w", err)
+	select {
+	case s.connecting <- struct{}{}:
+		defer func() { <-s.connecting }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	// Another caller may have connected in the meantime.
+	if session, err := s.currentSession(); session != nil || err != nil {
+		return session, err
 	}
 
-	s.session = session
+	var err error
+	backoff := s.connectBackoff
+	for attempt := 1; attempt <= s.maxConnectAttempts; attempt++ {
+		if attempt > 1 {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+			backoff *= 2
+		}
+		var session *mcp.ClientSession
+		if session, err = s.connect(ctx); err == nil {
+			s.mu.Lock()
+			if s.closed {
+				s.mu.Unlock()
+				session.Close()
+				return nil, errClosed
+			}
+			s.session = session
+			s.tools = nil
+			s.mu.Unlock()
+			go s.watch(session)
+			return session, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to init MCP session after %d attempts: %w", s.maxConnectAttempts, err)
+}
+
+// currentSession returns the current session, nil if there is none, or
+// errClosed if the tool set is closed.
+func (s *set) currentSession() (*mcp.ClientSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, errClosed
+	}
 	return s.session, nil
 }
+
+func (s *set) connect(ctx context.Context) (*mcp.ClientSession, error) {
+	transport := s.transport
+	if s.newTransport != nil {
+		var err error
+		if transport, err = s.newTransport(ctx); err != nil {
+			return nil, fmt.Errorf("failed to create transport: %w", err)
+		}
+	}
+	return s.client.Connect(ctx, transport, nil)
+}
+
+// watch drops the session once it's closed, e.g. because the server exited,
+// so that the next call reconnects.
+func (s *set) watch(session *mcp.ClientSession) {
+	_ = session.Wait()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.session == session {
+		s.session = nil
+		s.tools = nil
+	}
+}
+
+// connectionLost reports whether err shows that the connection of the session
+// is lost, in which case the next request uses a new session.
+func connectionLost(err error) bool {
+	return errors.Is(err, mcp.ErrConnectionClosed) || errors.Is(err, io.ErrClosedPipe) || errors.Is(err, io.EOF)
+}
+
+// sessionFailed drops the session if err shows that its connection is lost.
+func (s *set) sessionFailed(session *mcp.ClientSession, err error) {
+	if !connectionLost(err) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.session == session {
+		s.session = nil
+		s.tools = nil
+	}
+}
+
+// Close ends the MCP session of a tool set created by [New]. The tool set
+// can't be used afterwards.
+func Close(ts tool.Toolset) error {
+	s, ok := ts.(*set)
+	if !ok {
+		return fmt.Errorf("tool set %q is not a MCP tool set", ts.Name())
+	}
+	return s.Close()
+}
+
+// Close implements io.Closer.
+func (s *set) Close() error {
+	s.mu.Lock()
+	session := s.session
+	s.session = nil
+	s.tools = nil
+	s.closed = true
+	s.mu.Unlock()
+
+	if session == nil {
+		return nil
+	}
+	return session.Close()
+}
@@ -22,7 +22,10 @@ import (
 	"net/http"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -31,9 +34,12 @@ import (
 
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/artifact"
+	artifactinternal "google.golang.org/adk/internal/artifact"
 	icontext "google.golang.org/adk/internal/context"
 	"google.golang.org/adk/internal/httprr"
 	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/internal/toolinternal"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/model/gemini"
 	"google.golang.org/adk/runner"
@@ -307,3 +313,371 @@ func TestToolFilter(t *testing.T) {
 		t.Errorf("tools mismatch (-want +got):\n%s", diff)
 	}
 }
+
+// newServer returns a MCP server with the get_weather tool.
+func newServer() *mcp.Server {
+	server := mcp.NewServer(&mcp.Implementation{Name: "weather_server", Version: "v1.0.0"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "get_weather", Description: "returns weather in the given city"}, weatherFunc)
+	return server
+}
+
+// serverConnector connects server to a new in-memory transport on every
+// connection of the tool set, recording the server sessions and the client
+// connections.
+type serverConnector struct {
+	server *mcp.Server
+
+	mu          sync.Mutex
+	sessions    []*mcp.ServerSession
+	clientConns []mcp.Connection
+}
+
+func (c *serverConnector) newTransport(ctx context.Context) (mcp.Transport, error) {
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	session, err := c.server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sessions = append(c.sessions, session)
+	return &recordingTransport{Transport: clientTransport, connector: c}, nil
+}
+
+// dropLastConnection closes the last client connection, as if the connection
+// to the server was lost.
+func (c *serverConnector) dropLastConnection() {
+	c.mu.Lock()
+	conn := c.clientConns[len(c.clientConns)-1]
+	c.mu.Unlock()
+	conn.Close()
+}
+
+// recordingTransport records the client connections of a serverConnector.
+type recordingTransport struct {
+	mcp.Transport
+	connector *serverConnector
+}
+
+func (t *recordingTransport) Connect(ctx context.Context) (mcp.Connection, error) {
+	conn, err := t.Transport.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t.connector.mu.Lock()
+	defer t.connector.mu.Unlock()
+	t.connector.clientConns = append(t.connector.clientConns, conn)
+	return conn, nil
+}
+
+func (c *serverConnector) connections() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.sessions)
+}
+
+func (c *serverConnector) lastSession() *mcp.ServerSession {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sessions[len(c.sessions)-1]
+}
+
+func newReadonlyContext(t *testing.T) agent.ReadonlyContext {
+	t.Helper()
+	return icontext.NewReadonlyContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}))
+}
+
+func newToolContext(t *testing.T, artifacts agent.Artifacts) tool.Context {
+	t.Helper()
+	return toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{Artifacts: artifacts}), "", nil)
+}
+
+func toolNames(t *testing.T, ts tool.Toolset) []string {
+	t.Helper()
+
+	tools, err := ts.Tools(newReadonlyContext(t))
+	if err != nil {
+		t.Fatalf("Failed to get tools: %v", err)
+	}
+	var names []string
+	for _, tl := range tools {
+		names = append(names, tl.Name())
+	}
+	return names
+}
+
+func findTool(t *testing.T, ts tool.Toolset, name string) toolinternal.FunctionTool {
+	t.Helper()
+
+	tools, err := ts.Tools(newReadonlyContext(t))
+	if err != nil {
+		t.Fatalf("Failed to get tools: %v", err)
+	}
+	for _, tl := range tools {
+		if tl.Name() == name {
+			return tl.(toolinternal.FunctionTool)
+		}
+	}
+	t.Fatalf("tool %q not found", name)
+	return nil
+}
+
+func TestToolsCache(t *testing.T) {
+	server := newServer()
+	connector := &serverConnector{server: server}
+	ts, err := mcptoolset.New(mcptoolset.Config{NewTransport: connector.newTransport})
+	if err != nil {
+		t.Fatalf("Failed to create MCP tool set: %v", err)
+	}
+	defer mcptoolset.Close(ts)
+
+	if diff := cmp.Diff([]string{"get_weather"}, toolNames(t, ts)); diff != "" {
+		t.Errorf("tools mismatch (-want +got):\n%s", diff)
+	}
+
+	// The server notifies the client that its tool list changed.
+	mcp.AddTool(server, &mcp.Tool{Name: "get_forecast", Description: "returns forecast in the given city"}, weatherFunc)
+
+	want := []string{"get_forecast", "get_weather"}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		got := toolNames(t, ts)
+		if cmp.Equal(want, got) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("tools were not refreshed after list_changed notification, got %v, want %v", got, want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := connector.connections(); got != 1 {
+		t.Errorf("got %d connections, want 1", got)
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	server := newServer()
+	connector := &serverConnector{server: server}
+	var calls atomic.Int32
+	mcp.AddTool(server, &mcp.Tool{Name: "flaky_weather", Description: "drops the session on the first call"},
+		func(ctx context.Context, req *mcp.CallToolRequest, input Input) (*mcp.CallToolResult, Output, error) {
+			if calls.Add(1) == 1 {
+				connector.dropLastConnection()
+			}
+			return weatherFunc(ctx, req, input)
+		})
+	ts, err := mcptoolset.New(mcptoolset.Config{
+		NewTransport:   connector.newTransport,
+		ConnectBackoff: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create MCP tool set: %v", err)
+	}
+	defer mcptoolset.Close(ts)
+
+	weather := findTool(t, ts, "flaky_weather")
+	// The session is lost during the call, which must not be retried.
+	if _, err := weather.Run(newToolContext(t, nil), map[string]any{"city": "Paris"}); err == nil {
+		t.Fatal("Run() with lost session succeeded, want error")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("got %d calls, want 1", got)
+	}
+
+	// The next call must reconnect.
+	got, err := weather.Run(newToolContext(t, nil), map[string]any{"city": "Rome"})
+	if err != nil {
+		t.Fatalf("Run() after disconnect failed: %v", err)
+	}
+	want := map[string]any{"output": map[string]any{"weather_summary": `Today in "Rome" is sunny`}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+	if got := connector.connections(); got != 2 {
+		t.Errorf("got %d connections, want 2", got)
+	}
+}
+
+func TestConnectAttempts(t *testing.T) {
+	attempts := 0
+	ts, err := mcptoolset.New(mcptoolset.Config{
+		NewTransport: func(context.Context) (mcp.Transport, error) {
+			attempts++
+			return nil, fmt.Errorf("server unavailable")
+		},
+		MaxConnectAttempts: 2,
+		ConnectBackoff:     time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create MCP tool set: %v", err)
+	}
+
+	if _, err := ts.Tools(newReadonlyContext(t)); err == nil || !strings.Contains(err.Error(), "server unavailable") {
+		t.Errorf("Tools() error = %v, want server unavailable", err)
+	}
+	if attempts != 2 {
+		t.Errorf("got %d connection attempts, want 2", attempts)
+	}
+}
+
+func TestResourceTools(t *testing.T) {
+	server := newServer()
+	server.AddResource(&mcp.Resource{URI: "file:///docs/notes.txt", Name: "notes", MIMEType: "text/plain"},
+		func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{
+				{URI: req.Params.URI, MIMEType: "text/plain", Text: "remember the milk"},
+			}}, nil
+		})
+	server.AddResource(&mcp.Resource{URI: "file:///images/logo.png", Name: "logo", MIMEType: "image/png"},
+		func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{
+				{URI: req.Params.URI, MIMEType: "image/png", Blob: []byte("png")},
+			}}, nil
+		})
+	connector := &serverConnector{server: server}
+	ts, err := mcptoolset.New(mcptoolset.Config{
+		NewTransport:  connector.newTransport,
+		ResourceTools: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create MCP tool set: %v", err)
+	}
+	defer mcptoolset.Close(ts)
+
+	artifactService := artifact.InMemoryService()
+	artifacts := &artifactinternal.Artifacts{Service: artifactService, AppName: "app", UserID: "user", SessionID: "session"}
+
+	tests := []struct {
+		name string
+		tool string
+		args map[string]any
+		want map[string]any
+	}{
+		{
+			name: "list",
+			tool: "list_mcp_resources",
+			want: map[string]any{"resources": []any{
+				map[string]any{"uri": "file:///docs/notes.txt", "name": "notes", "mime_type": "text/plain"},
+				map[string]any{"uri": "file:///images/logo.png", "name": "logo", "mime_type": "image/png"},
+			}},
+		},
+		{
+			name: "read text",
+			tool: "read_mcp_resource",
+			args: map[string]any{"uri": "file:///docs/notes.txt"},
+			want: map[string]any{"contents": []any{
+				map[string]any{"uri": "file:///docs/notes.txt", "mime_type": "text/plain", "text": "remember the milk"},
+			}},
+		},
+		{
+			name: "read binary",
+			tool: "read_mcp_resource",
+			args: map[string]any{"uri": "file:///images/logo.png"},
+			want: map[string]any{"contents": []any{
+				map[string]any{"uri": "file:///images/logo.png", "mime_type": "image/png", "artifact": "logo.png", "artifact_version": int64(1)},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findTool(t, ts, tt.tool).Run(newToolContext(t, artifacts), tt.args)
+			if err != nil {
+				t.Fatalf("Run() failed: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.SortSlices(func(a, b any) bool {
+				return fmt.Sprint(a) < fmt.Sprint(b)
+			})); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	resp, err := artifactService.Load(t.Context(), &artifact.LoadRequest{AppName: "app", UserID: "user", SessionID: "session", FileName: "logo.png"})
+	if err != nil {
+		t.Fatalf("Failed to load artifact: %v", err)
+	}
+	if got := string(resp.Part.InlineData.Data); got != "png" {
+		t.Errorf("artifact data = %q, want %q", got, "png")
+	}
+}
+
+func TestPromptInstructionProvider(t *testing.T) {
+	server := newServer()
+	server.AddPrompt(&mcp.Prompt{Name: "forecaster", Arguments: []*mcp.PromptArgument{{Name: "tone"}}},
+		func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			return &mcp.GetPromptResult{Messages: []*mcp.PromptMessage{
+				{Role: "user", Content: &mcp.TextContent{Text: "You are a weather forecaster."}},
+				{Role: "user", Content: &mcp.TextContent{Text: "Answer in a " + req.Params.Arguments["tone"] + " tone."}},
+			}}, nil
+		})
+	connector := &serverConnector{server: server}
+	ts, err := mcptoolset.New(mcptoolset.Config{NewTransport: connector.newTransport})
+	if err != nil {
+		t.Fatalf("Failed to create MCP tool set: %v", err)
+	}
+	defer mcptoolset.Close(ts)
+
+	provider, err := mcptoolset.PromptInstructionProvider(ts, "forecaster", map[string]string{"tone": "cheerful"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := provider(newReadonlyContext(t))
+	if err != nil {
+		t.Fatalf("provider() failed: %v", err)
+	}
+	if want := "You are a weather forecaster.\n\nAnswer in a cheerful tone."; got != want {
+		t.Errorf("provider() = %q, want %q", got, want)
+	}
+}
+
+func TestClose(t *testing.T) {
+	connector := &serverConnector{server: newServer()}
+	ts, err := mcptoolset.New(mcptoolset.Config{NewTransport: connector.newTransport})
+	if err != nil {
+		t.Fatalf("Failed to create MCP tool set: %v", err)
+	}
+	weather := findTool(t, ts, "get_weather")
+
+	if err := mcptoolset.Close(ts); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	if _, err := ts.Tools(newReadonlyContext(t)); err == nil {
+		t.Error("Tools() after Close() succeeded, want error")
+	}
+	if _, err := weather.Run(newToolContext(t, nil), map[string]any{"city": "Paris"}); err == nil {
+		t.Error("Run() after Close() succeeded, want error")
+	}
+	if got := connector.connections(); got != 1 {
+		t.Errorf("got %d connections, want 1", got)
+	}
+}
+
+func TestClose_WhileConnecting(t *testing.T) {
+	connector := &serverConnector{server: newServer()}
+	connecting, release := make(chan struct{}), make(chan struct{})
+	ts, err := mcptoolset.New(mcptoolset.Config{
+		NewTransport: func(ctx context.Context) (mcp.Transport, error) {
+			close(connecting)
+			<-release
+			return connector.newTransport(ctx)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create MCP tool set: %v", err)
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := ts.Tools(newReadonlyContext(t))
+		errc <- err
+	}()
+	<-connecting
+	// Close must not wait for the connection attempt.
+	if err := mcptoolset.Close(ts); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	close(release)
+	if err := <-errc; err == nil {
+		t.Error("Tools() connecting during Close() succeeded, want error")
+	}
+}
@@ -29,9 +29,7 @@ import (
 	"google.golang.org/adk/tool"
 )
 
-type getSessionFunc func(ctx context.Context) (*mcp.ClientSession, error)
-
-func convertTool(t *mcp.Tool, getSessionFunc getSessionFunc) (tool.Tool, error) {
+func convertTool(t *mcp.Tool, s *set) (tool.Tool, error) {
 	mcp := &mcpTool{
 		name:        t.Name,
 		description: t.Description,
@@ -39,7 +37,7 @@ func convertTool(t *mcp.Tool, getSessionFunc getSessionFunc) (tool.Tool, error)
 			Name:        t.Name,
 			Description: t.Description,
 		},
-		getSessionFunc: getSessionFunc,
+		set: s,
 	}
 
 	// Since t.InputSchema and t.OutputSchema are pointers (*jsonschema.Schema) and the destination ResponseJsonSchema
@@ -61,7 +59,7 @@ type mcpTool struct {
 	description     string
 	funcDeclaration *genai.FunctionDeclaration
 
-	getSessionFunc getSessionFunc
+	set *set
 }
 
 // Name implements the tool.Tool.
@@ -88,16 +86,8 @@ func (t *mcpTool) Declaration() *genai.FunctionDeclaration {
 }
 
 func (t *mcpTool) Run(ctx tool.Context, args any) (map[string]any, error) {
-	session, err := t.getSessionFunc(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get session: %w", err)
-	}
-
 	// TODO: add auth
-	res, err := session.CallTool(ctx, &mcp.CallToolParams{
-		Name:      t.name,
-		Arguments: args,
-	})
+	res, err := t.callTool(ctx, args)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call MCP tool %q with err: %w", t.name, err)
 	}
@@ -150,6 +140,25 @@ func (t *mcpTool) Run(ctx tool.Context, args any) (map[string]any, error) {
 	}, nil
 }
 
+// callTool calls the tool. If the connection of the session is lost, the call
+// isn't retried, as the server may have received it, but the next call uses a
+// new session.
+func (t *mcpTool) callTool(ctx context.Context, args any) (*mcp.CallToolResult, error) {
+	session, err := t.set.getSession(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      t.name,
+		Arguments: args,
+	})
+	if err != nil {
+		t.set.sessionFailed(session, err)
+		return nil, err
+	}
+	return res, nil
+}
+
 var (
 	_ toolinternal.FunctionTool     = (*mcpTool)(nil)
 	_ toolinternal.RequestProcessor = (*mcpTool)(nil)
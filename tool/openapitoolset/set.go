@@ -0,0 +1,217 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package openapitoolset provides a tool set calling the operations of an
+// OpenAPI 3 document.
+package openapitoolset
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/tool"
+)
+
+// Config provides initial configuration for the OpenAPI ToolSet.
+type Config struct {
+	// Spec is the OpenAPI 3 document, in JSON or YAML.
+	Spec []byte
+	// BaseURL is the URL the paths of the document are relative to. If empty,
+	// the URL of the first server of the document is used.
+	BaseURL string
+	// HTTPClient is used to call the operations. Defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+	// Credentials maps the names of the security schemes of the document to
+	// their credentials. Operations are called with the credentials of the
+	// first of their security requirements which can be satisfied.
+	Credentials map[string]Credential
+	// ToolFilter selects tools for which tool.Predicate returns true.
+	// If ToolFilter is nil, then all tools are returned.
+	// tool.StringPredicate can be convenient if there's a known fixed list of tool names.
+	ToolFilter tool.Predicate
+}
+
+// Credential holds the secret of a security scheme.
+type Credential struct {
+	// APIKey is used for "apiKey" schemes, sent in the header, query
+	// parameter or cookie defined by the scheme.
+	APIKey string
+	// Token is used for "http" schemes with the "bearer" scheme, "oauth2" and
+	// "openIdConnect" schemes.
+	Token string
+	// TokenSource is used instead of Token if set, e.g. to refresh OAuth2
+	// tokens.
+	TokenSource oauth2.TokenSource
+	// Username and Password are used for "http" schemes with the "basic"
+	// scheme.
+	Username string
+	Password string
+}
+
+// New returns OpenAPI ToolSet.
+// OpenAPI ToolSet parses the OpenAPI 3 document and creates a tool for each
+// operation. Tools are named after the operationId in snake_case, or after
+// the method and path for operations without operationId. The parameters and
+// the request body of an operation are the arguments of its tool, the
+// request body being the "body" argument.
+//
+// Example:
+//
+//	llmagent.New(llmagent.Config{
+//		Name:        "agent_name",
+//		Model:       model,
+//		Description: "...",
+//		Instruction: "...",
+//		Toolsets: []tool.Toolset{
+//			openapitoolset.New(openapitoolset.Config{
+//				Spec:        spec,
+//				Credentials: map[string]openapitoolset.Credential{"api_key": {APIKey: key}},
+//			}),
+//		},
+//	})
+func New(cfg Config) (tool.Toolset, error) {
+	doc, err := parseDocument(cfg.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &set{
+		baseURL:     strings.TrimSuffix(cfg.BaseURL, "/"),
+		client:      cfg.HTTPClient,
+		credentials: cfg.Credentials,
+		schemes:     doc.Components.SecuritySchemes,
+		toolFilter:  cfg.ToolFilter,
+	}
+	if s.baseURL == "" {
+		s.baseURL = strings.TrimSuffix(doc.serverURL(), "/")
+	}
+	if s.baseURL == "" {
+		return nil, fmt.Errorf("OpenAPI document has no servers, BaseURL must be provided")
+	}
+	if s.client == nil {
+		s.client = http.DefaultClient
+	}
+	for name := range cfg.Credentials {
+		if s.schemes[name] == nil {
+			return nil, fmt.Errorf("credentials provided for unknown security scheme %q", name)
+		}
+	}
+
+	names := make(map[string]string)
+	for _, path := range sortedKeys(doc.Paths) {
+		item := doc.Paths[path]
+		ops := item.operations()
+		for _, method := range sortedKeys(ops) {
+			t, err := newOperationTool(s, doc, method, path, item, ops[method])
+			if err != nil {
+				return nil, fmt.Errorf("operation %s %s: %w", method, path, err)
+			}
+			if other, ok := names[t.name]; ok {
+				return nil, fmt.Errorf("operation %s %s: tool name %q is already used by operation %s", method, path, t.name, other)
+			}
+			names[t.name] = method + " " + path
+			s.tools = append(s.tools, t)
+		}
+	}
+	return s, nil
+}
+
+type set struct {
+	baseURL     string
+	client      *http.Client
+	credentials map[string]Credential
+	schemes     map[string]*securityScheme
+	toolFilter  tool.Predicate
+	tools       []*operationTool
+}
+
+func (*set) Name() string {
+	return "openapi_tool_set"
+}
+
+// Tools returns the tools of the operations selected by the filter.
+func (s *set) Tools(ctx agent.ReadonlyContext) ([]tool.Tool, error) {
+	var tools []tool.Tool
+	for _, t := range s.tools {
+		if s.toolFilter != nil && !s.toolFilter(ctx, t) {
+			continue
+		}
+		tools = append(tools, t)
+	}
+	return tools, nil
+}
+
+// authorize applies the credentials of the first security requirement which
+// can be satisfied. Requests of operations without satisfiable requirements
+// are sent without credentials.
+func (s *set) authorize(req *http.Request, requirements []map[string][]string) error {
+	for _, requirement := range requirements {
+		satisfied := true
+		for name := range requirement {
+			if _, ok := s.credentials[name]; !ok {
+				satisfied = false
+				break
+			}
+		}
+		if !satisfied {
+			continue
+		}
+		for _, name := range sortedKeys(requirement) {
+			if err := s.applyCredential(req, s.schemes[name], s.credentials[name]); err != nil {
+				return fmt.Errorf("security scheme %q: %w", name, err)
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+func (s *set) applyCredential(req *http.Request, scheme *securityScheme, cred Credential) error {
+	switch {
+	case scheme.Type == "apiKey":
+		switch scheme.In {
+		case "header":
+			req.Header.Set(scheme.Name, cred.APIKey)
+		case "query":
+			q := req.URL.Query()
+			q.Set(scheme.Name, cred.APIKey)
+			req.URL.RawQuery = q.Encode()
+		case "cookie":
+			req.AddCookie(&http.Cookie{Name: scheme.Name, Value: cred.APIKey})
+		default:
+			return fmt.Errorf("unsupported API key location %q", scheme.In)
+		}
+	case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+		req.SetBasicAuth(cred.Username, cred.Password)
+	case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "bearer"),
+		scheme.Type == "oauth2", scheme.Type == "openIdConnect":
+		token := cred.Token
+		if cred.TokenSource != nil {
+			t, err := cred.TokenSource.Token()
+			if err != nil {
+				return fmt.Errorf("failed to get token: %w", err)
+			}
+			token = t.AccessToken
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	default:
+		return fmt.Errorf("unsupported security scheme type %q", scheme.Type)
+	}
+	return nil
+}
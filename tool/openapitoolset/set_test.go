@@ -0,0 +1,284 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapitoolset_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/adk/agent"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/openapitoolset"
+)
+
+func TestToolset_Declarations(t *testing.T) {
+	tools := loadTools(t, openapitoolset.Config{BaseURL: "http://localhost"})
+
+	var names []string
+	for _, tl := range tools {
+		names = append(names, tl.Name())
+	}
+	if diff := cmp.Diff([]string{"list_pets", "create_pet", "get_pets_pet_id"}, names); diff != "" {
+		t.Errorf("tool names mismatch (-want +got):\n%s", diff)
+	}
+
+	wantParams := map[string]any{
+		"list_pets": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"limit": map[string]any{"type": "integer", "maximum": 100, "description": "Maximum number of pets."},
+				"tags":  map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Tags to filter by."},
+			},
+		},
+		"create_pet": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"body": map[string]any{
+					"type":     "object",
+					"required": []any{"name"},
+					"properties": map[string]any{
+						"name":   map[string]any{"type": "string"},
+						"tag":    map[string]any{"type": []any{"string", "null"}},
+						"parent": map[string]any{},
+					},
+				},
+			},
+			"required": []string{"body"},
+		},
+		"get_pets_pet_id": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"petId":        map[string]any{"type": "integer"},
+				"X-Request-Id": map[string]any{"type": "string"},
+			},
+			"required": []string{"petId"},
+		},
+	}
+	for _, tl := range tools {
+		decl := tl.(toolinternal.FunctionTool).Declaration()
+		if diff := cmp.Diff(wantParams[tl.Name()], decl.ParametersJsonSchema); diff != "" {
+			t.Errorf("%s parameters mismatch (-want +got):\n%s", tl.Name(), diff)
+		}
+	}
+}
+
+func TestToolset_Run(t *testing.T) {
+	var gotRequests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("X-API-Key")
+		if auth == "" {
+			auth = r.Header.Get("Authorization")
+		}
+		gotRequests = append(gotRequests, r.Method+" "+r.URL.String()+" auth="+auth+" request-id="+r.Header.Get("X-Request-Id"))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/pets":
+			_, _ = w.Write([]byte(`[{"name":"Rex"},{"name":"Tom"}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/pets":
+			var pet map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&pet); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			pet["id"] = 3
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(pet)
+		case r.URL.Path == "/pets/7":
+			_, _ = w.Write([]byte(`{"name":"Rex"}`))
+		case r.URL.Path == "/pets/9":
+			_, _ = w.Write([]byte(`{"name":"` + strings.Repeat("x", 1<<20) + `"}`))
+		default:
+			http.Error(w, "pet not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tools := loadTools(t, openapitoolset.Config{
+		BaseURL: server.URL,
+		Credentials: map[string]openapitoolset.Credential{
+			"api_key": {APIKey: "secret"},
+			"bearer":  {Token: "token"},
+		},
+	})
+	toolsByName := make(map[string]toolinternal.FunctionTool)
+	for _, tl := range tools {
+		toolsByName[tl.Name()] = tl.(toolinternal.FunctionTool)
+	}
+
+	tests := []struct {
+		name    string
+		tool    string
+		args    map[string]any
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			name: "query parameters",
+			tool: "list_pets",
+			args: map[string]any{"limit": 2.0, "tags": []any{"a", "b"}},
+			want: map[string]any{"status_code": 200, "body": []any{map[string]any{"name": "Rex"}, map[string]any{"name": "Tom"}}},
+		},
+		{
+			name: "request body",
+			tool: "create_pet",
+			args: map[string]any{"body": map[string]any{"name": "Max"}},
+			want: map[string]any{"status_code": 201, "body": map[string]any{"name": "Max", "id": 3.0}},
+		},
+		{
+			name: "path and header parameters",
+			tool: "get_pets_pet_id",
+			args: map[string]any{"petId": 7.0, "X-Request-Id": "r1"},
+			want: map[string]any{"status_code": 200, "body": map[string]any{"name": "Rex"}},
+		},
+		{
+			name: "truncated response",
+			tool: "get_pets_pet_id",
+			args: map[string]any{"petId": 9.0},
+			want: map[string]any{"status_code": 200, "body": `{"name":"` + strings.Repeat("x", 1<<20-9), "truncated": true},
+		},
+		{
+			name:    "error status",
+			tool:    "get_pets_pet_id",
+			args:    map[string]any{"petId": 8.0},
+			wantErr: "404 Not Found: pet not found",
+		},
+		{
+			name:    "missing required argument",
+			tool:    "get_pets_pet_id",
+			args:    map[string]any{},
+			wantErr: `missing required argument "petId"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toolsByName[tt.tool].Run(newToolContext(t), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Run() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	wantRequests := []string{
+		"GET /pets?limit=2&tags=a&tags=b auth=secret request-id=",
+		"POST /pets auth=Bearer token request-id=",
+		"GET /pets/7 auth=secret request-id=r1",
+		"GET /pets/9 auth=secret request-id=",
+		"GET /pets/8 auth=secret request-id=",
+	}
+	if diff := cmp.Diff(wantRequests, gotRequests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestToolset_ToolFilter(t *testing.T) {
+	tools := loadTools(t, openapitoolset.Config{
+		BaseURL:    "http://localhost",
+		ToolFilter: tool.StringPredicate([]string{"create_pet"}),
+	})
+	if len(tools) != 1 || tools[0].Name() != "create_pet" {
+		t.Errorf("Tools() = %v, want only create_pet", tools)
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  openapitoolset.Config
+	}{
+		{
+			name: "swagger 2",
+			cfg:  openapitoolset.Config{Spec: []byte(`{"swagger": "2.0"}`), BaseURL: "http://localhost"},
+		},
+		{
+			name: "no server",
+			cfg:  openapitoolset.Config{Spec: []byte(`{"openapi": "3.0.0", "paths": {}}`)},
+		},
+		{
+			name: "unknown security scheme",
+			cfg: openapitoolset.Config{
+				Spec:        []byte(`{"openapi": "3.0.0", "servers": [{"url": "http://localhost"}], "paths": {}}`),
+				Credentials: map[string]openapitoolset.Credential{"oauth": {Token: "t"}},
+			},
+		},
+		{
+			name: "unresolvable reference",
+			cfg: openapitoolset.Config{
+				Spec:    []byte(`{"openapi": "3.0.0", "paths": {"/a": {"get": {"parameters": [{"$ref": "#/components/parameters/B"}]}}}}`),
+				BaseURL: "http://localhost",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := openapitoolset.New(tt.cfg); err == nil {
+				t.Error("New() succeeded, want error")
+			}
+		})
+	}
+}
+
+func loadTools(t *testing.T, cfg openapitoolset.Config) []tool.Tool {
+	t.Helper()
+
+	spec, err := os.ReadFile("testdata/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Spec = spec
+	ts, err := openapitoolset.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tools, err := ts.Tools(icontext.NewReadonlyContext(newInvocationContext(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tools
+}
+
+func newInvocationContext(t *testing.T) agent.InvocationContext {
+	t.Helper()
+
+	sessionService := session.InMemoryService()
+	resp, err := sessionService.Create(t.Context(), &session.CreateRequest{AppName: "app", UserID: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{Session: resp.Session})
+}
+
+func newToolContext(t *testing.T) tool.Context {
+	t.Helper()
+	return toolinternal.NewToolContext(newInvocationContext(t), "", nil)
+}
@@ -0,0 +1,278 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapitoolset
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// document is the subset of an OpenAPI 3 document used to build tools.
+type document struct {
+	OpenAPI    string                `yaml:"openapi"`
+	Servers    []server              `yaml:"servers"`
+	Paths      map[string]*pathItem  `yaml:"paths"`
+	Components components            `yaml:"components"`
+	Security   []map[string][]string `yaml:"security"`
+}
+
+type server struct {
+	URL       string `yaml:"url"`
+	Variables map[string]struct {
+		Default string `yaml:"default"`
+	} `yaml:"variables"`
+}
+
+type pathItem struct {
+	Parameters []*parameter `yaml:"parameters"`
+	Get        *operation   `yaml:"get"`
+	Put        *operation   `yaml:"put"`
+	Post       *operation   `yaml:"post"`
+	Delete     *operation   `yaml:"delete"`
+	Options    *operation   `yaml:"options"`
+	Head       *operation   `yaml:"head"`
+	Patch      *operation   `yaml:"patch"`
+	Trace      *operation   `yaml:"trace"`
+}
+
+// operations returns the operations of the path item by HTTP method.
+func (p *pathItem) operations() map[string]*operation {
+	ops := map[string]*operation{
+		"GET":     p.Get,
+		"PUT":     p.Put,
+		"POST":    p.Post,
+		"DELETE":  p.Delete,
+		"OPTIONS": p.Options,
+		"HEAD":    p.Head,
+		"PATCH":   p.Patch,
+		"TRACE":   p.Trace,
+	}
+	maps.DeleteFunc(ops, func(_ string, op *operation) bool { return op == nil })
+	return ops
+}
+
+type operation struct {
+	OperationID string                 `yaml:"operationId"`
+	Summary     string                 `yaml:"summary"`
+	Description string                 `yaml:"description"`
+	Parameters  []*parameter           `yaml:"parameters"`
+	RequestBody *requestBody           `yaml:"requestBody"`
+	Security    *[]map[string][]string `yaml:"security"`
+}
+
+type parameter struct {
+	Ref         string         `yaml:"$ref"`
+	Name        string         `yaml:"name"`
+	In          string         `yaml:"in"`
+	Description string         `yaml:"description"`
+	Required    bool           `yaml:"required"`
+	Schema      map[string]any `yaml:"schema"`
+}
+
+type requestBody struct {
+	Ref         string                `yaml:"$ref"`
+	Description string                `yaml:"description"`
+	Required    bool                  `yaml:"required"`
+	Content     map[string]*mediaType `yaml:"content"`
+}
+
+type mediaType struct {
+	Schema map[string]any `yaml:"schema"`
+}
+
+type components struct {
+	Schemas         map[string]map[string]any  `yaml:"schemas"`
+	Parameters      map[string]*parameter      `yaml:"parameters"`
+	RequestBodies   map[string]*requestBody    `yaml:"requestBodies"`
+	SecuritySchemes map[string]*securityScheme `yaml:"securitySchemes"`
+}
+
+type securityScheme struct {
+	Type   string `yaml:"type"`
+	Scheme string `yaml:"scheme"`
+	Name   string `yaml:"name"`
+	In     string `yaml:"in"`
+}
+
+// parseDocument parses an OpenAPI 3 document in JSON or YAML. JSON documents
+// are valid YAML.
+func parseDocument(data []byte) (*document, error) {
+	var doc document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q, want 3.x", doc.OpenAPI)
+	}
+	return &doc, nil
+}
+
+// serverURL returns the URL of the first server with its variables replaced
+// by their defaults.
+func (d *document) serverURL() string {
+	if len(d.Servers) == 0 {
+		return ""
+	}
+	u := d.Servers[0].URL
+	for name, v := range d.Servers[0].Variables {
+		u = strings.ReplaceAll(u, "{"+name+"}", v.Default)
+	}
+	return u
+}
+
+func (d *document) resolveParameter(p *parameter) (*parameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+	name, ok := strings.CutPrefix(p.Ref, "#/components/parameters/")
+	if !ok || d.Components.Parameters[name] == nil {
+		return nil, fmt.Errorf("unresolvable parameter reference %q", p.Ref)
+	}
+	return d.resolveParameter(d.Components.Parameters[name])
+}
+
+func (d *document) resolveRequestBody(b *requestBody) (*requestBody, error) {
+	if b.Ref == "" {
+		return b, nil
+	}
+	name, ok := strings.CutPrefix(b.Ref, "#/components/requestBodies/")
+	if !ok || d.Components.RequestBodies[name] == nil {
+		return nil, fmt.Errorf("unresolvable request body reference %q", b.Ref)
+	}
+	return d.resolveRequestBody(d.Components.RequestBodies[name])
+}
+
+// schemaKeywords are the keywords kept when converting OpenAPI schemas to the
+// JSON schemas of function declarations. Other keywords, e.g. "example" or
+// "xml", are dropped.
+var schemaKeywords = []string{
+	"type", "format", "title", "description", "enum", "default", "items",
+	"properties", "required", "additionalProperties", "anyOf", "oneOf", "allOf",
+	"minimum", "maximum", "minItems", "maxItems", "minLength", "maxLength", "pattern",
+}
+
+// jsonSchema converts an OpenAPI schema to a JSON schema, inlining references
+// to component schemas. Recursive references are replaced by an empty schema.
+func (d *document) jsonSchema(schema map[string]any, visiting map[string]bool) (map[string]any, error) {
+	if schema == nil {
+		return map[string]any{}, nil
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+		if !ok || d.Components.Schemas[name] == nil {
+			return nil, fmt.Errorf("unresolvable schema reference %q", ref)
+		}
+		if visiting[name] {
+			return map[string]any{}, nil
+		}
+		visiting[name] = true
+		defer delete(visiting, name)
+		return d.jsonSchema(d.Components.Schemas[name], visiting)
+	}
+
+	result := make(map[string]any)
+	for _, k := range schemaKeywords {
+		v, ok := schema[k]
+		if !ok {
+			continue
+		}
+		var err error
+		switch k {
+		case "items":
+			v, err = d.subSchema(v, visiting)
+		case "additionalProperties":
+			if _, isBool := v.(bool); !isBool {
+				v, err = d.subSchema(v, visiting)
+			}
+		case "properties":
+			props, _ := v.(map[string]any)
+			converted := make(map[string]any, len(props))
+			for name, prop := range props {
+				if converted[name], err = d.subSchema(prop, visiting); err != nil {
+					break
+				}
+			}
+			v = converted
+		case "anyOf", "oneOf", "allOf":
+			list, _ := v.([]any)
+			converted := make([]any, len(list))
+			for i, s := range list {
+				if converted[i], err = d.subSchema(s, visiting); err != nil {
+					break
+				}
+			}
+			v = converted
+		}
+		if err != nil {
+			return nil, err
+		}
+		result[k] = v
+	}
+	if nullable, _ := schema["nullable"].(bool); nullable {
+		if t, ok := result["type"].(string); ok {
+			result["type"] = []any{t, "null"}
+		}
+	}
+	return result, nil
+}
+
+func (d *document) subSchema(v any, visiting map[string]bool) (any, error) {
+	schema, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid schema %v", v)
+	}
+	return d.jsonSchema(schema, visiting)
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// toolName returns the snake_case name of the operation, derived from its
+// operationId or, if it has none, from its method and path.
+func toolName(method, path string, op *operation) string {
+	name := op.OperationID
+	if name == "" {
+		name = strings.ToLower(method) + "_" + path
+	}
+	var sb strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		isUpper := r >= 'A' && r <= 'Z'
+		if isUpper && i > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z'
+			if (prev >= 'a' && prev <= 'z') || (prev >= '0' && prev <= '9') || (prev >= 'A' && prev <= 'Z' && nextIsLower) {
+				sb.WriteByte('_')
+			}
+		}
+		sb.WriteRune(r)
+	}
+	// Runs of other characters, including underscores, become one underscore.
+	name = invalidNameChars.ReplaceAllString(strings.ToLower(sb.String()), "_")
+	name = strings.Trim(name, "_")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// sortedKeys returns the keys of m in lexical order.
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
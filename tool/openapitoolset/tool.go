@@ -0,0 +1,368 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapitoolset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// bodyArg is the argument holding the request body of an operation.
+const bodyArg = "body"
+
+// maxResponseSize limits the size of the response bodies returned to the
+// model.
+const maxResponseSize = 1 << 20
+
+// operationParam is a parameter of an operation, exposed to the model as the
+// argument argName.
+type operationParam struct {
+	argName  string
+	name     string
+	in       string
+	required bool
+}
+
+// operationTool is a tool calling an operation of an OpenAPI document.
+type operationTool struct {
+	name        string
+	description string
+	method      string
+	path        string
+	params      []operationParam
+	// bodyType is the media type of the request body, empty if the operation
+	// has no request body.
+	bodyType     string
+	bodyRequired bool
+	declaration  *genai.FunctionDeclaration
+	// security lists the alternative security requirements of the operation.
+	security []map[string][]string
+
+	set *set
+}
+
+func newOperationTool(s *set, doc *document, method, path string, item *pathItem, op *operation) (*operationTool, error) {
+	t := &operationTool{
+		name:        toolName(method, path, op),
+		description: strings.TrimSpace(op.Summary + "\n\n" + op.Description),
+		method:      method,
+		path:        path,
+		security:    doc.Security,
+		set:         s,
+	}
+	if op.Security != nil {
+		t.security = *op.Security
+	}
+
+	properties := make(map[string]any)
+	var required []string
+
+	// Operation parameters override the path item parameters with the same
+	// name and location.
+	var params []*parameter
+	seen := make(map[string]int)
+	for _, p := range append(append([]*parameter{}, item.Parameters...), op.Parameters...) {
+		resolved, err := doc.resolveParameter(p)
+		if err != nil {
+			return nil, err
+		}
+		key := resolved.In + "/" + resolved.Name
+		if i, ok := seen[key]; ok {
+			params[i] = resolved
+			continue
+		}
+		seen[key] = len(params)
+		params = append(params, resolved)
+	}
+
+	for _, p := range params {
+		switch p.In {
+		case "path", "query", "header", "cookie":
+		default:
+			return nil, fmt.Errorf("parameter %q has unsupported location %q", p.Name, p.In)
+		}
+		argName := p.Name
+		if _, ok := properties[argName]; ok || argName == bodyArg {
+			argName = p.Name + "_" + p.In
+		}
+		schema, err := doc.jsonSchema(p.Schema, map[string]bool{})
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", p.Name, err)
+		}
+		if p.Description != "" {
+			schema["description"] = p.Description
+		}
+		properties[argName] = schema
+		// Path parameters are always required.
+		isRequired := p.Required || p.In == "path"
+		if isRequired {
+			required = append(required, argName)
+		}
+		t.params = append(t.params, operationParam{argName: argName, name: p.Name, in: p.In, required: isRequired})
+	}
+
+	if op.RequestBody != nil {
+		body, err := doc.resolveRequestBody(op.RequestBody)
+		if err != nil {
+			return nil, err
+		}
+		t.bodyType = pickMediaType(body.Content)
+		if t.bodyType != "" {
+			schema, err := doc.jsonSchema(body.Content[t.bodyType].Schema, map[string]bool{})
+			if err != nil {
+				return nil, fmt.Errorf("request body: %w", err)
+			}
+			if body.Description != "" {
+				schema["description"] = body.Description
+			}
+			properties[bodyArg] = schema
+			t.bodyRequired = body.Required
+			if body.Required {
+				required = append(required, bodyArg)
+			}
+		}
+	}
+
+	parameters := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		parameters["required"] = required
+	}
+	t.declaration = &genai.FunctionDeclaration{
+		Name:                 t.name,
+		Description:          t.description,
+		ParametersJsonSchema: parameters,
+	}
+	return t, nil
+}
+
+// pickMediaType returns the media type used for request bodies, preferring
+// JSON.
+func pickMediaType(content map[string]*mediaType) string {
+	types := sortedKeys(content)
+	for _, t := range types {
+		if t == "application/json" || strings.HasSuffix(t, "+json") {
+			return t
+		}
+	}
+	for _, t := range types {
+		if t == "application/x-www-form-urlencoded" || strings.HasPrefix(t, "text/") {
+			return t
+		}
+	}
+	return ""
+}
+
+// Name implements tool.Tool.
+func (t *operationTool) Name() string {
+	return t.name
+}
+
+// Description implements tool.Tool.
+func (t *operationTool) Description() string {
+	return t.description
+}
+
+// IsLongRunning implements tool.Tool.
+func (t *operationTool) IsLongRunning() bool {
+	return false
+}
+
+func (t *operationTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return toolutils.PackTool(req, t)
+}
+
+func (t *operationTool) Declaration() *genai.FunctionDeclaration {
+	return t.declaration
+}
+
+// Run calls the operation. It returns the status code and the body of the
+// response, decoded if it's JSON. Bodies larger than 1 MiB are cut, returned
+// as text and flagged as "truncated". Responses with an error status are
+// returned as errors.
+func (t *operationTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	margs, ok := args.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected args type, got: %T", args)
+	}
+
+	req, err := t.newRequest(ctx, margs)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.set.authorize(req, t.security); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.set.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s %s: %w", t.method, t.path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	truncated := len(data) > maxResponseSize
+	if truncated {
+		data = data[:maxResponseSize]
+	}
+	var body any = string(data)
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		var decoded any
+		if err := json.Unmarshal(data, &decoded); err == nil {
+			body = decoded
+		}
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("%s %s returned %s: %s", t.method, t.path, resp.Status, strings.TrimSpace(string(data)))
+	}
+	result := map[string]any{
+		"status_code": resp.StatusCode,
+		"body":        body,
+	}
+	if truncated {
+		result["truncated"] = true
+	}
+	return result, nil
+}
+
+func (t *operationTool) newRequest(ctx tool.Context, args map[string]any) (*http.Request, error) {
+	path := t.path
+	query := url.Values{}
+	header := http.Header{}
+	var cookies []*http.Cookie
+	for _, p := range t.params {
+		v, ok := args[p.argName]
+		if !ok || v == nil {
+			if p.required {
+				return nil, fmt.Errorf("missing required argument %q", p.argName)
+			}
+			continue
+		}
+		switch p.in {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+p.name+"}", url.PathEscape(formatValue(v)))
+		case "query":
+			if list, ok := v.([]any); ok {
+				for _, item := range list {
+					query.Add(p.name, formatValue(item))
+				}
+			} else {
+				query.Set(p.name, formatValue(v))
+			}
+		case "header":
+			header.Set(p.name, formatValue(v))
+		case "cookie":
+			cookies = append(cookies, &http.Cookie{Name: p.name, Value: formatValue(v)})
+		}
+	}
+
+	var body io.Reader
+	if t.bodyType != "" {
+		v, ok := args[bodyArg]
+		switch {
+		case !ok && t.bodyRequired:
+			return nil, fmt.Errorf("missing required argument %q", bodyArg)
+		case ok:
+			data, err := encodeBody(t.bodyType, v)
+			if err != nil {
+				return nil, err
+			}
+			body = bytes.NewReader(data)
+			header.Set("Content-Type", t.bodyType)
+		}
+	}
+
+	u := t.set.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, t.method, u, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+	return req, nil
+}
+
+func encodeBody(mediaType string, v any) ([]byte, error) {
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		fields, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("form request body must be an object, got %T", v)
+		}
+		form := url.Values{}
+		for k, field := range fields {
+			form.Set(k, formatValue(field))
+		}
+		return []byte(form.Encode()), nil
+	case strings.HasPrefix(mediaType, "text/"):
+		return []byte(formatValue(v)), nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		return data, nil
+	}
+}
+
+// formatValue formats a parameter value for URLs and headers.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case fmt.Stringer:
+		return v.String()
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+var (
+	_ toolinternal.FunctionTool     = (*operationTool)(nil)
+	_ toolinternal.RequestProcessor = (*operationTool)(nil)
+)
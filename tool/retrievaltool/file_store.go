@@ -0,0 +1,117 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retrievaltool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// NewFileStore returns a VectorStore persisting the chunks in a JSON file at
+// path, so that documents are indexed once across restarts. The chunks are
+// loaded in memory and searched like with NewInMemoryStore; every change
+// rewrites the file. The file is created on the first change if it doesn't
+// exist.
+func NewFileStore(path string) (VectorStore, error) {
+	s := &fileStore{path: path, chunks: make(map[string]Chunk)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vector store: %w", err)
+	}
+	var chunks []Chunk
+	if err := json.Unmarshal(data, &chunks); err != nil {
+		return nil, fmt.Errorf("failed to decode vector store %q: %w", path, err)
+	}
+	for _, c := range chunks {
+		s.chunks[c.ID] = c
+	}
+	return s, nil
+}
+
+type fileStore struct {
+	path string
+
+	mu     sync.RWMutex
+	chunks map[string]Chunk
+}
+
+func (s *fileStore) ReplaceSource(ctx context.Context, source string, chunks []Chunk) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	updated, err := replaceSource(s.chunks, source, chunks)
+	if err != nil {
+		return err
+	}
+	return s.save(updated)
+}
+
+func (s *fileStore) DeleteSource(ctx context.Context, source string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	updated := maps.Clone(s.chunks)
+	maps.DeleteFunc(updated, func(_ string, c Chunk) bool { return c.Source == source })
+	if len(updated) == len(s.chunks) {
+		return nil
+	}
+	return s.save(updated)
+}
+
+func (s *fileStore) Search(ctx context.Context, embedding []float32, k int) ([]Match, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return search(s.chunks, embedding, k), nil
+}
+
+// save writes the chunks to the file and makes them the content of the store.
+// The file is replaced atomically, so that it's never left half written.
+func (s *fileStore) save(chunks map[string]Chunk) error {
+	sorted := make([]Chunk, 0, len(chunks))
+	for _, id := range slices.Sorted(maps.Keys(chunks)) {
+		sorted = append(sorted, chunks[id])
+	}
+	data, err := json.Marshal(sorted)
+	if err != nil {
+		return fmt.Errorf("failed to encode vector store: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to write vector store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write vector store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write vector store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write vector store: %w", err)
+	}
+	s.chunks = chunks
+	return nil
+}
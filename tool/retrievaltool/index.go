@@ -0,0 +1,192 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retrievaltool
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+)
+
+// IndexConfig configures an Index.
+type IndexConfig struct {
+	// Embedder computes the embeddings of the chunks and queries.
+	Embedder model.Embedder
+	// Store keeps the chunks. Defaults to NewInMemoryStore().
+	Store VectorStore
+	// ChunkSize is the maximum number of characters of a chunk. Defaults to
+	// 1000.
+	ChunkSize int
+	// ChunkOverlap is the number of characters shared by consecutive chunks,
+	// so that sentences across chunk boundaries can be found. Defaults to
+	// 100 or a tenth of ChunkSize, whichever is smaller. Set it to NoOverlap
+	// for chunks that don't overlap.
+	ChunkOverlap int
+}
+
+// NoOverlap is the IndexConfig.ChunkOverlap of chunks that don't overlap.
+const NoOverlap = -1
+
+// Index splits documents into chunks and stores them with their embeddings,
+// to search them by similarity with a query.
+type Index struct {
+	embedder     model.Embedder
+	store        VectorStore
+	chunkSize    int
+	chunkOverlap int
+}
+
+// NewIndex creates an Index.
+func NewIndex(cfg IndexConfig) (*Index, error) {
+	if cfg.Embedder == nil {
+		return nil, fmt.Errorf("embedder is required")
+	}
+	if cfg.Store == nil {
+		cfg.Store = NewInMemoryStore()
+	}
+	if cfg.ChunkSize == 0 {
+		cfg.ChunkSize = 1000
+	}
+	switch {
+	case cfg.ChunkOverlap == 0:
+		cfg.ChunkOverlap = min(100, cfg.ChunkSize/10)
+	case cfg.ChunkOverlap == NoOverlap:
+		cfg.ChunkOverlap = 0
+	}
+	if cfg.ChunkSize < 0 || cfg.ChunkOverlap < 0 || cfg.ChunkOverlap >= cfg.ChunkSize {
+		return nil, fmt.Errorf("invalid chunking: ChunkSize %d, ChunkOverlap %d; ChunkOverlap must be smaller than ChunkSize", cfg.ChunkSize, cfg.ChunkOverlap)
+	}
+	return &Index{
+		embedder:     cfg.Embedder,
+		store:        cfg.Store,
+		chunkSize:    cfg.ChunkSize,
+		chunkOverlap: cfg.ChunkOverlap,
+	}, nil
+}
+
+// IndexText indexes the text of the source, replacing the chunks previously
+// indexed for it.
+func (idx *Index) IndexText(ctx context.Context, source, text string) error {
+	texts := splitText(text, idx.chunkSize, idx.chunkOverlap)
+	var chunks []Chunk
+	if len(texts) > 0 {
+		// Embed before touching the store, so that the previous chunks stay
+		// searchable if embedding fails.
+		resp, err := idx.embedder.Embed(ctx, &model.EmbedRequest{Texts: texts, TaskType: model.EmbedTaskRetrievalDocument})
+		if err != nil {
+			return fmt.Errorf("failed to embed %q: %w", source, err)
+		}
+		if len(resp.Embeddings) != len(texts) {
+			return fmt.Errorf("embedder returned %d embeddings for %d chunks", len(resp.Embeddings), len(texts))
+		}
+		chunks = make([]Chunk, len(texts))
+		for i, t := range texts {
+			chunks[i] = Chunk{
+				ID:        fmt.Sprintf("%s#%d", source, i),
+				Source:    source,
+				Index:     i,
+				Text:      t,
+				Embedding: resp.Embeddings[i],
+			}
+		}
+	}
+	if err := idx.store.ReplaceSource(ctx, source, chunks); err != nil {
+		return fmt.Errorf("failed to store chunks of %q: %w", source, err)
+	}
+	return nil
+}
+
+// IndexFile indexes the text file at path, using the path as source.
+func (idx *Index) IndexFile(ctx context.Context, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %q: %w", path, err)
+	}
+	return idx.IndexText(ctx, path, string(data))
+}
+
+// IndexArtifact indexes the latest version of the text artifact with the
+// given name, using the name as source. The artifact must be a text part or
+// inline data with a text MIME type.
+func (idx *Index) IndexArtifact(ctx context.Context, artifacts agent.Artifacts, name string) error {
+	if artifacts == nil {
+		return fmt.Errorf("artifact service is not configured")
+	}
+	resp, err := artifacts.Load(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to load artifact %q: %w", name, err)
+	}
+	part := resp.Part
+	switch {
+	case part == nil:
+		return fmt.Errorf("artifact %q is empty", name)
+	case part.Text != "":
+		return idx.IndexText(ctx, name, part.Text)
+	case part.InlineData != nil && toolutils.IsTextMIMEType(part.InlineData.MIMEType):
+		return idx.IndexText(ctx, name, string(part.InlineData.Data))
+	}
+	return fmt.Errorf("artifact %q is not a text artifact", name)
+}
+
+// Search returns the k chunks most similar to the query.
+func (idx *Index) Search(ctx context.Context, query string, k int) ([]Match, error) {
+	resp, err := idx.embedder.Embed(ctx, &model.EmbedRequest{Texts: []string{query}, TaskType: model.EmbedTaskRetrievalQuery})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	if len(resp.Embeddings) != 1 {
+		return nil, fmt.Errorf("embedder returned %d embeddings for the query", len(resp.Embeddings))
+	}
+	return idx.store.Search(ctx, resp.Embeddings[0], k)
+}
+
+// splitText splits text into chunks of at most size characters, consecutive
+// chunks sharing up to overlap characters. Chunks start and end at whitespace
+// when possible, so that words aren't cut.
+func splitText(text string, size, overlap int) []string {
+	runes := []rune(strings.TrimSpace(text))
+	var chunks []string
+	for start := 0; start < len(runes); {
+		end := min(start+size, len(runes))
+		if end < len(runes) {
+			// Cut at the last whitespace of the second half of the chunk.
+			for i := end; i > start+size/2; i-- {
+				if unicode.IsSpace(runes[i]) {
+					end = i
+					break
+				}
+			}
+		}
+		if chunk := strings.TrimSpace(string(runes[start:end])); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		if end == len(runes) {
+			break
+		}
+		next := max(end-overlap, start+1)
+		// Start the next chunk at a word boundary.
+		for next < end && !unicode.IsSpace(runes[next-1]) {
+			next++
+		}
+		start = next
+	}
+	return chunks
+}
@@ -0,0 +1,352 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retrievaltool_test
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/artifact"
+	artifactinternal "google.golang.org/adk/internal/artifact"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/retrievaltool"
+)
+
+// fakeEmbedder embeds texts as normalized bags of words hashed into a fixed
+// number of dimensions, so that texts sharing words are similar.
+type fakeEmbedder struct {
+	calls int
+	err   error
+}
+
+const fakeDimensions = 256
+
+func (e *fakeEmbedder) Name() string {
+	return "fake-embedder"
+}
+
+func (e *fakeEmbedder) Embed(ctx context.Context, req *model.EmbedRequest) (*model.EmbedResponse, error) {
+	e.calls++
+	if e.err != nil {
+		return nil, e.err
+	}
+	resp := &model.EmbedResponse{}
+	for _, text := range req.Texts {
+		v := make([]float32, fakeDimensions)
+		for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) }) {
+			h := fnv.New32a()
+			_, _ = h.Write([]byte(word))
+			v[h.Sum32()%fakeDimensions]++
+		}
+		var norm float64
+		for _, x := range v {
+			norm += float64(x * x)
+		}
+		if norm > 0 {
+			for i := range v {
+				v[i] /= float32(math.Sqrt(norm))
+			}
+		}
+		resp.Embeddings = append(resp.Embeddings, v)
+	}
+	return resp, nil
+}
+
+var documents = map[string]string{
+	"pets.txt":    "Cats sleep most of the day. Dogs need a walk every morning.",
+	"weather.txt": "Paris weather is mild in spring. Rain is frequent in autumn.",
+	"cooking.txt": "Boil pasta in salted water. Serve the pasta with tomato sauce.",
+}
+
+func newIndex(t *testing.T, store retrievaltool.VectorStore) *retrievaltool.Index {
+	t.Helper()
+
+	index, err := retrievaltool.NewIndex(retrievaltool.IndexConfig{Embedder: &fakeEmbedder{}, Store: store})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for source, text := range documents {
+		if err := index.IndexText(t.Context(), source, text); err != nil {
+			t.Fatalf("IndexText(%q) error = %v", source, err)
+		}
+	}
+	return index
+}
+
+func TestTool_Run(t *testing.T) {
+	retrieve, err := retrievaltool.New(retrievaltool.Config{Index: newIndex(t, nil), TopK: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query      string
+		wantSource string
+	}{
+		{query: "how often to walk the dogs", wantSource: "pets.txt"},
+		{query: "is there rain in autumn", wantSource: "weather.txt"},
+		{query: "pasta sauce", wantSource: "cooking.txt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := retrieve.(toolinternal.FunctionTool).Run(newToolContext(t), map[string]any{"query": tt.query})
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			results := got["results"].([]any)
+			if len(results) != 1 {
+				t.Fatalf("got %d results, want 1", len(results))
+			}
+			result := results[0].(map[string]any)
+			if result["source"] != tt.wantSource || result["citation"] != tt.wantSource+"#0" || result["text"] != documents[tt.wantSource] {
+				t.Errorf("Run() result = %v, want the chunk of %s", result, tt.wantSource)
+			}
+		})
+	}
+
+	if _, err := retrieve.(toolinternal.FunctionTool).Run(newToolContext(t), map[string]any{}); err == nil {
+		t.Error("Run() without query succeeded, want error")
+	}
+}
+
+func TestIndex_Chunking(t *testing.T) {
+	store := retrievaltool.NewInMemoryStore()
+	index, err := retrievaltool.NewIndex(retrievaltool.IndexConfig{
+		Embedder:     &fakeEmbedder{},
+		Store:        store,
+		ChunkSize:    20,
+		ChunkOverlap: 5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := "alpha beta gamma delta epsilon zeta eta theta iota kappa"
+	if err := index.IndexText(t.Context(), "greek.txt", text); err != nil {
+		t.Fatal(err)
+	}
+
+	matches, err := index.Search(t.Context(), "alpha", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunks := make([]string, len(matches))
+	for _, m := range matches {
+		chunks[m.Index] = m.Text
+	}
+	want := []string{"alpha beta gamma", "gamma delta epsilon", "zeta eta theta iota", "iota kappa"}
+	if diff := cmp.Diff(want, chunks); diff != "" {
+		t.Errorf("chunks mismatch (-want +got):\n%s", diff)
+	}
+
+	// Indexing the source again replaces its chunks.
+	if err := index.IndexText(t.Context(), "greek.txt", "omega"); err != nil {
+		t.Fatal(err)
+	}
+	matches, err = index.Search(t.Context(), "alpha", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Text != "omega" {
+		t.Errorf("got matches %v after reindexing, want only omega", matches)
+	}
+}
+
+func TestIndex_ChunkOverlap(t *testing.T) {
+	text := "alpha beta gamma delta epsilon zeta eta theta iota kappa lambda mu nu xi omicron pi rho sigma tau"
+	tests := []struct {
+		name string
+		cfg  retrievaltool.IndexConfig
+		want []string
+	}{
+		{
+			name: "default overlap of small chunks",
+			cfg:  retrievaltool.IndexConfig{ChunkSize: 50},
+			want: []string{"alpha beta gamma delta epsilon zeta eta theta iota", "iota kappa lambda mu nu xi omicron pi rho sigma", "sigma tau"},
+		},
+		{
+			name: "no overlap",
+			cfg:  retrievaltool.IndexConfig{ChunkSize: 20, ChunkOverlap: retrievaltool.NoOverlap},
+			want: []string{"alpha beta gamma", "delta epsilon zeta", "eta theta iota", "kappa lambda mu nu", "xi omicron pi rho", "sigma tau"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Embedder = &fakeEmbedder{}
+			index, err := retrievaltool.NewIndex(tt.cfg)
+			if err != nil {
+				t.Fatalf("NewIndex() error = %v", err)
+			}
+			if err := index.IndexText(t.Context(), "greek.txt", text); err != nil {
+				t.Fatal(err)
+			}
+			matches, err := index.Search(t.Context(), "alpha", 100)
+			if err != nil {
+				t.Fatal(err)
+			}
+			chunks := make([]string, len(matches))
+			for _, m := range matches {
+				chunks[m.Index] = m.Text
+			}
+			if diff := cmp.Diff(tt.want, chunks); diff != "" {
+				t.Errorf("chunks mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIndex_IndexFileAndArtifact(t *testing.T) {
+	index, err := retrievaltool.NewIndex(retrievaltool.IndexConfig{Embedder: &fakeEmbedder{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "garden.txt")
+	if err := os.WriteFile(path, []byte("Water the tomatoes in the garden every evening."), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := index.IndexFile(t.Context(), path); err != nil {
+		t.Fatalf("IndexFile() error = %v", err)
+	}
+
+	artifacts := &artifactinternal.Artifacts{Service: artifact.InMemoryService(), AppName: "app", UserID: "user", SessionID: "session"}
+	if _, err := artifacts.Save(t.Context(), "notes.md", genai.NewPartFromBytes([]byte("The meeting with the bank is on Monday."), "text/markdown")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := artifacts.Save(t.Context(), "photo.png", genai.NewPartFromBytes([]byte{0x89}, "image/png")); err != nil {
+		t.Fatal(err)
+	}
+	if err := index.IndexArtifact(t.Context(), artifacts, "notes.md"); err != nil {
+		t.Fatalf("IndexArtifact() error = %v", err)
+	}
+	if err := index.IndexArtifact(t.Context(), artifacts, "photo.png"); err == nil {
+		t.Error("IndexArtifact() of an image succeeded, want error")
+	}
+
+	for query, wantSource := range map[string]string{"tomatoes garden": path, "bank meeting": "notes.md"} {
+		matches, err := index.Search(t.Context(), query, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(matches) != 1 || matches[0].Source != wantSource {
+			t.Errorf("Search(%q) = %v, want a chunk of %s", query, matches, wantSource)
+		}
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	store, err := retrievaltool.NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newIndex(t, store)
+
+	// The chunks are loaded from the file by a new store.
+	reopened, err := retrievaltool.NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	embedder := &fakeEmbedder{}
+	index, err := retrievaltool.NewIndex(retrievaltool.IndexConfig{Embedder: embedder, Store: reopened})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matches, err := index.Search(t.Context(), "spring weather in Paris", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Source != "weather.txt" {
+		t.Errorf("Search() = %v, want the chunk of weather.txt", matches)
+	}
+	if embedder.calls != 1 {
+		t.Errorf("embedder called %d times, want 1 for the query only", embedder.calls)
+	}
+
+	if err := reopened.DeleteSource(t.Context(), "weather.txt"); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err = retrievaltool.NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := reopened.Search(t.Context(), make([]float32, fakeDimensions), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sources []string
+	for _, m := range all {
+		sources = append(sources, m.Source)
+	}
+	if diff := cmp.Diff([]string{"cooking.txt", "pets.txt"}, sources); diff != "" {
+		t.Errorf("sources after delete mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestIndex_EmbedError(t *testing.T) {
+	embedder := &fakeEmbedder{}
+	index, err := retrievaltool.NewIndex(retrievaltool.IndexConfig{Embedder: embedder})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := index.IndexText(t.Context(), "pets.txt", documents["pets.txt"]); err != nil {
+		t.Fatal(err)
+	}
+
+	embedder.err = errors.New("quota exceeded")
+	if err := index.IndexText(t.Context(), "pets.txt", "Birds sing in the morning."); err == nil {
+		t.Fatal("IndexText() succeeded, want error")
+	}
+
+	// The previous chunks are kept when the new ones can't be embedded.
+	embedder.err = nil
+	matches, err := index.Search(t.Context(), "walk the dogs", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Text != documents["pets.txt"] {
+		t.Errorf("Search() = %v, want the previous chunk of pets.txt", matches)
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	if _, err := retrievaltool.New(retrievaltool.Config{}); err == nil {
+		t.Error("New() without index succeeded, want error")
+	}
+	if _, err := retrievaltool.NewIndex(retrievaltool.IndexConfig{}); err == nil {
+		t.Error("NewIndex() without embedder succeeded, want error")
+	}
+	if _, err := retrievaltool.NewIndex(retrievaltool.IndexConfig{Embedder: &fakeEmbedder{}, ChunkSize: 10, ChunkOverlap: 10}); err == nil {
+		t.Error("NewIndex() with overlap as large as chunks succeeded, want error")
+	}
+}
+
+func newToolContext(t *testing.T) tool.Context {
+	t.Helper()
+	return toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}), "", nil)
+}
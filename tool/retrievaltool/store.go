@@ -0,0 +1,158 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retrievaltool
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sync"
+)
+
+// Chunk is a piece of an indexed document.
+type Chunk struct {
+	// ID identifies the chunk in the store.
+	ID string `json:"id"`
+	// Source is the document the chunk was taken from, e.g. a file path or
+	// an artifact name.
+	Source string `json:"source"`
+	// Index is the position of the chunk in the document, from 0.
+	Index int `json:"index"`
+	// Text of the chunk.
+	Text string `json:"text"`
+	// Embedding of the text.
+	Embedding []float32 `json:"embedding"`
+}
+
+// Match is a chunk found by a search, with its cosine similarity to the
+// query.
+type Match struct {
+	Chunk
+	Score float64
+}
+
+// VectorStore stores chunks and finds the ones most similar to a query.
+type VectorStore interface {
+	// ReplaceSource replaces the chunks of the source with the given chunks,
+	// which must all belong to the source. Readers see either the previous
+	// chunks or the new ones, never a mix.
+	ReplaceSource(ctx context.Context, source string, chunks []Chunk) error
+	// DeleteSource deletes the chunks of the source.
+	DeleteSource(ctx context.Context, source string) error
+	// Search returns the k chunks most similar to the embedding, most
+	// similar first.
+	Search(ctx context.Context, embedding []float32, k int) ([]Match, error)
+}
+
+// NewInMemoryStore returns a VectorStore keeping the chunks in memory. It
+// compares the query with every chunk, which is suitable for up to tens of
+// thousands of chunks.
+func NewInMemoryStore() VectorStore {
+	return &inMemoryStore{chunks: make(map[string]Chunk)}
+}
+
+type inMemoryStore struct {
+	mu     sync.RWMutex
+	chunks map[string]Chunk
+}
+
+func (s *inMemoryStore) ReplaceSource(ctx context.Context, source string, chunks []Chunk) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	updated, err := replaceSource(s.chunks, source, chunks)
+	if err != nil {
+		return err
+	}
+	s.chunks = updated
+	return nil
+}
+
+func (s *inMemoryStore) DeleteSource(ctx context.Context, source string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	maps.DeleteFunc(s.chunks, func(_ string, c Chunk) bool { return c.Source == source })
+	return nil
+}
+
+func (s *inMemoryStore) Search(ctx context.Context, embedding []float32, k int) ([]Match, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return search(s.chunks, embedding, k), nil
+}
+
+// replaceSource returns a copy of chunks where the chunks of the source are
+// replaced with the given ones.
+func replaceSource(chunks map[string]Chunk, source string, replacement []Chunk) (map[string]Chunk, error) {
+	updated := maps.Clone(chunks)
+	maps.DeleteFunc(updated, func(_ string, c Chunk) bool { return c.Source == source })
+	for _, c := range replacement {
+		if c.ID == "" {
+			return nil, fmt.Errorf("chunk %d of %q has no ID", c.Index, c.Source)
+		}
+		if c.Source != source {
+			return nil, fmt.Errorf("chunk %q belongs to %q, not %q", c.ID, c.Source, source)
+		}
+		updated[c.ID] = c
+	}
+	return updated, nil
+}
+
+// search compares the embedding with all the chunks and returns the k most
+// similar. Ties are broken by chunk ID to keep results stable.
+func search(chunks map[string]Chunk, embedding []float32, k int) []Match {
+	matches := make([]Match, 0, len(chunks))
+	for _, c := range chunks {
+		matches = append(matches, Match{Chunk: c, Score: cosineSimilarity(embedding, c.Embedding)})
+	}
+	slices.SortFunc(matches, func(a, b Match) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		if a.ID < b.ID {
+			return -1
+		}
+		if a.ID > b.ID {
+			return 1
+		}
+		return 0
+	})
+	if len(matches) > k {
+		matches = matches[:k]
+	}
+	return matches
+}
+
+// cosineSimilarity returns the cosine similarity of a and b, 0 if they have
+// different dimensions or one of them is zero.
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
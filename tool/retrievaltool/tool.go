@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retrievaltool provides a tool answering queries with the most
+// relevant chunks of indexed documents (retrieval-augmented generation).
+//
+// Documents are indexed with an Index, which embeds their chunks with a
+// model.Embedder and keeps them in a VectorStore:
+//
+//	embedder, err := gemini.NewEmbedder(ctx, "gemini-embedding-001", clientConfig)
+//	...
+//	index, err := retrievaltool.NewIndex(retrievaltool.IndexConfig{Embedder: embedder})
+//	...
+//	err = index.IndexFile(ctx, "docs/handbook.md")
+//	...
+//	retrieve, err := retrievaltool.New(retrievaltool.Config{Index: index})
+//	...
+//	llmagent.New(llmagent.Config{
+//		...
+//		Tools: []tool.Tool{retrieve},
+//	})
+package retrievaltool
+
+import (
+	"fmt"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// Config configures the retrieval tool.
+type Config struct {
+	// Name of the tool. Defaults to "retrieve_documents".
+	Name string
+	// Description of the tool. Defaults to a generic description; describe
+	// the indexed documents to help the model decide when to use the tool.
+	Description string
+	// Index holds the documents to search.
+	Index *Index
+	// TopK is the number of chunks returned for a query. Defaults to 5.
+	TopK int
+}
+
+// New creates a tool searching the documents of the index. For a "query"
+// argument, it returns the most relevant chunks, each with the citation of
+// its source, e.g. "docs/handbook.md#3" for the fourth chunk of the file.
+func New(cfg Config) (tool.Tool, error) {
+	if cfg.Index == nil {
+		return nil, fmt.Errorf("index is required")
+	}
+	if cfg.Name == "" {
+		cfg.Name = "retrieve_documents"
+	}
+	if cfg.Description == "" {
+		cfg.Description = "Searches the indexed documents and returns the passages most relevant to the query, with their sources. Cite the sources of the passages used in the answer."
+	}
+	if cfg.TopK <= 0 {
+		cfg.TopK = 5
+	}
+	return &retrievalTool{cfg: cfg}, nil
+}
+
+type retrievalTool struct {
+	cfg Config
+}
+
+// Name implements tool.Tool.
+func (t *retrievalTool) Name() string {
+	return t.cfg.Name
+}
+
+// Description implements tool.Tool.
+func (t *retrievalTool) Description() string {
+	return t.cfg.Description
+}
+
+// IsLongRunning implements tool.Tool.
+func (t *retrievalTool) IsLongRunning() bool {
+	return false
+}
+
+func (t *retrievalTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return toolutils.PackTool(req, t)
+}
+
+func (t *retrievalTool) Declaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{
+		Name:        t.cfg.Name,
+		Description: t.cfg.Description,
+		ParametersJsonSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{
+					"type":        "string",
+					"description": "The question or keywords to search for.",
+				},
+			},
+			"required": []string{"query"},
+		},
+	}
+}
+
+func (t *retrievalTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	margs, ok := args.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected args type, got: %T", args)
+	}
+	query, _ := margs["query"].(string)
+	if query == "" {
+		return nil, fmt.Errorf("missing required argument %q", "query")
+	}
+
+	matches, err := t.cfg.Index.Search(ctx, query, t.cfg.TopK)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]any, 0, len(matches))
+	for _, m := range matches {
+		results = append(results, map[string]any{
+			"text":     m.Text,
+			"source":   m.Source,
+			"citation": m.ID,
+			"score":    m.Score,
+		})
+	}
+	return map[string]any{"results": results}, nil
+}
+
+var (
+	_ toolinternal.FunctionTool     = (*retrievalTool)(nil)
+	_ toolinternal.RequestProcessor = (*retrievalTool)(nil)
+)
@@ -0,0 +1,84 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shelltool
+
+import (
+	"fmt"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool"
+)
+
+// ConfirmationConfig configures the callback created by ConfirmationCallback.
+type ConfirmationConfig struct {
+	// NeedsConfirmation reports whether the command must be approved before
+	// it runs. If nil, all commands must be approved.
+	NeedsConfirmation func(cmd Command) bool
+	// Confirm asks a human whether the command can run. It is required.
+	// Returning an error fails the tool call.
+	Confirm func(ctx tool.Context, cmd Command) (bool, error)
+}
+
+// ConfirmationCallback returns a callback asking for the approval of the
+// commands of the shell tools created with New before they run. Commands
+// which are rejected don't run, and the model is told that the user
+// rejected them. Other tools are not affected. The shell tools are found
+// through the wrappers of the ADK, e.g. functiontool.WithLimits and
+// tool.PrefixToolset.
+//
+// Example:
+//
+//	llmagent.New(llmagent.Config{
+//		...
+//		Tools: []tool.Tool{shellTool},
+//		BeforeToolCallbacks: []llmagent.BeforeToolCallback{
+//			shelltool.ConfirmationCallback(shelltool.ConfirmationConfig{
+//				NeedsConfirmation: func(cmd shelltool.Command) bool {
+//					return cmd.Executable != "ls"
+//				},
+//				Confirm: askOnConsole,
+//			}),
+//		},
+//	})
+func ConfirmationCallback(cfg ConfirmationConfig) llmagent.BeforeToolCallback {
+	return func(ctx tool.Context, t tool.Tool, args map[string]any) (map[string]any, error) {
+		// Find the shell tool wrapped e.g. by functiontool.WithLimits or
+		// tool.PrefixToolset.
+		st, ok := toolinternal.Unwrap(t).(*shellTool)
+		if !ok {
+			return nil, nil
+		}
+		cmd, err := st.parseCommand(args)
+		if err != nil {
+			// Let the tool report the invalid command.
+			return nil, nil
+		}
+		if cfg.NeedsConfirmation != nil && !cfg.NeedsConfirmation(cmd) {
+			return nil, nil
+		}
+		if cfg.Confirm == nil {
+			return nil, fmt.Errorf("command %q requires confirmation but no confirmation function is configured", cmd.Executable)
+		}
+		approved, err := cfg.Confirm(ctx, cmd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to confirm command %q: %w", cmd.Executable, err)
+		}
+		if !approved {
+			return map[string]any{"error": fmt.Sprintf("the user rejected the command %q", cmd.String())}, nil
+		}
+		return nil, nil
+	}
+}
@@ -0,0 +1,369 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package shelltool provides a tool running commands.
+//
+// Commands are run directly, without a shell: the model provides the
+// executable and its arguments, and the executable must be allowed by the
+// allowlist and denylist of the tool. Use ConfirmationCallback to let a
+// human approve the commands before they run.
+package shelltool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// Config provides initial configuration for the shell tool.
+type Config struct {
+	// Name of the tool. Defaults to "run_command".
+	Name string
+	// Description of the tool. Defaults to a description listing the
+	// allowed commands.
+	Description string
+
+	// Dir is the working directory of the commands. Defaults to the current
+	// directory. The model may run commands in subdirectories of Dir.
+	Dir string
+
+	// AllowedCommands lists the executables which can be run. If empty, all
+	// executables which are not denied can be run.
+	AllowedCommands []string
+	// DeniedCommands lists the executables which can't be run. At least one
+	// of AllowedCommands and DeniedCommands must be set.
+	//
+	// Executables are matched by name, e.g. "git", or by the exact path given
+	// by the model. Denied executables are also matched by the last element
+	// of their path, so that "/bin/rm" is denied by "rm".
+	//
+	// DeniedCommands is not a security boundary: allowed executables can run
+	// denied ones, e.g. "sh -c rm", "env rm" or "find -delete". Use
+	// AllowedCommands, listing executables which can't run other programs,
+	// to restrict what the model can do.
+	DeniedCommands []string
+
+	// Timeout of a command. The command is also stopped when the invocation
+	// is cancelled. Defaults to 30 seconds.
+	Timeout time.Duration
+
+	// InheritEnv lists the environment variables of the current process
+	// passed to the commands. Defaults to PATH, HOME, USER, LANG, LC_ALL,
+	// TMPDIR and TZ. Other variables, e.g. holding credentials, are not
+	// visible to the commands.
+	InheritEnv []string
+	// Env holds additional environment variables of the commands.
+	Env map[string]string
+
+	// MaxOutputSize is the maximum number of bytes of stdout and stderr
+	// returned to the model, each. Defaults to 64 KiB.
+	MaxOutputSize int
+}
+
+const (
+	defaultName          = "run_command"
+	defaultTimeout       = 30 * time.Second
+	defaultMaxOutputSize = 64 << 10
+	// waitDelay bounds the time spent waiting for the output of the
+	// processes started by a command which was stopped.
+	waitDelay = time.Second
+)
+
+var defaultInheritEnv = []string{"PATH", "HOME", "USER", "LANG", "LC_ALL", "TMPDIR", "TZ"}
+
+// Command is a command the model asked to run.
+type Command struct {
+	// Executable is the name or path of the executable.
+	Executable string
+	// Args are the arguments of the executable.
+	Args []string
+	// Dir is the working directory, relative to Config.Dir.
+	Dir string
+}
+
+// String returns the command line of the command, for display.
+func (c Command) String() string {
+	return strings.Join(append([]string{c.Executable}, c.Args...), " ")
+}
+
+// shellTool runs the commands requested by the model.
+type shellTool struct {
+	name          string
+	description   string
+	dir           string
+	allowed       []string
+	denied        []string
+	timeout       time.Duration
+	env           []string
+	maxOutputSize int
+}
+
+// New creates a shell tool.
+//
+// The tool returns the stdout, stderr and exit code of the command. A non
+// zero exit code is not an error: the model can react to it. If the command
+// times out, "timed_out" is set in the result.
+//
+// Example:
+//
+//	shellTool, err := shelltool.New(shelltool.Config{
+//		Dir:             "/path/to/workspace",
+//		AllowedCommands: []string{"go", "git", "ls"},
+//		Timeout:         time.Minute,
+//	})
+func New(cfg Config) (tool.Tool, error) {
+	if len(cfg.AllowedCommands) == 0 && len(cfg.DeniedCommands) == 0 {
+		return nil, errors.New("at least one of AllowedCommands and DeniedCommands must be set")
+	}
+	t := &shellTool{
+		name:          cfg.Name,
+		description:   cfg.Description,
+		dir:           cfg.Dir,
+		allowed:       cfg.AllowedCommands,
+		denied:        cfg.DeniedCommands,
+		timeout:       cfg.Timeout,
+		maxOutputSize: cfg.MaxOutputSize,
+		// An empty environment, not nil which means the environment of the
+		// current process.
+		env: []string{},
+	}
+	if t.name == "" {
+		t.name = defaultName
+	}
+	if t.dir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the current directory: %w", err)
+		}
+		t.dir = wd
+	}
+	if info, err := os.Stat(t.dir); err != nil {
+		return nil, fmt.Errorf("invalid working directory: %w", err)
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("working directory %q is not a directory", t.dir)
+	}
+	if t.timeout <= 0 {
+		t.timeout = defaultTimeout
+	}
+	if t.maxOutputSize <= 0 {
+		t.maxOutputSize = defaultMaxOutputSize
+	}
+
+	inherit := cfg.InheritEnv
+	if inherit == nil {
+		inherit = defaultInheritEnv
+	}
+	for _, name := range inherit {
+		if v, ok := os.LookupEnv(name); ok && cfg.Env[name] == "" {
+			t.env = append(t.env, name+"="+v)
+		}
+	}
+	for name, v := range cfg.Env {
+		t.env = append(t.env, name+"="+v)
+	}
+	slices.Sort(t.env)
+
+	if t.description == "" {
+		t.description = "Runs a command and returns its stdout, stderr and exit code. The command is not run by a shell: pipes, redirections and variables are not supported."
+		if len(t.allowed) > 0 {
+			t.description += " Allowed commands: " + strings.Join(t.allowed, ", ") + "."
+		}
+	}
+	return t, nil
+}
+
+// Name implements tool.Tool.
+func (t *shellTool) Name() string {
+	return t.name
+}
+
+// Description implements tool.Tool.
+func (t *shellTool) Description() string {
+	return t.description
+}
+
+// IsLongRunning implements tool.Tool.
+func (t *shellTool) IsLongRunning() bool {
+	return false
+}
+
+func (t *shellTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return toolutils.PackTool(req, t)
+}
+
+func (t *shellTool) Declaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{
+		Name:        t.name,
+		Description: t.description,
+		ParametersJsonSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"command": map[string]any{
+					"type":        "string",
+					"description": "The executable to run, e.g. \"ls\".",
+				},
+				"args": map[string]any{
+					"type":        "array",
+					"items":       map[string]any{"type": "string"},
+					"description": "The arguments of the executable, e.g. [\"-l\", \"src\"].",
+				},
+				"dir": map[string]any{
+					"type":        "string",
+					"description": "The directory to run the command in, relative to the working directory. Defaults to the working directory.",
+				},
+			},
+			"required": []string{"command"},
+		},
+	}
+}
+
+// Run runs the command and returns its output.
+func (t *shellTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	cmd, err := t.parseCommand(args)
+	if err != nil {
+		return nil, err
+	}
+	return t.run(ctx, cmd)
+}
+
+// parseCommand returns the command requested by the model, and checks that
+// it can be run.
+func (t *shellTool) parseCommand(args any) (Command, error) {
+	margs, ok := args.(map[string]any)
+	if !ok {
+		return Command{}, fmt.Errorf("unexpected args type, got: %T", args)
+	}
+	var cmd Command
+	cmd.Executable, _ = margs["command"].(string)
+	if cmd.Executable == "" {
+		return Command{}, errors.New("missing required argument \"command\"")
+	}
+	if rawArgs, ok := margs["args"]; ok && rawArgs != nil {
+		list, ok := rawArgs.([]any)
+		if !ok {
+			return Command{}, fmt.Errorf("argument \"args\" must be an array of strings, got: %T", rawArgs)
+		}
+		for _, a := range list {
+			s, ok := a.(string)
+			if !ok {
+				return Command{}, fmt.Errorf("argument \"args\" must be an array of strings, got an item of type %T", a)
+			}
+			cmd.Args = append(cmd.Args, s)
+		}
+	}
+	if dir, ok := margs["dir"].(string); ok && dir != "" {
+		if !filepath.IsLocal(filepath.FromSlash(dir)) {
+			return Command{}, fmt.Errorf("directory %q is outside of the working directory", dir)
+		}
+		cmd.Dir = dir
+	}
+	if err := t.check(cmd.Executable); err != nil {
+		return Command{}, err
+	}
+	return cmd, nil
+}
+
+// check returns an error if the executable can't be run.
+func (t *shellTool) check(executable string) error {
+	if slices.Contains(t.denied, executable) || slices.Contains(t.denied, filepath.Base(executable)) {
+		return fmt.Errorf("command %q is not allowed", executable)
+	}
+	if len(t.allowed) > 0 && !slices.Contains(t.allowed, executable) {
+		return fmt.Errorf("command %q is not allowed, allowed commands are: %s", executable, strings.Join(t.allowed, ", "))
+	}
+	return nil
+}
+
+func (t *shellTool) run(ctx context.Context, c Command) (map[string]any, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, c.Executable, c.Args...)
+	cmd.Dir = filepath.Join(t.dir, filepath.FromSlash(c.Dir))
+	cmd.Env = t.env
+	cmd.WaitDelay = waitDelay
+	stdout := &limitedBuffer{max: t.maxOutputSize}
+	stderr := &limitedBuffer{max: t.maxOutputSize}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	result := map[string]any{
+		"stdout": stdout.String(),
+		"stderr": stderr.String(),
+	}
+	if stdout.truncated {
+		result["stdout_truncated"] = true
+	}
+	if stderr.truncated {
+		result["stderr_truncated"] = true
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			result["timed_out"] = true
+			result["exit_code"] = -1
+			return result, nil
+		}
+		return nil, fmt.Errorf("command %q was cancelled: %w", c.Executable, ctx.Err())
+	case errors.As(err, &exitErr):
+		result["exit_code"] = exitErr.ExitCode()
+	case err != nil:
+		return nil, fmt.Errorf("failed to run command %q: %w", c.Executable, err)
+	default:
+		result["exit_code"] = 0
+	}
+	return result, nil
+}
+
+// limitedBuffer keeps the first max bytes written to it.
+type limitedBuffer struct {
+	buf       []byte
+	max       int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if n := b.max - len(b.buf); n < len(p) {
+		b.buf = append(b.buf, p[:max(n, 0)]...)
+		b.truncated = true
+	} else {
+		b.buf = append(b.buf, p...)
+	}
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	return strings.ToValidUTF8(string(b.buf), "�")
+}
+
+var (
+	_ toolinternal.FunctionTool     = (*shellTool)(nil)
+	_ toolinternal.RequestProcessor = (*shellTool)(nil)
+)
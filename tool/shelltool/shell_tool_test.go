@@ -0,0 +1,272 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shelltool_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+	"google.golang.org/adk/tool/loadmemorytool"
+	"google.golang.org/adk/tool/shelltool"
+)
+
+func newTool(t *testing.T, cfg shelltool.Config) toolinternal.FunctionTool {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the tests use Unix commands")
+	}
+	st, err := shelltool.New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return st.(toolinternal.FunctionTool)
+}
+
+func createToolContext(t *testing.T) tool.Context {
+	t.Helper()
+	return toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}), "", nil)
+}
+
+func TestNew(t *testing.T) {
+	for _, cfg := range []shelltool.Config{
+		{},
+		{AllowedCommands: []string{"ls"}, Dir: filepath.Join(t.TempDir(), "missing")},
+	} {
+		if _, err := shelltool.New(cfg); err == nil {
+			t.Errorf("New(%+v) succeeded, want error", cfg)
+		}
+	}
+}
+
+func TestShellTool_Run(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SHELLTOOL_SECRET", "secret")
+	st := newTool(t, shelltool.Config{
+		Dir:             dir,
+		AllowedCommands: []string{"echo", "sh", "pwd"},
+		Env:             map[string]string{"GREETING": "hi"},
+		MaxOutputSize:   16,
+	})
+
+	tests := []struct {
+		name string
+		args map[string]any
+		want map[string]any
+	}{
+		{
+			name: "stdout",
+			args: map[string]any{"command": "echo", "args": []any{"hello", "world"}},
+			want: map[string]any{"stdout": "hello world\n", "stderr": "", "exit_code": 0},
+		},
+		{
+			name: "stderr and exit code",
+			args: map[string]any{"command": "sh", "args": []any{"-c", "echo oops >&2; exit 3"}},
+			want: map[string]any{"stdout": "", "stderr": "oops\n", "exit_code": 3},
+		},
+		{
+			name: "truncated",
+			args: map[string]any{"command": "echo", "args": []any{"0123456789abcdefghij"}},
+			want: map[string]any{"stdout": "0123456789abcdef", "stderr": "", "exit_code": 0, "stdout_truncated": true},
+		},
+		{
+			name: "environment",
+			args: map[string]any{"command": "sh", "args": []any{"-c", "echo $GREETING$SHELLTOOL_SECRET"}},
+			want: map[string]any{"stdout": "hi\n", "stderr": "", "exit_code": 0},
+		},
+		{
+			name: "subdirectory",
+			args: map[string]any{"command": "sh", "args": []any{"-c", "basename $(pwd)"}, "dir": "sub"},
+			want: map[string]any{"stdout": "sub\n", "stderr": "", "exit_code": 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.Run(createToolContext(t), tt.args)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestShellTool_Timeout(t *testing.T) {
+	st := newTool(t, shelltool.Config{AllowedCommands: []string{"sleep"}, Timeout: 100 * time.Millisecond})
+
+	start := time.Now()
+	got, err := st.Run(createToolContext(t), map[string]any{"command": "sleep", "args": []any{"10"}})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Run() took %v, want the command to be stopped", elapsed)
+	}
+	if got["timed_out"] != true || got["exit_code"] != -1 {
+		t.Errorf("Run() = %v, want timed_out and exit code -1", got)
+	}
+}
+
+func TestShellTool_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  shelltool.Config
+		args map[string]any
+	}{
+		{
+			name: "not allowed",
+			cfg:  shelltool.Config{AllowedCommands: []string{"echo"}},
+			args: map[string]any{"command": "rm", "args": []any{"-rf", "/"}},
+		},
+		{
+			name: "denied",
+			cfg:  shelltool.Config{DeniedCommands: []string{"rm"}},
+			args: map[string]any{"command": "rm"},
+		},
+		{
+			name: "denied by path",
+			cfg:  shelltool.Config{DeniedCommands: []string{"rm"}},
+			args: map[string]any{"command": "/bin/rm"},
+		},
+		{
+			name: "allowed and denied",
+			cfg:  shelltool.Config{AllowedCommands: []string{"echo"}, DeniedCommands: []string{"echo"}},
+			args: map[string]any{"command": "echo"},
+		},
+		{
+			name: "directory outside",
+			cfg:  shelltool.Config{AllowedCommands: []string{"ls"}},
+			args: map[string]any{"command": "ls", "dir": "../"},
+		},
+		{
+			name: "missing command",
+			cfg:  shelltool.Config{AllowedCommands: []string{"ls"}},
+			args: map[string]any{},
+		},
+		{
+			name: "invalid args",
+			cfg:  shelltool.Config{AllowedCommands: []string{"ls"}},
+			args: map[string]any{"command": "ls", "args": "-l"},
+		},
+		{
+			name: "not found",
+			cfg:  shelltool.Config{DeniedCommands: []string{"rm"}},
+			args: map[string]any{"command": "shelltool-missing-command"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newTool(t, tt.cfg)
+			if got, err := st.Run(createToolContext(t), tt.args); err == nil {
+				t.Errorf("Run() = %v, want error", got)
+			}
+		})
+	}
+}
+
+func TestConfirmationCallback(t *testing.T) {
+	st := newTool(t, shelltool.Config{AllowedCommands: []string{"echo", "touch"}})
+	var asked []string
+	callback := shelltool.ConfirmationCallback(shelltool.ConfirmationConfig{
+		NeedsConfirmation: func(cmd shelltool.Command) bool {
+			return cmd.Executable != "echo"
+		},
+		Confirm: func(ctx tool.Context, cmd shelltool.Command) (bool, error) {
+			asked = append(asked, cmd.String())
+			return strings.Contains(cmd.String(), "approved"), nil
+		},
+	})
+	limited, err := functiontool.WithLimits(st, functiontool.Limits{Timeout: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefixed, err := tool.PrefixToolset(tool.NewToolset("shell", limited), "sandbox_").Tools(createToolContext(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		tool      tool.Tool
+		args      map[string]any
+		want      map[string]any
+		wantAsked []string
+	}{
+		{
+			name: "no confirmation needed",
+			tool: st,
+			args: map[string]any{"command": "echo", "args": []any{"hi"}},
+		},
+		{
+			name:      "approved",
+			tool:      st,
+			args:      map[string]any{"command": "touch", "args": []any{"approved"}},
+			wantAsked: []string{"touch approved"},
+		},
+		{
+			name:      "rejected",
+			tool:      st,
+			args:      map[string]any{"command": "touch", "args": []any{"file"}},
+			want:      map[string]any{"error": "the user rejected the command \"touch file\""},
+			wantAsked: []string{"touch file"},
+		},
+		{
+			name:      "rejected through wrappers",
+			tool:      prefixed[0],
+			args:      map[string]any{"command": "touch", "args": []any{"file"}},
+			want:      map[string]any{"error": "the user rejected the command \"touch file\""},
+			wantAsked: []string{"touch file"},
+		},
+		{
+			name: "invalid command",
+			tool: st,
+			args: map[string]any{"command": "rm"},
+		},
+		{
+			name: "other tool",
+			tool: loadmemorytool.New(),
+			args: map[string]any{"command": "touch"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asked = nil
+			got, err := callback(createToolContext(t), tt.tool, tt.args)
+			if err != nil {
+				t.Fatalf("callback error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("callback result mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantAsked, asked); diff != "" {
+				t.Errorf("confirmations mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltoolset
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/tool"
+)
+
+// queryKeywords are the first keywords of the statements returning rows
+// without modifying the database.
+var queryKeywords = []string{"SELECT", "WITH", "VALUES", "TABLE", "EXPLAIN", "SHOW", "DESCRIBE", "DESC"}
+
+// writeKeywords are the keywords which make a statement starting with a
+// query keyword modify the database, e.g. data-modifying common table
+// expressions, SELECT INTO or EXPLAIN ANALYZE DELETE.
+var writeKeywords = []string{"INSERT", "UPDATE", "DELETE", "MERGE", "INTO"}
+
+func (s *set) runSQL(ctx context.Context, toolCtx tool.Context, args map[string]any) (map[string]any, error) {
+	query, _ := args["query"].(string)
+	if strings.TrimSpace(query) == "" {
+		return nil, errors.New("missing required argument \"query\"")
+	}
+	keywords, err := scanStatement(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(keywords) == 0 {
+		return nil, errors.New("query is empty")
+	}
+
+	isQuery := slices.Contains(queryKeywords, keywords[0]) && !slices.ContainsFunc(keywords, func(k string) bool {
+		return slices.Contains(writeKeywords, k)
+	})
+	if !isQuery {
+		if !s.allowWrites {
+			return nil, errors.New("the database is read-only, only SELECT queries are allowed")
+		}
+		res := s.db.WithContext(ctx).Exec(query)
+		if res.Error != nil {
+			return nil, fmt.Errorf("failed to run statement: %w", res.Error)
+		}
+		return map[string]any{"rows_affected": res.RowsAffected}, nil
+	}
+
+	db := s.db.WithContext(ctx)
+	if !s.allowWrites {
+		// The keywords are checked to reject the statements modifying the
+		// database early, the read-only transaction which is rolled back
+		// enforces it.
+		db = db.Begin(&sql.TxOptions{ReadOnly: true})
+		if db.Error != nil {
+			return nil, fmt.Errorf("failed to begin transaction: %w", db.Error)
+		}
+		defer db.Rollback()
+	}
+	rows, err := db.Raw(query).Rows()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run query: %w", err)
+	}
+	defer rows.Close()
+	return s.readRows(ctx, toolCtx, rows)
+}
+
+// readRows returns at most maxRows rows to the model. If the result has
+// more rows and large results are saved, up to maxArtifactRows rows are
+// saved in a CSV artifact.
+func (s *set) readRows(ctx context.Context, toolCtx tool.Context, rows *sql.Rows) (map[string]any, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read result: %w", err)
+	}
+
+	var csvData bytes.Buffer
+	csvWriter := csv.NewWriter(&csvData)
+	if s.saveLargeResults {
+		if err := csvWriter.Write(columns); err != nil {
+			return nil, fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	resultRows := [][]any{}
+	count, truncated, artifactTruncated := 0, false, false
+	for rows.Next() {
+		// Not all drivers stop reading the rows when the context is done.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("failed to read row: %w", err)
+		}
+		for i, v := range values {
+			values[i] = jsonValue(v)
+		}
+
+		count++
+		if count <= s.maxRows {
+			resultRows = append(resultRows, values)
+		} else {
+			truncated = true
+			if !s.saveLargeResults {
+				break
+			}
+		}
+		if s.saveLargeResults {
+			if count > s.maxArtifactRows {
+				artifactTruncated = true
+				break
+			}
+			if err := csvWriter.Write(csvRecord(values)); err != nil {
+				return nil, fmt.Errorf("failed to write CSV: %w", err)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read result: %w", err)
+	}
+
+	result := map[string]any{
+		"columns":   columns,
+		"rows":      resultRows,
+		"row_count": len(resultRows),
+	}
+	if !truncated {
+		return result, nil
+	}
+	result["truncated"] = true
+	if !s.saveLargeResults {
+		return result, nil
+	}
+
+	if toolCtx.Artifacts() == nil {
+		return nil, fmt.Errorf("the result has more than %d rows but the artifact service is not configured to save it", s.maxRows)
+	}
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	name := "query_result_" + strings.ReplaceAll(uuid.NewString(), "-", "")[:12] + ".csv"
+	resp, err := toolCtx.Artifacts().Save(toolCtx, name, genai.NewPartFromBytes(csvData.Bytes(), "text/csv"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save result as artifact: %w", err)
+	}
+	result["artifact"] = name
+	result["artifact_version"] = resp.Version
+	result["artifact_row_count"] = min(count, s.maxArtifactRows)
+	if artifactTruncated {
+		result["artifact_truncated"] = true
+	}
+	return result, nil
+}
+
+// jsonValue converts a value scanned from a row to a JSON value.
+func jsonValue(v any) any {
+	switch v := v.(type) {
+	case []byte:
+		return strings.ToValidUTF8(string(v), "�")
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return v
+	}
+}
+
+func csvRecord(values []any) []string {
+	record := make([]string, len(values))
+	for i, v := range values {
+		if v != nil {
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return record
+}
+
+// scanStatement returns the upper-cased keywords and identifiers of the SQL
+// statement, skipping comments, string literals and quoted identifiers. It
+// returns an error if the query has several statements.
+func scanStatement(query string) ([]string, error) {
+	var words []string
+	ended := false
+	r := []rune(query)
+	for i := 0; i < len(r); i++ {
+		c := r[i]
+		switch {
+		case unicode.IsSpace(c):
+			continue
+		case c == '-' && i+1 < len(r) && r[i+1] == '-':
+			for i < len(r) && r[i] != '\n' {
+				i++
+			}
+			continue
+		case c == '/' && i+1 < len(r) && r[i+1] == '*':
+			for i += 2; i+1 < len(r) && (r[i] != '*' || r[i+1] != '/'); i++ {
+			}
+			if i+1 >= len(r) {
+				return nil, errors.New("unterminated comment")
+			}
+			i++
+			continue
+		case c == ';':
+			ended = true
+			continue
+		}
+
+		if ended {
+			return nil, errors.New("only a single statement is allowed")
+		}
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			// Quotes are escaped by doubling them.
+			i++
+			for ; i < len(r); i++ {
+				if r[i] == c {
+					if i+1 < len(r) && r[i+1] == c {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			if i == len(r) {
+				return nil, errors.New("unterminated quoted string")
+			}
+		case c == '_' || unicode.IsLetter(c):
+			start := i
+			for i+1 < len(r) && (r[i+1] == '_' || unicode.IsLetter(r[i+1]) || unicode.IsDigit(r[i+1])) {
+				i++
+			}
+			words = append(words, strings.ToUpper(string(r[start:i+1])))
+		}
+	}
+	return words, nil
+}
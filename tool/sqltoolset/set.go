@@ -0,0 +1,151 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqltoolset provides a tool set giving agents access to a SQL
+// database through gorm.
+//
+// By default the tool set is read-only: only queries are allowed, and they
+// run in a read-only transaction which is rolled back.
+package sqltoolset
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/tool"
+)
+
+// Config provides initial configuration for the SQL ToolSet.
+type Config struct {
+	// Dialector of the database, e.g. postgres.Open(dsn).
+	Dialector gorm.Dialector
+	// GormOptions are passed to gorm.Open.
+	GormOptions []gorm.Option
+
+	// AllowWrites allows run_sql to run statements modifying the database
+	// or its schema. By default only queries are allowed.
+	AllowWrites bool
+	// MaxRows is the maximum number of rows returned to the model. Defaults
+	// to 100.
+	MaxRows int
+	// Timeout of a statement. Defaults to 30 seconds. Whether a running
+	// statement is interrupted depends on the driver, the reading of the
+	// rows is always stopped.
+	Timeout time.Duration
+
+	// SaveLargeResults saves the results having more than MaxRows rows as
+	// CSV artifacts, so that they can be downloaded or processed by other
+	// tools. The artifact service must be configured.
+	SaveLargeResults bool
+	// MaxArtifactRows is the maximum number of rows saved in an artifact.
+	// Defaults to 100000.
+	MaxArtifactRows int
+
+	// ToolFilter selects tools for which tool.Predicate returns true.
+	// If ToolFilter is nil, then all tools are returned.
+	// tool.StringPredicate can be convenient if there's a known fixed list of tool names.
+	ToolFilter tool.Predicate
+}
+
+const (
+	defaultMaxRows         = 100
+	defaultTimeout         = 30 * time.Second
+	defaultMaxArtifactRows = 100000
+)
+
+// New returns SQL ToolSet.
+// It provides the tools list_tables, describe_table and run_sql. Query
+// results are returned as columns and rows:
+//
+//	{"columns": ["id", "name"], "rows": [[1, "Alice"], [2, "Bob"]], "row_count": 2}
+//
+// Example:
+//
+//	sqlToolSet, err := sqltoolset.New(sqltoolset.Config{
+//		Dialector: postgres.Open(dsn),
+//		MaxRows:   50,
+//	})
+//	...
+//	llmagent.New(llmagent.Config{
+//		Name:        "agent_name",
+//		Model:       model,
+//		Description: "...",
+//		Instruction: "...",
+//		Toolsets:    []tool.Toolset{sqlToolSet},
+//	})
+func New(cfg Config) (tool.Toolset, error) {
+	if cfg.Dialector == nil {
+		return nil, errors.New("dialector is required")
+	}
+	db, err := gorm.Open(cfg.Dialector, cfg.GormOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	s := &set{
+		db:               db,
+		allowWrites:      cfg.AllowWrites,
+		maxRows:          cfg.MaxRows,
+		timeout:          cfg.Timeout,
+		saveLargeResults: cfg.SaveLargeResults,
+		maxArtifactRows:  cfg.MaxArtifactRows,
+		toolFilter:       cfg.ToolFilter,
+	}
+	if s.maxRows <= 0 {
+		s.maxRows = defaultMaxRows
+	}
+	if s.timeout <= 0 {
+		s.timeout = defaultTimeout
+	}
+	if s.maxArtifactRows <= 0 {
+		s.maxArtifactRows = defaultMaxArtifactRows
+	}
+	s.tools = []*sqlTool{
+		newListTablesTool(s),
+		newDescribeTableTool(s),
+		newRunSQLTool(s),
+	}
+	return s, nil
+}
+
+type set struct {
+	db               *gorm.DB
+	allowWrites      bool
+	maxRows          int
+	timeout          time.Duration
+	saveLargeResults bool
+	maxArtifactRows  int
+	toolFilter       tool.Predicate
+	tools            []*sqlTool
+}
+
+func (*set) Name() string {
+	return "sql_tool_set"
+}
+
+// Tools returns the tools selected by the filter.
+func (s *set) Tools(ctx agent.ReadonlyContext) ([]tool.Tool, error) {
+	var tools []tool.Tool
+	for _, t := range s.tools {
+		if s.toolFilter != nil && !s.toolFilter(ctx, t) {
+			continue
+		}
+		tools = append(tools, t)
+	}
+	return tools, nil
+}
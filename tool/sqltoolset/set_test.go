@@ -0,0 +1,299 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltoolset_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"google.golang.org/adk/artifact"
+	artifactinternal "google.golang.org/adk/internal/artifact"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/sqltoolset"
+)
+
+var gormConfig = &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)}
+
+// newDatabase creates a database with a users table of 5 rows.
+func newDatabase(t *testing.T) gorm.Dialector {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.db")
+	db, err := gorm.Open(sqlite.Open(path), gormConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL, age INTEGER DEFAULT 18)",
+		"INSERT INTO users (id, name, age) VALUES (1, 'Alice', 30), (2, 'Bob', NULL), (3, 'Carol', 25), (4, 'Dan', 41), (5, 'Eve, \"the spy\"', 22)",
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sqlDB.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return sqlite.Open(path)
+}
+
+func loadTools(t *testing.T, cfg sqltoolset.Config) map[string]toolinternal.FunctionTool {
+	t.Helper()
+	cfg.GormOptions = append(cfg.GormOptions, gormConfig)
+	ts, err := sqltoolset.New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	tools, err := ts.Tools(icontext.NewReadonlyContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{})))
+	if err != nil {
+		t.Fatalf("Tools() error = %v", err)
+	}
+	m := make(map[string]toolinternal.FunctionTool)
+	for _, tl := range tools {
+		m[tl.Name()] = tl.(toolinternal.FunctionTool)
+	}
+	return m
+}
+
+func createToolContext(t *testing.T, artifacts artifact.Service) tool.Context {
+	t.Helper()
+	params := icontext.InvocationContextParams{}
+	if artifacts != nil {
+		params.Artifacts = &artifactinternal.Artifacts{
+			Service:   artifacts,
+			AppName:   "app",
+			UserID:    "user",
+			SessionID: "session",
+		}
+	}
+	return toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), params), "", nil)
+}
+
+func runSQL(t *testing.T, tools map[string]toolinternal.FunctionTool, query string) (map[string]any, error) {
+	t.Helper()
+	return tools["run_sql"].Run(createToolContext(t, nil), map[string]any{"query": query})
+}
+
+func TestTools_Run(t *testing.T) {
+	tools := loadTools(t, sqltoolset.Config{Dialector: newDatabase(t), MaxRows: 3})
+
+	tests := []struct {
+		name string
+		tool string
+		args map[string]any
+		want map[string]any
+	}{
+		{
+			name: "list tables",
+			tool: "list_tables",
+			args: map[string]any{},
+			want: map[string]any{"tables": []string{"users"}},
+		},
+		{
+			name: "describe table",
+			tool: "describe_table",
+			args: map[string]any{"table": "users"},
+			want: map[string]any{
+				"table": "users",
+				"columns": []any{
+					map[string]any{"name": "id", "type": "INTEGER", "primary_key": true},
+					map[string]any{"name": "name", "type": "TEXT"},
+					map[string]any{"name": "age", "type": "INTEGER", "default": "18"},
+				},
+			},
+		},
+		{
+			name: "query",
+			tool: "run_sql",
+			args: map[string]any{"query": "SELECT id, name, age FROM users WHERE id <= 2 ORDER BY id"},
+			want: map[string]any{
+				"columns":   []string{"id", "name", "age"},
+				"rows":      [][]any{{int64(1), "Alice", int64(30)}, {int64(2), "Bob", nil}},
+				"row_count": 2,
+			},
+		},
+		{
+			name: "max rows",
+			tool: "run_sql",
+			args: map[string]any{"query": "select name from users order by id -- all users; drop table users"},
+			want: map[string]any{
+				"columns":   []string{"name"},
+				"rows":      [][]any{{"Alice"}, {"Bob"}, {"Carol"}},
+				"row_count": 3,
+				"truncated": true,
+			},
+		},
+		{
+			name: "keywords in literals",
+			tool: "run_sql",
+			args: map[string]any{"query": "WITH x AS (SELECT 'DELETE; DROP' AS \"insert\") SELECT * FROM x;"},
+			want: map[string]any{
+				"columns":   []string{"insert"},
+				"rows":      [][]any{{"DELETE; DROP"}},
+				"row_count": 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tools[tt.tool].Run(createToolContext(t, nil), tt.args)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			// The nullability reported by the SQLite driver is unreliable.
+			ignoreNullable := cmpopts.IgnoreMapEntries(func(k string, v any) bool { return k == "nullable" })
+			if diff := cmp.Diff(tt.want, got, ignoreNullable); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	tools := loadTools(t, sqltoolset.Config{Dialector: newDatabase(t)})
+
+	for _, query := range []string{
+		"DELETE FROM users",
+		"UPDATE users SET age = 0",
+		"INSERT INTO users (name) VALUES ('Mallory')",
+		"DROP TABLE users",
+		"CREATE TABLE other (id INTEGER)",
+		"PRAGMA user_version = 3",
+		"WITH x AS (SELECT 1) DELETE FROM users",
+		"SELECT 1; DELETE FROM users",
+		"SELECT 1 /* comment */; DELETE FROM users",
+		"SELECT * INTO backup FROM users",
+		"SELECT 'unterminated",
+		"",
+	} {
+		if got, err := runSQL(t, tools, query); err == nil {
+			t.Errorf("run_sql(%q) = %v, want error", query, got)
+		}
+	}
+
+	got, err := runSQL(t, tools, "SELECT count(*) AS n, sum(age) AS total FROM users")
+	if err != nil {
+		t.Fatalf("run_sql error = %v", err)
+	}
+	if diff := cmp.Diff([][]any{{int64(5), int64(118)}}, got["rows"]); diff != "" {
+		t.Errorf("the database was modified (-want +got):\n%s", diff)
+	}
+	if _, err := tools["describe_table"].Run(createToolContext(t, nil), map[string]any{"table": "missing"}); err == nil {
+		t.Error("describe_table of a missing table succeeded, want error")
+	}
+}
+
+func TestAllowWrites(t *testing.T) {
+	tools := loadTools(t, sqltoolset.Config{Dialector: newDatabase(t), AllowWrites: true})
+
+	got, err := runSQL(t, tools, "UPDATE users SET age = age + 1 WHERE age > 25")
+	if err != nil {
+		t.Fatalf("run_sql error = %v", err)
+	}
+	if diff := cmp.Diff(map[string]any{"rows_affected": int64(2)}, got); diff != "" {
+		t.Errorf("run_sql mismatch (-want +got):\n%s", diff)
+	}
+	got, err = runSQL(t, tools, "SELECT age FROM users WHERE id = 1")
+	if err != nil {
+		t.Fatalf("run_sql error = %v", err)
+	}
+	if diff := cmp.Diff([][]any{{int64(31)}}, got["rows"]); diff != "" {
+		t.Errorf("run_sql mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := runSQL(t, tools, "SELECT 1; SELECT 2"); err == nil {
+		t.Error("run_sql of several statements succeeded, want error")
+	}
+}
+
+func TestSaveLargeResults(t *testing.T) {
+	artifacts := artifact.InMemoryService()
+	tools := loadTools(t, sqltoolset.Config{Dialector: newDatabase(t), MaxRows: 2, SaveLargeResults: true, MaxArtifactRows: 4})
+
+	got, err := tools["run_sql"].Run(createToolContext(t, artifacts), map[string]any{"query": "SELECT id, name FROM users ORDER BY id"})
+	if err != nil {
+		t.Fatalf("run_sql error = %v", err)
+	}
+	name, _ := got["artifact"].(string)
+	if !strings.HasPrefix(name, "query_result_") || !strings.HasSuffix(name, ".csv") {
+		t.Fatalf("artifact = %q, want a CSV artifact", got["artifact"])
+	}
+	want := map[string]any{
+		"columns":            []string{"id", "name"},
+		"rows":               [][]any{{int64(1), "Alice"}, {int64(2), "Bob"}},
+		"row_count":          2,
+		"truncated":          true,
+		"artifact":           name,
+		"artifact_version":   int64(1),
+		"artifact_row_count": 4,
+		"artifact_truncated": true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("run_sql mismatch (-want +got):\n%s", diff)
+	}
+
+	resp, err := artifacts.Load(t.Context(), &artifact.LoadRequest{AppName: "app", UserID: "user", SessionID: "session", FileName: name})
+	if err != nil {
+		t.Fatalf("failed to load artifact: %v", err)
+	}
+	wantCSV := "id,name\n1,Alice\n2,Bob\n3,Carol\n4,Dan\n"
+	if diff := cmp.Diff(wantCSV, string(resp.Part.InlineData.Data)); diff != "" {
+		t.Errorf("artifact mismatch (-want +got):\n%s", diff)
+	}
+
+	// Results which fit are not saved.
+	got, err = tools["run_sql"].Run(createToolContext(t, artifacts), map[string]any{"query": "SELECT name FROM users WHERE id = 5"})
+	if err != nil {
+		t.Fatalf("run_sql error = %v", err)
+	}
+	if diff := cmp.Diff(map[string]any{"columns": []string{"name"}, "rows": [][]any{{"Eve, \"the spy\""}}, "row_count": 1}, got); diff != "" {
+		t.Errorf("run_sql mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	tools := loadTools(t, sqltoolset.Config{
+		Dialector:        newDatabase(t),
+		Timeout:          100 * time.Millisecond,
+		SaveLargeResults: true,
+		MaxArtifactRows:  1 << 30,
+	})
+
+	start := time.Now()
+	_, err := tools["run_sql"].Run(createToolContext(t, artifact.InMemoryService()), map[string]any{
+		"query": "WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c) SELECT x FROM c",
+	})
+	if err == nil {
+		t.Fatal("run_sql of an endless query succeeded, want error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("run_sql took %v, want the query to be stopped", elapsed)
+	}
+	if !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("run_sql error = %v, want timeout", err)
+	}
+}
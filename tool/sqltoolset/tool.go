@@ -0,0 +1,181 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltoolset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// sqlTool is a tool of the SQL tool set. run is called with a context
+// bounded by the statement timeout.
+type sqlTool struct {
+	name        string
+	description string
+	parameters  map[string]any
+	run         func(ctx context.Context, toolCtx tool.Context, args map[string]any) (map[string]any, error)
+
+	set *set
+}
+
+func newListTablesTool(s *set) *sqlTool {
+	return &sqlTool{
+		name:        "list_tables",
+		description: "Lists the tables of the database.",
+		parameters:  map[string]any{"type": "object", "properties": map[string]any{}},
+		run:         s.listTables,
+		set:         s,
+	}
+}
+
+func newDescribeTableTool(s *set) *sqlTool {
+	return &sqlTool{
+		name:        "describe_table",
+		description: "Describes the columns of a table: their name, type, and whether they are nullable or part of the primary key.",
+		parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"table": map[string]any{"type": "string", "description": "The name of the table."},
+			},
+			"required": []string{"table"},
+		},
+		run: s.describeTable,
+		set: s,
+	}
+}
+
+func newRunSQLTool(s *set) *sqlTool {
+	description := fmt.Sprintf("Runs a SQL query and returns the columns and rows of its result, at most %d rows.", s.maxRows)
+	if s.allowWrites {
+		description = fmt.Sprintf("Runs a SQL statement. Returns the columns and rows of the result of queries, at most %d rows, and the number of rows affected by other statements.", s.maxRows)
+	} else {
+		description += " The database is read-only: only SELECT queries are allowed."
+	}
+	if s.saveLargeResults {
+		description += " Larger results are saved as a CSV artifact whose name is returned."
+	}
+	return &sqlTool{
+		name:        "run_sql",
+		description: description,
+		parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{"type": "string", "description": "A single SQL statement."},
+			},
+			"required": []string{"query"},
+		},
+		run: s.runSQL,
+		set: s,
+	}
+}
+
+// Name implements tool.Tool.
+func (t *sqlTool) Name() string {
+	return t.name
+}
+
+// Description implements tool.Tool.
+func (t *sqlTool) Description() string {
+	return t.description
+}
+
+// IsLongRunning implements tool.Tool.
+func (t *sqlTool) IsLongRunning() bool {
+	return false
+}
+
+func (t *sqlTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return toolutils.PackTool(req, t)
+}
+
+func (t *sqlTool) Declaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{
+		Name:                 t.name,
+		Description:          t.description,
+		ParametersJsonSchema: t.parameters,
+	}
+}
+
+func (t *sqlTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	margs, ok := args.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected args type, got: %T", args)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, t.set.timeout)
+	defer cancel()
+	result, err := t.run(timeoutCtx, ctx, margs)
+	if err != nil && errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("statement timed out after %v: %w", t.set.timeout, err)
+	}
+	return result, err
+}
+
+func (s *set) listTables(ctx context.Context, toolCtx tool.Context, args map[string]any) (map[string]any, error) {
+	tables, err := s.db.WithContext(ctx).Migrator().GetTables()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	if tables == nil {
+		tables = []string{}
+	}
+	return map[string]any{"tables": tables}, nil
+}
+
+func (s *set) describeTable(ctx context.Context, toolCtx tool.Context, args map[string]any) (map[string]any, error) {
+	table, _ := args["table"].(string)
+	if table == "" {
+		return nil, errors.New("missing required argument \"table\"")
+	}
+	migrator := s.db.WithContext(ctx).Migrator()
+	if !migrator.HasTable(table) {
+		return nil, fmt.Errorf("table %q does not exist", table)
+	}
+	columnTypes, err := migrator.ColumnTypes(table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe table %q: %w", table, err)
+	}
+
+	columns := []any{}
+	for _, c := range columnTypes {
+		column := map[string]any{
+			"name": c.Name(),
+			"type": c.DatabaseTypeName(),
+		}
+		if nullable, ok := c.Nullable(); ok {
+			column["nullable"] = nullable
+		}
+		if primaryKey, ok := c.PrimaryKey(); ok && primaryKey {
+			column["primary_key"] = true
+		}
+		if def, ok := c.DefaultValue(); ok && def != "" {
+			column["default"] = def
+		}
+		columns = append(columns, column)
+	}
+	return map[string]any{"table": table, "columns": columns}, nil
+}
+
+var (
+	_ toolinternal.FunctionTool     = (*sqlTool)(nil)
+	_ toolinternal.RequestProcessor = (*sqlTool)(nil)
+)
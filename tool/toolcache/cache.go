@@ -0,0 +1,220 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package toolcache caches the results of idempotent tools, so that calls
+// with the same arguments don't run the tools again.
+//
+// The cache is set up on an agent with a pair of callbacks:
+//
+//	cache := toolcache.New(toolcache.Config{
+//		Tools: tool.StringPredicate([]string{"get_weather"}),
+//		Scope: toolcache.ScopeSession,
+//		TTL:   10 * time.Minute,
+//	})
+//	llmagent.New(llmagent.Config{
+//		...
+//		BeforeToolCallbacks: []llmagent.BeforeToolCallback{cache.BeforeToolCallback()},
+//		AfterToolCallbacks:  []llmagent.AfterToolCallback{cache.AfterToolCallback()},
+//	})
+//
+// Cached results produce the same function response events as the tools,
+// with the IDs of the cached function calls listed in the
+// MetadataKeyCachedCalls entry of their custom metadata.
+package toolcache
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool"
+)
+
+// MetadataKeyCachedCalls is the CustomMetadata key of function response
+// events listing the IDs of the function calls whose results come from a
+// cache.
+const MetadataKeyCachedCalls = toolinternal.CachedFunctionCallsKey
+
+// Scope defines which calls share the cached results.
+type Scope int
+
+const (
+	// ScopeInvocation shares the results within an invocation of the agent,
+	// i.e. the handling of one user message.
+	ScopeInvocation Scope = iota
+	// ScopeSession shares the results within a session.
+	ScopeSession
+	// ScopeGlobal shares the results between all the sessions of all the
+	// users of all the apps using the cache.
+	ScopeGlobal
+)
+
+const defaultMaxEntries = 1000
+
+// Config configures a Cache.
+type Config struct {
+	// Tools selects the tools whose results are cached. Only select tools
+	// whose results depend on their arguments only. Defaults to no tool.
+	Tools tool.Predicate
+	// Scope of the cached results. Defaults to ScopeInvocation.
+	Scope Scope
+	// TTL is the duration results are cached for. Zero means until they are
+	// evicted.
+	TTL time.Duration
+	// MaxEntries is the maximum number of cached results. The least recently
+	// used results are evicted first. Defaults to 1000.
+	MaxEntries int
+}
+
+// Cache caches the results of tool calls. Results are keyed by the name of
+// the tool and its arguments, independently of the order of the keys of the
+// arguments. Calls which fail, or return a result with an "error" entry,
+// aren't cached.
+type Cache struct {
+	cfg Config
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+// pendingKey is the key of the tool context value holding the cache key of a
+// call which missed the cache, until its result is stored.
+type pendingKey struct{}
+
+type entry struct {
+	key     string
+	result  []byte
+	expires time.Time
+}
+
+// New creates a cache.
+func New(cfg Config) *Cache {
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = defaultMaxEntries
+	}
+	return &Cache{
+		cfg:     cfg,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// BeforeToolCallback returns the callback serving the cached results. Use it
+// together with AfterToolCallback, which stores the results.
+func (c *Cache) BeforeToolCallback() llmagent.BeforeToolCallback {
+	return func(ctx tool.Context, t tool.Tool, args map[string]any) (map[string]any, error) {
+		if c.cfg.Tools == nil || !c.cfg.Tools(ctx, t) {
+			return nil, nil
+		}
+		key, ok := c.key(ctx, t, args)
+		if !ok {
+			return nil, nil
+		}
+		if result, ok := c.get(key); ok {
+			toolinternal.MarkCached(ctx)
+			return result, nil
+		}
+		toolinternal.SetValue(ctx, pendingKey{}, key)
+		return nil, nil
+	}
+}
+
+// AfterToolCallback returns the callback storing the results of the calls
+// which missed the cache. Use it together with BeforeToolCallback. Results
+// are only stored if the tool ran, not if a before tool callback following
+// BeforeToolCallback provided them.
+func (c *Cache) AfterToolCallback() llmagent.AfterToolCallback {
+	return func(ctx tool.Context, t tool.Tool, args, result map[string]any, err error) (map[string]any, error) {
+		key, ok := toolinternal.Value(ctx, pendingKey{}).(string)
+		if !ok || !toolinternal.HasRun(ctx) || err != nil || result == nil {
+			return nil, nil
+		}
+		if _, failed := result["error"]; failed {
+			return nil, nil
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return nil, nil
+		}
+		c.put(key, data)
+		return nil, nil
+	}
+}
+
+// key returns the cache key of the call. Arguments are canonicalized by
+// their JSON encoding, whose map keys are sorted.
+func (c *Cache) key(ctx tool.Context, t tool.Tool, args map[string]any) (string, bool) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", false
+	}
+	var scope []string
+	switch c.cfg.Scope {
+	case ScopeInvocation:
+		scope = []string{ctx.AppName(), ctx.UserID(), ctx.SessionID(), ctx.InvocationID()}
+	case ScopeSession:
+		scope = []string{ctx.AppName(), ctx.UserID(), ctx.SessionID()}
+	}
+	key, err := json.Marshal(append(scope, t.Name(), string(data)))
+	if err != nil {
+		return "", false
+	}
+	return string(key), true
+}
+
+// get returns a copy of the cached result of the key.
+func (c *Cache) get(key string) (map[string]any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := elem.Value.(*entry)
+	if !e.expires.IsZero() && !time.Now().Before(e.expires) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+	var result map[string]any
+	if err := json.Unmarshal(e.result, &result); err != nil {
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return result, true
+}
+
+func (c *Cache) put(key string, result []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := &entry{key: key, result: result}
+	if c.cfg.TTL > 0 {
+		e.expires = time.Now().Add(c.cfg.TTL)
+	}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = e
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(e)
+	for c.lru.Len() > c.cfg.MaxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry).key)
+	}
+}
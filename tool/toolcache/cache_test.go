@@ -0,0 +1,321 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolcache_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+	"google.golang.org/adk/tool/toolcache"
+)
+
+type weatherArgs struct {
+	City string `json:"city"`
+	Unit string `json:"unit,omitempty"`
+}
+
+func newWeatherTool(t *testing.T, calls *int) tool.Tool {
+	t.Helper()
+	weather, err := functiontool.New(functiontool.Config{Name: "get_weather"}, func(_ tool.Context, args weatherArgs) (map[string]any, error) {
+		*calls++
+		if args.City == "" {
+			return nil, errors.New("missing city")
+		}
+		return map[string]any{"city": args.City, "forecast": "sunny"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return weather
+}
+
+func functionCall(id, city string) *genai.Part {
+	return &genai.Part{FunctionCall: &genai.FunctionCall{ID: id, Name: "get_weather", Args: map[string]any{"city": city}}}
+}
+
+func TestCache_Agent(t *testing.T) {
+	var calls int
+	cache := toolcache.New(toolcache.Config{Tools: tool.StringPredicate([]string{"get_weather"})})
+	model := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromParts([]*genai.Part{functionCall("c1", "Paris")}, genai.RoleModel),
+		genai.NewContentFromParts([]*genai.Part{functionCall("c2", "Paris"), functionCall("c3", "Rome")}, genai.RoleModel),
+		genai.NewContentFromText("done", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:                "weather_agent",
+		Model:               model,
+		Tools:               []tool.Tool{newWeatherTool(t, &calls)},
+		BeforeToolCallbacks: []llmagent.BeforeToolCallback{cache.BeforeToolCallback()},
+		AfterToolCallbacks:  []llmagent.AfterToolCallback{cache.AfterToolCallback()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := testutil.CollectEvents(testutil.NewTestAgentRunner(t, a).Run(t, "session", "weather?"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("the tool ran %d times, want 2", calls)
+	}
+
+	var responses []map[string]any
+	var metadata []map[string]any
+	for _, ev := range events {
+		if ev.Content == nil || len(ev.Content.Parts) == 0 || ev.Content.Parts[0].FunctionResponse == nil {
+			continue
+		}
+		for _, p := range ev.Content.Parts {
+			responses = append(responses, p.FunctionResponse.Response)
+		}
+		metadata = append(metadata, ev.CustomMetadata)
+	}
+	wantResponses := []map[string]any{
+		{"city": "Paris", "forecast": "sunny"},
+		{"city": "Paris", "forecast": "sunny"},
+		{"city": "Rome", "forecast": "sunny"},
+	}
+	if diff := cmp.Diff(wantResponses, responses); diff != "" {
+		t.Errorf("function responses mismatch (-want +got):\n%s", diff)
+	}
+	wantMetadata := []map[string]any{
+		nil,
+		{toolcache.MetadataKeyCachedCalls: []string{"c2"}},
+	}
+	if diff := cmp.Diff(wantMetadata, metadata); diff != "" {
+		t.Errorf("function response metadata mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCache_OtherCallbacks(t *testing.T) {
+	var calls int
+	cache := toolcache.New(toolcache.Config{Tools: tool.StringPredicate([]string{"get_weather"})})
+	model := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromParts([]*genai.Part{functionCall("c1", "Paris")}, genai.RoleModel),
+		genai.NewContentFromParts([]*genai.Part{functionCall("c2", "Paris")}, genai.RoleModel),
+		genai.NewContentFromParts([]*genai.Part{functionCall("c3", "Paris")}, genai.RoleModel),
+		genai.NewContentFromText("done", genai.RoleModel),
+	}}
+	// The result of c1 comes from a later before tool callback, it must not
+	// be cached.
+	stub := func(ctx tool.Context, _ tool.Tool, _ map[string]any) (map[string]any, error) {
+		if ctx.FunctionCallID() == "c1" {
+			return map[string]any{"forecast": "stubbed"}, nil
+		}
+		return nil, nil
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name:                "weather_agent",
+		Model:               model,
+		Tools:               []tool.Tool{newWeatherTool(t, &calls)},
+		BeforeToolCallbacks: []llmagent.BeforeToolCallback{cache.BeforeToolCallback(), stub},
+		AfterToolCallbacks:  []llmagent.AfterToolCallback{cache.AfterToolCallback()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := testutil.CollectEvents(testutil.NewTestAgentRunner(t, a).Run(t, "session", "weather?"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("the tool ran %d times, want 1", calls)
+	}
+	var responses []map[string]any
+	for _, ev := range events {
+		if ev.Content != nil && len(ev.Content.Parts) > 0 && ev.Content.Parts[0].FunctionResponse != nil {
+			responses = append(responses, ev.Content.Parts[0].FunctionResponse.Response)
+		}
+	}
+	wantResponses := []map[string]any{
+		{"forecast": "stubbed"},
+		{"city": "Paris", "forecast": "sunny"},
+		{"city": "Paris", "forecast": "sunny"},
+	}
+	if diff := cmp.Diff(wantResponses, responses); diff != "" {
+		t.Errorf("function responses mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// invocations creates the invocation contexts of the tests, by invocation
+// and session name.
+type invocations map[[2]string]agent.InvocationContext
+
+func (inv invocations) get(t *testing.T, invocation, sessionID string) agent.InvocationContext {
+	key := [2]string{invocation, sessionID}
+	if _, ok := inv[key]; !ok {
+		inv[key] = icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{
+			Session: &fakeSession{id: sessionID},
+		})
+	}
+	return inv[key]
+}
+
+// call runs the tool through the callbacks of the cache, like the agent
+// flow, and reports whether the result came from the cache.
+func call(t *testing.T, cache *toolcache.Cache, invocationCtx agent.InvocationContext, fnTool tool.Tool, args map[string]any) (map[string]any, bool) {
+	t.Helper()
+	ctx := toolinternal.NewToolContext(invocationCtx, "", nil)
+
+	result, err := cache.BeforeToolCallback()(ctx, fnTool, args)
+	if err != nil {
+		t.Fatalf("BeforeToolCallback() error = %v", err)
+	}
+	if result != nil {
+		return result, toolinternal.IsCached(ctx)
+	}
+	toolinternal.MarkRun(ctx)
+	result, err = fnTool.(toolinternal.FunctionTool).Run(ctx, args)
+	if _, err := cache.AfterToolCallback()(ctx, fnTool, args, result, err); err != nil {
+		t.Fatalf("AfterToolCallback() error = %v", err)
+	}
+	return result, false
+}
+
+func TestCache(t *testing.T) {
+	paris := map[string]any{"city": "Paris"}
+	parisCelsius := map[string]any{"city": "Paris", "unit": "C"}
+	celsiusParis := map[string]any{"unit": "C", "city": "Paris"}
+
+	// step is a call made in the given invocation and session.
+	type step struct {
+		invocation, session string
+		args                map[string]any
+		wantCached          bool
+	}
+	for _, tc := range []struct {
+		name  string
+		cfg   toolcache.Config
+		steps []step
+	}{
+		{
+			name: "tool not selected",
+			cfg:  toolcache.Config{Tools: tool.StringPredicate([]string{"other"})},
+			steps: []step{
+				{"i1", "s1", paris, false},
+				{"i1", "s1", paris, false},
+			},
+		},
+		{
+			name: "invocation scope",
+			cfg:  toolcache.Config{Tools: tool.StringPredicate([]string{"get_weather"})},
+			steps: []step{
+				{"i1", "s1", paris, false},
+				{"i1", "s1", paris, true},
+				{"i1", "s1", parisCelsius, false},
+				{"i1", "s1", celsiusParis, true},
+				{"i2", "s1", paris, false},
+			},
+		},
+		{
+			name: "session scope",
+			cfg:  toolcache.Config{Tools: tool.StringPredicate([]string{"get_weather"}), Scope: toolcache.ScopeSession},
+			steps: []step{
+				{"i1", "s1", paris, false},
+				{"i2", "s1", paris, true},
+				{"i3", "s2", paris, false},
+			},
+		},
+		{
+			name: "global scope",
+			cfg:  toolcache.Config{Tools: tool.StringPredicate([]string{"get_weather"}), Scope: toolcache.ScopeGlobal},
+			steps: []step{
+				{"i1", "s1", paris, false},
+				{"i2", "s2", paris, true},
+			},
+		},
+		{
+			name: "errors are not cached",
+			cfg:  toolcache.Config{Tools: tool.StringPredicate([]string{"get_weather"})},
+			steps: []step{
+				{"i1", "s1", map[string]any{}, false},
+				{"i1", "s1", map[string]any{}, false},
+			},
+		},
+		{
+			name: "eviction",
+			cfg:  toolcache.Config{Tools: tool.StringPredicate([]string{"get_weather"}), MaxEntries: 1},
+			steps: []step{
+				{"i1", "s1", paris, false},
+				{"i1", "s1", parisCelsius, false},
+				{"i1", "s1", paris, false},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var runs int
+			weather := newWeatherTool(t, &runs)
+			cache := toolcache.New(tc.cfg)
+			inv := invocations{}
+			for i, s := range tc.steps {
+				if _, cached := call(t, cache, inv.get(t, s.invocation, s.session), weather, s.args); cached != s.wantCached {
+					t.Errorf("call %d cached = %v, want %v", i, cached, s.wantCached)
+				}
+			}
+		})
+	}
+}
+
+func TestCache_TTL(t *testing.T) {
+	var runs int
+	weather := newWeatherTool(t, &runs)
+	cache := toolcache.New(toolcache.Config{Tools: tool.StringPredicate([]string{"get_weather"}), TTL: 20 * time.Millisecond})
+	args := map[string]any{"city": "Paris"}
+	invocationCtx := invocations{}.get(t, "i1", "s1")
+
+	call(t, cache, invocationCtx, weather, args)
+	if got, cached := call(t, cache, invocationCtx, weather, args); !cached {
+		t.Errorf("second call isn't cached, result %v", got)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, cached := call(t, cache, invocationCtx, weather, args); cached {
+		t.Error("call after the TTL is cached")
+	}
+	if runs != 2 {
+		t.Errorf("the tool ran %d times, want 2", runs)
+	}
+}
+
+// fakeSession is a session with an ID only.
+type fakeSession struct {
+	session.Session
+	id string
+}
+
+func (s *fakeSession) ID() string {
+	return s.id
+}
+
+func (s *fakeSession) AppName() string {
+	return "app"
+}
+
+func (s *fakeSession) UserID() string {
+	return "user"
+}
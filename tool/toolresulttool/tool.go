@@ -0,0 +1,181 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package toolresulttool defines a tool reading the tool results which were
+// too large to be sent to the model, page by page.
+//
+// When llmagent.Config.MaxToolResultSize is set, larger tool results are
+// saved as artifacts and the model receives a preview and the name of the
+// artifact. The agent is given this tool to read the rest of the result.
+package toolresulttool
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+const (
+	// Name is the name of the tool.
+	Name = toolinternal.ReadToolResultName
+
+	defaultPageSize = 10000
+	maxPageSize     = 50000
+)
+
+// resultTool reads a page of a tool result saved as an artifact.
+type resultTool struct{}
+
+// New creates a tool reading pages of the tool results saved as artifacts.
+// llmagent.New adds it to the agents offloading tool results, it doesn't
+// need to be added explicitly.
+func New() tool.Tool {
+	return &resultTool{}
+}
+
+// Name implements tool.Tool.
+func (t *resultTool) Name() string {
+	return Name
+}
+
+// Description implements tool.Tool.
+func (t *resultTool) Description() string {
+	return "Reads a page of a tool result which was too large to be returned and was saved as an artifact. Returns the content starting at offset, and the offset of the next page if there is one."
+}
+
+// IsLongRunning implements tool.Tool.
+func (t *resultTool) IsLongRunning() bool {
+	return false
+}
+
+// Declaration returns the GenAI FunctionDeclaration for the read_tool_result
+// tool.
+func (t *resultTool) Declaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{
+		Name:        t.Name(),
+		Description: t.Description(),
+		ParametersJsonSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"artifact": map[string]any{
+					"type":        "string",
+					"description": "The name of the artifact holding the tool result.",
+				},
+				"offset": map[string]any{
+					"type":        "integer",
+					"description": "The byte offset of the page in the result. Defaults to 0.",
+				},
+				"length": map[string]any{
+					"type":        "integer",
+					"description": fmt.Sprintf("The maximum number of bytes of the page. Defaults to %d, at most %d.", defaultPageSize, maxPageSize),
+				},
+			},
+			"required": []string{"artifact"},
+		},
+	}
+}
+
+// Run implements tool.Tool.
+func (t *resultTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	m, ok := args.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected args type, got: %T", args)
+	}
+	name, _ := m["artifact"].(string)
+	if name == "" {
+		return nil, errors.New("missing required argument \"artifact\"")
+	}
+	offset, err := toolutils.IntArg(m, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	length, err := toolutils.IntArg(m, "length", defaultPageSize)
+	if err != nil {
+		return nil, err
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("offset must not be negative, got %d", offset)
+	}
+	if length <= 0 {
+		return nil, fmt.Errorf("length must be positive, got %d", length)
+	}
+	length = min(length, maxPageSize)
+
+	// Only the artifacts holding tool results can be read, not the other
+	// artifacts of the session.
+	if !strings.HasPrefix(name, toolinternal.ToolResultArtifactPrefix) {
+		return nil, fmt.Errorf("artifact %q is not a tool result", name)
+	}
+	if ctx.Artifacts() == nil {
+		return nil, errors.New("artifact service is not configured")
+	}
+	resp, err := ctx.Artifacts().Load(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load artifact %q: %w", name, err)
+	}
+	var data []byte
+	switch {
+	case resp.Part.InlineData != nil:
+		data = resp.Part.InlineData.Data
+	default:
+		data = []byte(resp.Part.Text)
+	}
+	if offset > len(data) {
+		return nil, fmt.Errorf("offset %d is past the end of the result of %d bytes", offset, len(data))
+	}
+
+	// Pages don't split UTF-8 characters.
+	start := offset
+	for start < len(data) && !utf8.RuneStart(data[start]) {
+		start++
+	}
+	end := min(start+length, len(data))
+	for end < len(data) && end > start && !utf8.RuneStart(data[end]) {
+		end--
+	}
+	if end == start && start < len(data) {
+		// The page is smaller than the character at start: return the
+		// whole character so that paging moves forward.
+		_, size := utf8.DecodeRune(data[start:])
+		end = start + size
+	}
+
+	result := map[string]any{
+		"content": string(data[start:end]),
+		"offset":  start,
+		"size":    len(data),
+	}
+	if end < len(data) {
+		result["next_offset"] = end
+	}
+	return result, nil
+}
+
+// ProcessRequest packs the tool.
+func (t *resultTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return toolutils.PackTool(req, t)
+}
+
+var (
+	_ toolinternal.FunctionTool     = (*resultTool)(nil)
+	_ toolinternal.RequestProcessor = (*resultTool)(nil)
+)
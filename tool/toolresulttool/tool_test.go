@@ -0,0 +1,145 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolresulttool_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/artifact"
+	artifactinternal "google.golang.org/adk/internal/artifact"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/toolresulttool"
+)
+
+func createToolContext(t *testing.T) tool.Context {
+	t.Helper()
+
+	invocationCtx := icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{
+		Artifacts: &artifactinternal.Artifacts{
+			Service:   artifact.InMemoryService(),
+			AppName:   "app",
+			UserID:    "user",
+			SessionID: "session",
+		},
+	})
+	return toolinternal.NewToolContext(invocationCtx, "", nil)
+}
+
+func TestReadToolResult(t *testing.T) {
+	ctx := createToolContext(t)
+	if _, err := ctx.Artifacts().Save(ctx, "tool_result_search_1.json", genai.NewPartFromBytes([]byte(`{"text":"héllo world"}`), "application/json")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ctx.Artifacts().Save(ctx, "tool_result_search_3.json", genai.NewPartFromBytes([]byte("€€"), "application/json")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ctx.Artifacts().Save(ctx, "notes.txt", genai.NewPartFromText("private notes")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ctx.Artifacts().Save(ctx, "tool_result_search_2.json", genai.NewPartFromBytes([]byte(strings.Repeat("a", 60000)), "application/json")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name: "whole result",
+			args: map[string]any{"artifact": "tool_result_search_1.json"},
+			want: map[string]any{"content": `{"text":"héllo world"}`, "offset": 0, "size": 23},
+		},
+		{
+			name: "first page",
+			args: map[string]any{"artifact": "tool_result_search_1.json", "length": 10.0},
+			want: map[string]any{"content": `{"text":"h`, "offset": 0, "size": 23, "next_offset": 10},
+		},
+		{
+			name: "page ends inside a character",
+			args: map[string]any{"artifact": "tool_result_search_1.json", "length": 11.0},
+			want: map[string]any{"content": `{"text":"h`, "offset": 0, "size": 23, "next_offset": 10},
+		},
+		{
+			name: "page starts inside a character",
+			args: map[string]any{"artifact": "tool_result_search_1.json", "offset": 11.0, "length": 5.0},
+			want: map[string]any{"content": "llo w", "offset": 12, "size": 23, "next_offset": 17},
+		},
+		{
+			name: "last page",
+			args: map[string]any{"artifact": "tool_result_search_1.json", "offset": 17.0},
+			want: map[string]any{"content": `orld"}`, "offset": 17, "size": 23},
+		},
+		{
+			name: "page smaller than a character",
+			args: map[string]any{"artifact": "tool_result_search_3.json", "length": 1.0},
+			want: map[string]any{"content": "€", "offset": 0, "size": 6, "next_offset": 3},
+		},
+		{
+			name: "length is capped",
+			args: map[string]any{"artifact": "tool_result_search_2.json", "length": 100000.0},
+			want: map[string]any{"content": strings.Repeat("a", 50000), "offset": 0, "size": 60000, "next_offset": 50000},
+		},
+		{
+			name:    "missing artifact argument",
+			args:    map[string]any{},
+			wantErr: true,
+		},
+		{
+			name:    "unknown artifact",
+			args:    map[string]any{"artifact": "tool_result_unknown.json"},
+			wantErr: true,
+		},
+		{
+			name:    "artifact which isn't a tool result",
+			args:    map[string]any{"artifact": "notes.txt"},
+			wantErr: true,
+		},
+		{
+			name:    "offset past the end",
+			args:    map[string]any{"artifact": "tool_result_search_1.json", "offset": 100.0},
+			wantErr: true,
+		},
+		{
+			name:    "negative offset",
+			args:    map[string]any{"artifact": "tool_result_search_1.json", "offset": -1.0},
+			wantErr: true,
+		},
+		{
+			name:    "fractional length",
+			args:    map[string]any{"artifact": "tool_result_search_1.json", "length": 1.5},
+			wantErr: true,
+		},
+	}
+	readTool := toolresulttool.New().(toolinternal.FunctionTool)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := readTool.Run(ctx, tc.args)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tool
+
+import (
+	"fmt"
+	"slices"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+)
+
+// Selector chooses the tools of a toolset exposed to the model. It is called
+// before each model call with the tools of the toolset, so that the choice
+// can depend on the state of the session. Selectors must not modify the
+// slice of tools they receive, which may be shared by the toolset.
+type Selector func(ctx agent.ReadonlyContext, tools []Tool) ([]Tool, error)
+
+// NewToolset returns a toolset with the given tools, e.g. to apply the other
+// toolset helpers to them.
+func NewToolset(name string, tools ...Tool) Toolset {
+	return &staticToolset{name: name, tools: tools}
+}
+
+type staticToolset struct {
+	name  string
+	tools []Tool
+}
+
+func (s *staticToolset) Name() string {
+	return s.name
+}
+
+func (s *staticToolset) Tools(agent.ReadonlyContext) ([]Tool, error) {
+	return slices.Clone(s.tools), nil
+}
+
+// MergeToolsets returns a toolset with the tools of all the toolsets. Its
+// Tools method fails if two toolsets provide tools with the same name: use
+// PrefixToolset to rename them.
+func MergeToolsets(name string, toolsets ...Toolset) Toolset {
+	return &mergedToolset{name: name, toolsets: toolsets}
+}
+
+type mergedToolset struct {
+	name     string
+	toolsets []Toolset
+}
+
+func (s *mergedToolset) Name() string {
+	return s.name
+}
+
+func (s *mergedToolset) Tools(ctx agent.ReadonlyContext) ([]Tool, error) {
+	var tools []Tool
+	owners := make(map[string]string)
+	for _, ts := range s.toolsets {
+		tsTools, err := ts.Tools(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract tools from the tool set %q: %w", ts.Name(), err)
+		}
+		for _, t := range tsTools {
+			if owner, ok := owners[t.Name()]; ok {
+				return nil, fmt.Errorf("tool %q of the tool set %q is also provided by the tool set %q", t.Name(), ts.Name(), owner)
+			}
+			owners[t.Name()] = ts.Name()
+		}
+		tools = append(tools, tsTools...)
+	}
+	return tools, nil
+}
+
+// FilterToolset returns a toolset with the tools of ts for which predicate
+// returns true.
+func FilterToolset(ts Toolset, predicate Predicate) Toolset {
+	return SelectToolset(ts, func(ctx agent.ReadonlyContext, tools []Tool) ([]Tool, error) {
+		var selected []Tool
+		for _, t := range tools {
+			if predicate(ctx, t) {
+				selected = append(selected, t)
+			}
+		}
+		return selected, nil
+	})
+}
+
+// SelectToolset returns a toolset with the tools of ts chosen by selector.
+//
+// Example, exposing the checkout tools once the cart isn't empty:
+//
+//	tool.SelectToolset(shopTools, func(ctx agent.ReadonlyContext, tools []tool.Tool) ([]tool.Tool, error) {
+//		cart, _ := ctx.ReadonlyState().Get("cart")
+//		if cart != nil {
+//			return tools, nil
+//		}
+//		return slices.DeleteFunc(slices.Clone(tools), func(t tool.Tool) bool {
+//			return strings.HasPrefix(t.Name(), "checkout_")
+//		}), nil
+//	})
+func SelectToolset(ts Toolset, selector Selector) Toolset {
+	return &selectedToolset{toolset: ts, selector: selector}
+}
+
+type selectedToolset struct {
+	toolset  Toolset
+	selector Selector
+}
+
+func (s *selectedToolset) Name() string {
+	return s.toolset.Name()
+}
+
+func (s *selectedToolset) Tools(ctx agent.ReadonlyContext) ([]Tool, error) {
+	tools, err := s.toolset.Tools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.selector(ctx, tools)
+}
+
+// PrefixToolset returns a toolset with the tools of ts, their names prefixed
+// with prefix, e.g. to use two MCP servers providing tools with the same
+// names.
+//
+// Only the tools the model calls, such as function, MCP and agent tools, are
+// renamed; built-in tools like geminitool.GoogleSearch are left as is. The
+// renamed tools are declared to the model as they are, their own request
+// processing, e.g. adding instructions, is skipped.
+func PrefixToolset(ts Toolset, prefix string) Toolset {
+	return &prefixedToolset{toolset: ts, prefix: prefix}
+}
+
+type prefixedToolset struct {
+	toolset Toolset
+	prefix  string
+}
+
+func (s *prefixedToolset) Name() string {
+	return s.prefix + s.toolset.Name()
+}
+
+func (s *prefixedToolset) Tools(ctx agent.ReadonlyContext) ([]Tool, error) {
+	tools, err := s.toolset.Tools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prefixed := make([]Tool, 0, len(tools))
+	for _, t := range tools {
+		if fnTool, ok := t.(functionTool); ok {
+			t = &renamedTool{functionTool: fnTool, name: s.prefix + t.Name()}
+		}
+		prefixed = append(prefixed, t)
+	}
+	return prefixed, nil
+}
+
+// functionTool is a tool the model can call, see
+// toolinternal.FunctionTool.
+type functionTool interface {
+	Tool
+	Declaration() *genai.FunctionDeclaration
+	Run(ctx Context, args any) (map[string]any, error)
+}
+
+// renamedTool is a function tool with another name.
+type renamedTool struct {
+	functionTool
+	name string
+}
+
+func (t *renamedTool) Name() string {
+	return t.name
+}
+
+func (t *renamedTool) Declaration() *genai.FunctionDeclaration {
+	decl := t.functionTool.Declaration()
+	if decl == nil {
+		return nil
+	}
+	renamed := *decl
+	renamed.Name = t.name
+	return &renamed
+}
+
+// Unwrap returns the renamed tool, see toolinternal.Wrapper.
+func (t *renamedTool) Unwrap() Tool {
+	return t.functionTool
+}
+
+func (t *renamedTool) ProcessRequest(ctx Context, req *model.LLMRequest) error {
+	return toolutils.PackTool(req, t)
+}
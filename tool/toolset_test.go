@@ -0,0 +1,180 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tool_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/adk/agent"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+	"google.golang.org/adk/tool/geminitool"
+)
+
+type echoArgs struct {
+	Text string `json:"text"`
+}
+
+func newEchoTool(t *testing.T, name string) tool.Tool {
+	t.Helper()
+	echo, err := functiontool.New(functiontool.Config{Name: name, Description: "echoes " + name}, func(_ tool.Context, args echoArgs) (map[string]any, error) {
+		return map[string]any{"tool": name, "text": args.Text}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return echo
+}
+
+func toolNames(t *testing.T, ctx agent.ReadonlyContext, ts tool.Toolset) []string {
+	t.Helper()
+	tools, err := ts.Tools(ctx)
+	if err != nil {
+		t.Fatalf("Tools() error = %v", err)
+	}
+	var names []string
+	for _, tl := range tools {
+		names = append(names, tl.Name())
+	}
+	return names
+}
+
+func newReadonlyContext(t *testing.T, state map[string]any) agent.ReadonlyContext {
+	t.Helper()
+	service := session.InMemoryService()
+	resp, err := service.Create(t.Context(), &session.CreateRequest{AppName: "app", UserID: "user", State: state})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return icontext.NewReadonlyContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{Session: resp.Session}))
+}
+
+func TestMergeToolsets(t *testing.T) {
+	ctx := newReadonlyContext(t, nil)
+	first := tool.NewToolset("first", newEchoTool(t, "a"), newEchoTool(t, "b"))
+	second := tool.NewToolset("second", newEchoTool(t, "c"))
+
+	merged := tool.MergeToolsets("merged", first, second)
+	if got := merged.Name(); got != "merged" {
+		t.Errorf("Name() = %q, want %q", got, "merged")
+	}
+	if diff := cmp.Diff([]string{"a", "b", "c"}, toolNames(t, ctx, merged)); diff != "" {
+		t.Errorf("Tools() mismatch (-want +got):\n%s", diff)
+	}
+
+	colliding := tool.MergeToolsets("colliding", first, tool.NewToolset("third", newEchoTool(t, "a")))
+	if _, err := colliding.Tools(ctx); err == nil {
+		t.Error("Tools() of colliding toolsets succeeded, want an error")
+	}
+}
+
+func TestFilterToolset(t *testing.T) {
+	ctx := newReadonlyContext(t, nil)
+	ts := tool.FilterToolset(tool.NewToolset("set", newEchoTool(t, "a"), newEchoTool(t, "b"), newEchoTool(t, "c")), tool.StringPredicate([]string{"a", "c"}))
+	if diff := cmp.Diff([]string{"a", "c"}, toolNames(t, ctx, ts)); diff != "" {
+		t.Errorf("Tools() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSelectToolset(t *testing.T) {
+	ts := tool.SelectToolset(tool.NewToolset("shop", newEchoTool(t, "search"), newEchoTool(t, "checkout_pay")), func(ctx agent.ReadonlyContext, tools []tool.Tool) ([]tool.Tool, error) {
+		if cart, _ := ctx.ReadonlyState().Get("cart"); cart != nil {
+			return tools, nil
+		}
+		return slices.DeleteFunc(slices.Clone(tools), func(t tool.Tool) bool {
+			return strings.HasPrefix(t.Name(), "checkout_")
+		}), nil
+	})
+	if got := ts.Name(); got != "shop" {
+		t.Errorf("Name() = %q, want %q", got, "shop")
+	}
+
+	if diff := cmp.Diff([]string{"search"}, toolNames(t, newReadonlyContext(t, nil), ts)); diff != "" {
+		t.Errorf("Tools() with an empty cart mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"search", "checkout_pay"}, toolNames(t, newReadonlyContext(t, map[string]any{"cart": "book"}), ts)); diff != "" {
+		t.Errorf("Tools() with a cart mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewToolset_ToolsNotShared(t *testing.T) {
+	ctx := newReadonlyContext(t, nil)
+	ts := tool.NewToolset("set", newEchoTool(t, "a"), newEchoTool(t, "b"))
+	// A selector modifying the tools it receives doesn't change the toolset.
+	selected := tool.SelectToolset(ts, func(ctx agent.ReadonlyContext, tools []tool.Tool) ([]tool.Tool, error) {
+		return slices.DeleteFunc(tools, func(t tool.Tool) bool { return t.Name() == "a" }), nil
+	})
+	for range 2 {
+		if diff := cmp.Diff([]string{"b"}, toolNames(t, ctx, selected)); diff != "" {
+			t.Errorf("Tools() of the selected toolset mismatch (-want +got):\n%s", diff)
+		}
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, toolNames(t, ctx, ts)); diff != "" {
+		t.Errorf("Tools() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPrefixToolset(t *testing.T) {
+	readonlyCtx := newReadonlyContext(t, nil)
+	search := geminitool.GoogleSearch{}
+	ts := tool.PrefixToolset(tool.NewToolset("set", newEchoTool(t, "echo"), search), "github_")
+	if got := ts.Name(); got != "github_set" {
+		t.Errorf("Name() = %q, want %q", got, "github_set")
+	}
+
+	tools, err := ts.Tools(readonlyCtx)
+	if err != nil {
+		t.Fatalf("Tools() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"github_echo", "google_search"}, toolNames(t, readonlyCtx, ts)); diff != "" {
+		t.Errorf("Tools() mismatch (-want +got):\n%s", diff)
+	}
+
+	echo := tools[0].(toolinternal.FunctionTool)
+	if got := echo.Declaration().Name; got != "github_echo" {
+		t.Errorf("Declaration().Name = %q, want %q", got, "github_echo")
+	}
+	if got := echo.Description(); got != "echoes echo" {
+		t.Errorf("Description() = %q, want %q", got, "echoes echo")
+	}
+
+	toolCtx := toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}), "", nil)
+	req := &model.LLMRequest{}
+	if err := echo.(toolinternal.RequestProcessor).ProcessRequest(toolCtx, req); err != nil {
+		t.Fatalf("ProcessRequest() error = %v", err)
+	}
+	if req.Tools["github_echo"] != echo {
+		t.Errorf("ProcessRequest() registered tools %v, want the prefixed tool", req.Tools)
+	}
+	if got := req.Config.Tools[0].FunctionDeclarations[0].Name; got != "github_echo" {
+		t.Errorf("declared function %q, want %q", got, "github_echo")
+	}
+
+	got, err := echo.Run(toolCtx, map[string]any{"text": "hi"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if diff := cmp.Diff(map[string]any{"tool": "echo", "text": "hi"}, got); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+}
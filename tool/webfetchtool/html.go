@@ -0,0 +1,363 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webfetchtool
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// htmlToText converts the HTML document to markdown-like text. Links are
+// resolved against the base URL.
+func htmlToText(data []byte, base *url.URL) (title, text string, err error) {
+	doc, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return "", "", err
+	}
+	w := &textWriter{base: base}
+	w.walk(doc)
+	return findTitle(doc), w.String(), nil
+}
+
+// skippedElements are the elements whose content isn't displayed.
+var skippedElements = map[atom.Atom]bool{
+	atom.Head:     true,
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Svg:      true,
+	atom.Iframe:   true,
+	atom.Object:   true,
+	atom.Canvas:   true,
+	atom.Button:   true,
+	atom.Select:   true,
+}
+
+// blockElements are the elements separated from their siblings by blank
+// lines.
+var blockElements = map[atom.Atom]bool{
+	atom.Address:    true,
+	atom.Article:    true,
+	atom.Aside:      true,
+	atom.Blockquote: true,
+	atom.Dd:         true,
+	atom.Div:        true,
+	atom.Dl:         true,
+	atom.Dt:         true,
+	atom.Figcaption: true,
+	atom.Figure:     true,
+	atom.Footer:     true,
+	atom.Form:       true,
+	atom.Header:     true,
+	atom.Main:       true,
+	atom.Nav:        true,
+	atom.P:          true,
+	atom.Section:    true,
+	atom.Table:      true,
+}
+
+var headingLevels = map[atom.Atom]int{
+	atom.H1: 1, atom.H2: 2, atom.H3: 3, atom.H4: 4, atom.H5: 5, atom.H6: 6,
+}
+
+// textWriter writes the text of HTML nodes.
+type textWriter struct {
+	buf  bytes.Buffer
+	base *url.URL
+	// pre is the number of enclosing pre elements, in which whitespace is
+	// preserved.
+	pre int
+	// lists holds the next item number of the enclosing lists, 0 for
+	// unordered lists.
+	lists []int
+}
+
+func (w *textWriter) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.text(n.Data)
+		return
+	case html.DocumentNode:
+		w.walkChildren(n)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	if skippedElements[n.DataAtom] || hasAttr(n, "hidden") {
+		return
+	}
+
+	if level, ok := headingLevels[n.DataAtom]; ok {
+		w.blankLine()
+		w.buf.WriteString(strings.Repeat("#", level) + " ")
+		w.walkChildren(n)
+		w.blankLine()
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		w.newline()
+	case atom.Hr:
+		w.blankLine()
+		w.buf.WriteString("---")
+		w.blankLine()
+	case atom.Pre:
+		w.blankLine()
+		w.buf.WriteString("```\n")
+		w.pre++
+		w.walkChildren(n)
+		w.pre--
+		w.newline()
+		w.buf.WriteString("```")
+		w.blankLine()
+	case atom.Code:
+		if w.pre > 0 {
+			w.walkChildren(n)
+			return
+		}
+		w.wrap(n, "`", "`")
+	case atom.Strong, atom.B:
+		w.wrap(n, "**", "**")
+	case atom.Em, atom.I:
+		w.wrap(n, "*", "*")
+	case atom.A:
+		href := w.resolve(attr(n, "href"))
+		if href == "" {
+			w.walkChildren(n)
+			return
+		}
+		w.wrap(n, "[", "]("+href+")")
+	case atom.Img:
+		if alt := strings.TrimSpace(attr(n, "alt")); alt != "" {
+			w.buf.WriteString("![" + alt + "](" + w.resolve(attr(n, "src")) + ")")
+		}
+	case atom.Ul, atom.Ol:
+		w.newline()
+		next := 0
+		if n.DataAtom == atom.Ol {
+			next = 1
+		}
+		w.lists = append(w.lists, next)
+		w.walkChildren(n)
+		w.lists = w.lists[:len(w.lists)-1]
+		if len(w.lists) == 0 {
+			w.blankLine()
+		} else {
+			w.newline()
+		}
+	case atom.Li:
+		w.newline()
+		marker := "- "
+		if depth := len(w.lists); depth > 0 {
+			w.buf.WriteString(strings.Repeat("  ", depth-1))
+			if w.lists[depth-1] > 0 {
+				marker = fmt.Sprintf("%d. ", w.lists[depth-1])
+				w.lists[depth-1]++
+			}
+		}
+		w.buf.WriteString(marker)
+		w.walkChildren(n)
+		w.newline()
+	case atom.Tr:
+		w.newline()
+		w.buf.WriteString("|")
+		w.walkChildren(n)
+		w.newline()
+	case atom.Td, atom.Th:
+		w.buf.WriteString(" ")
+		w.walkChildren(n)
+		w.trimSpaces()
+		w.buf.WriteString(" |")
+	default:
+		block := blockElements[n.DataAtom]
+		if block {
+			w.blankLine()
+		}
+		if n.DataAtom == atom.Blockquote {
+			w.buf.WriteString("> ")
+		}
+		w.walkChildren(n)
+		if block {
+			w.blankLine()
+		}
+	}
+}
+
+func (w *textWriter) walkChildren(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.walk(c)
+	}
+}
+
+// wrap writes the children of n between prefix and suffix. Nothing is
+// written if the children have no text.
+func (w *textWriter) wrap(n *html.Node, prefix, suffix string) {
+	start := w.buf.Len()
+	w.buf.WriteString(prefix)
+	textStart := w.buf.Len()
+	w.walkChildren(n)
+	if strings.TrimSpace(string(w.buf.Bytes()[textStart:])) == "" {
+		w.buf.Truncate(start)
+		return
+	}
+	// Move the trailing space after the suffix.
+	space := w.trimSpaces()
+	w.buf.WriteString(suffix)
+	if space {
+		w.buf.WriteString(" ")
+	}
+}
+
+// text writes the text, collapsing whitespace outside of pre elements.
+func (w *textWriter) text(s string) {
+	if w.pre > 0 {
+		w.buf.WriteString(s)
+		return
+	}
+	s = whitespace.ReplaceAllString(s, " ")
+	if w.atLineStart() || w.endsWithSpace() {
+		s = strings.TrimLeft(s, " ")
+	}
+	w.buf.WriteString(s)
+}
+
+var whitespace = regexp.MustCompile(`[ \t\r\n\f]+`)
+
+func (w *textWriter) atLineStart() bool {
+	b := w.buf.Bytes()
+	return len(b) == 0 || b[len(b)-1] == '\n' || bytes.HasSuffix(b, []byte("> ")) || w.afterListMarker()
+}
+
+func (w *textWriter) afterListMarker() bool {
+	line := w.buf.Bytes()[bytes.LastIndexByte(w.buf.Bytes(), '\n')+1:]
+	return listMarker.Match(line)
+}
+
+var listMarker = regexp.MustCompile(`^ *(-|\d+\.) $`)
+
+func (w *textWriter) endsWithSpace() bool {
+	b := w.buf.Bytes()
+	return len(b) > 0 && b[len(b)-1] == ' '
+}
+
+// trimSpaces removes the trailing spaces, and reports whether there were
+// any.
+func (w *textWriter) trimSpaces() bool {
+	b := w.buf.Bytes()
+	n := len(bytes.TrimRight(b, " "))
+	w.buf.Truncate(n)
+	return n < len(b)
+}
+
+// newline ends the current line, if any.
+func (w *textWriter) newline() {
+	w.trimSpaces()
+	if !w.atLineStartStrict() {
+		w.buf.WriteByte('\n')
+	}
+}
+
+// blankLine ends the current paragraph with a blank line.
+func (w *textWriter) blankLine() {
+	w.newline()
+	b := w.buf.Bytes()
+	if len(b) > 0 && !bytes.HasSuffix(b, []byte("\n\n")) {
+		w.buf.WriteByte('\n')
+	}
+}
+
+func (w *textWriter) atLineStartStrict() bool {
+	b := w.buf.Bytes()
+	return len(b) == 0 || b[len(b)-1] == '\n'
+}
+
+// String returns the text, without trailing whitespace.
+func (w *textWriter) String() string {
+	lines := strings.Split(w.buf.String(), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " \t")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// resolve returns the absolute URL of the link, or an empty string for links
+// which can't be followed.
+func (w *textWriter) resolve(href string) string {
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "#") {
+		return ""
+	}
+	u, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	if w.base != nil {
+		u = w.base.ResolveReference(u)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "mailto" {
+		return ""
+	}
+	return u.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasAttr(n *html.Node, key string) bool {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// findTitle returns the text of the title element of the document.
+func findTitle(n *html.Node) string {
+	if n.Type == html.ElementNode && n.DataAtom == atom.Title {
+		var b strings.Builder
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.TextNode {
+				b.WriteString(c.Data)
+			}
+		}
+		return strings.Join(strings.Fields(b.String()), " ")
+	}
+	if n.Type == html.ElementNode && n.DataAtom == atom.Svg {
+		return ""
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if title := findTitle(c); title != "" {
+			return title
+		}
+	}
+	return ""
+}
@@ -0,0 +1,413 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webfetchtool provides a tool fetching web pages.
+//
+// HTML pages are converted to markdown-like text, preserving headings,
+// lists and links. Other text content is returned as is, and binary content
+// is saved as an artifact.
+package webfetchtool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/internal/version"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// Config provides initial configuration for the web fetch tool.
+type Config struct {
+	// Name of the tool. Defaults to "fetch_url".
+	Name string
+	// Description of the tool. Defaults to a description of the tool and
+	// of the allowed domains.
+	Description string
+
+	// HTTPClient is used to fetch the URLs. Defaults to http.DefaultClient.
+	// Its redirect policy is wrapped to check the allowed domains, and its
+	// transport, which must be an *http.Transport unless
+	// AllowPrivateNetworks is set, is wrapped to check the addresses it
+	// connects to.
+	HTTPClient *http.Client
+	// AllowedDomains lists the domains which can be fetched, including
+	// their subdomains: "example.com" allows "www.example.com". If empty,
+	// all public domains are allowed.
+	AllowedDomains []string
+	// AllowPrivateNetworks allows connecting to loopback, private,
+	// link-local and other non-public IP addresses, such as 127.0.0.1,
+	// 10.0.0.0/8 or the metadata server 169.254.169.254. By default, the
+	// tool refuses to connect to them, even if their domain is allowed, so
+	// that the model can't reach internal services. The addresses are
+	// checked after DNS resolution; when a proxy is configured, the
+	// address of the proxy is checked instead of the one of the fetched
+	// host.
+	AllowPrivateNetworks bool
+
+	// Timeout of a fetch. Defaults to 30 seconds.
+	Timeout time.Duration
+	// MaxSize is the maximum number of bytes downloaded. Larger text
+	// contents are truncated, larger binary contents are rejected. Defaults
+	// to 5 MiB.
+	MaxSize int64
+	// MaxTextLength is the maximum number of bytes of text returned to the
+	// model. Defaults to 100000.
+	MaxTextLength int
+	// UserAgent sent with the requests. Defaults to "google-adk/<version>".
+	UserAgent string
+}
+
+const (
+	defaultName          = "fetch_url"
+	defaultTimeout       = 30 * time.Second
+	defaultMaxSize       = 5 << 20
+	defaultMaxTextLength = 100000
+	maxRedirects         = 10
+)
+
+// fetchTool fetches the URLs requested by the model.
+type fetchTool struct {
+	name           string
+	description    string
+	client         *http.Client
+	allowedDomains []string
+	timeout        time.Duration
+	maxSize        int64
+	maxTextLength  int
+	userAgent      string
+}
+
+// New creates a web fetch tool.
+//
+// The tool returns the final URL, the status code, the content type and,
+// for text content, the content and the title of HTML pages. Binary content
+// is saved as an artifact whose name is returned; the artifact service
+// must be configured.
+//
+// Example:
+//
+//	fetchTool, err := webfetchtool.New(webfetchtool.Config{
+//		AllowedDomains: []string{"go.dev", "pkg.go.dev"},
+//	})
+func New(cfg Config) (tool.Tool, error) {
+	t := &fetchTool{
+		name:          cfg.Name,
+		description:   cfg.Description,
+		timeout:       cfg.Timeout,
+		maxSize:       cfg.MaxSize,
+		maxTextLength: cfg.MaxTextLength,
+		userAgent:     cfg.UserAgent,
+	}
+	for _, d := range cfg.AllowedDomains {
+		d = strings.Trim(strings.ToLower(d), ".")
+		if d == "" {
+			return nil, errors.New("allowed domains must not be empty")
+		}
+		t.allowedDomains = append(t.allowedDomains, d)
+	}
+	if t.name == "" {
+		t.name = defaultName
+	}
+	if t.description == "" {
+		t.description = "Fetches a web page or file from an http or https URL. HTML pages are returned as markdown text with their links, binary files are saved as artifacts."
+		if len(t.allowedDomains) > 0 {
+			t.description += " Allowed domains: " + strings.Join(t.allowedDomains, ", ") + "."
+		}
+	}
+	if t.timeout <= 0 {
+		t.timeout = defaultTimeout
+	}
+	if t.maxSize <= 0 {
+		t.maxSize = defaultMaxSize
+	}
+	if t.maxTextLength <= 0 {
+		t.maxTextLength = defaultMaxTextLength
+	}
+	if t.userAgent == "" {
+		t.userAgent = "google-adk/" + version.Version
+	}
+
+	client := http.DefaultClient
+	if cfg.HTTPClient != nil {
+		client = cfg.HTTPClient
+	}
+	// Copy the client to check the redirects and the addresses.
+	c := *client
+	if !cfg.AllowPrivateNetworks {
+		transport, err := publicTransport(c.Transport)
+		if err != nil {
+			return nil, err
+		}
+		c.Transport = transport
+	}
+	checkRedirect := c.CheckRedirect
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if err := t.checkURL(req.URL); err != nil {
+			return err
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		return nil
+	}
+	t.client = &c
+	return t, nil
+}
+
+// Name implements tool.Tool.
+func (t *fetchTool) Name() string {
+	return t.name
+}
+
+// Description implements tool.Tool.
+func (t *fetchTool) Description() string {
+	return t.description
+}
+
+// IsLongRunning implements tool.Tool.
+func (t *fetchTool) IsLongRunning() bool {
+	return false
+}
+
+func (t *fetchTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return toolutils.PackTool(req, t)
+}
+
+func (t *fetchTool) Declaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{
+		Name:        t.name,
+		Description: t.description,
+		ParametersJsonSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"url": map[string]any{
+					"type":        "string",
+					"description": "The http or https URL to fetch.",
+				},
+			},
+			"required": []string{"url"},
+		},
+	}
+}
+
+// Run fetches the URL.
+func (t *fetchTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	margs, ok := args.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected args type, got: %T", args)
+	}
+	rawURL, _ := margs["url"].(string)
+	if rawURL == "" {
+		return nil, errors.New("missing required argument \"url\"")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	if err := t.checkURL(u); err != nil {
+		return nil, err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(timeoutCtx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", t.userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,text/*;q=0.9,*/*;q=0.8")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %q: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, t.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", rawURL, err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("fetching %q returned %s", rawURL, resp.Status)
+	}
+	truncated := int64(len(data)) > t.maxSize
+	if truncated {
+		data = data[:t.maxSize]
+	}
+
+	finalURL := resp.Request.URL
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "" {
+		mediaType = http.DetectContentType(data)
+		mediaType, _, _ = mime.ParseMediaType(mediaType)
+	}
+	result := map[string]any{
+		"url":          finalURL.String(),
+		"status_code":  resp.StatusCode,
+		"content_type": mediaType,
+	}
+
+	switch {
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		title, text, err := htmlToText(data, finalURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse HTML of %q: %w", rawURL, err)
+		}
+		if title != "" {
+			result["title"] = title
+		}
+		t.setContent(result, text, truncated)
+	case toolutils.IsTextMIMEType(mediaType):
+		t.setContent(result, strings.ToValidUTF8(string(data), "�"), truncated)
+	default:
+		if truncated {
+			return nil, fmt.Errorf("content of %q is larger than the maximum size of %d bytes", rawURL, t.maxSize)
+		}
+		if ctx.Artifacts() == nil {
+			return nil, fmt.Errorf("content of %q has type %q but the artifact service is not configured to save it", rawURL, mediaType)
+		}
+		name := toolutils.ArtifactName(finalURL, mediaType)
+		saveResp, err := ctx.Artifacts().Save(ctx, name, genai.NewPartFromBytes(data, mediaType))
+		if err != nil {
+			return nil, fmt.Errorf("failed to save content of %q as artifact: %w", rawURL, err)
+		}
+		result["artifact"] = name
+		result["artifact_version"] = saveResp.Version
+		result["size"] = len(data)
+	}
+	return result, nil
+}
+
+// setContent sets the text content in the result, truncated to the maximum
+// text length.
+func (t *fetchTool) setContent(result map[string]any, text string, truncated bool) {
+	if len(text) > t.maxTextLength {
+		text = strings.ToValidUTF8(text[:t.maxTextLength], "")
+		truncated = true
+	}
+	result["content"] = text
+	if truncated {
+		result["truncated"] = true
+	}
+}
+
+// checkURL returns an error if the URL can't be fetched.
+func (t *fetchTool) checkURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("URL %q must have the http or https scheme", u.String())
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		return fmt.Errorf("URL %q has no host", u.String())
+	}
+	if len(t.allowedDomains) == 0 {
+		return nil
+	}
+	for _, d := range t.allowedDomains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return nil
+		}
+	}
+	return fmt.Errorf("domain %q is not allowed, allowed domains are: %s", host, strings.Join(t.allowedDomains, ", "))
+}
+
+// publicTransport returns a copy of the transport which only connects to
+// public IP addresses.
+func publicTransport(rt http.RoundTripper) (*http.Transport, error) {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("the transport of the HTTP client must be an *http.Transport to block private networks, got %T", rt)
+	}
+	dialContext, dialTLSContext := t.DialContext, t.DialTLSContext
+	t = t.Clone()
+	if rt == http.DefaultTransport || dialContext == nil {
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				return checkAddress(address)
+			},
+		}
+		t.DialContext = dialer.DialContext
+	} else {
+		t.DialContext = checkRemoteAddress(dialContext)
+	}
+	if dialTLSContext != nil {
+		t.DialTLSContext = checkRemoteAddress(dialTLSContext)
+	}
+	// The deprecated dial functions are ignored if the context variants are set.
+	t.Dial, t.DialTLS = nil, nil
+	return t, nil
+}
+
+type dialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// checkRemoteAddress wraps a custom dial function to close the connections
+// to non-public addresses before anything is sent.
+func checkRemoteAddress(dial dialFunc) dialFunc {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		conn, err := dial(ctx, network, address)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkAddress(conn.RemoteAddr().String()); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return conn, nil
+	}
+}
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// checkAddress returns an error if the host:port address isn't a public IP
+// address.
+func checkAddress(address string) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("failed to parse address %q: %w", address, err)
+	}
+	addr := addrPort.Addr().Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || sharedAddressSpace.Contains(addr) {
+		return fmt.Errorf("connecting to the non-public address %s is not allowed", addr)
+	}
+	return nil
+}
+
+var (
+	_ toolinternal.FunctionTool     = (*fetchTool)(nil)
+	_ toolinternal.RequestProcessor = (*fetchTool)(nil)
+)
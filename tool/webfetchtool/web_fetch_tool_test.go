@@ -0,0 +1,369 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webfetchtool_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/adk/artifact"
+	artifactinternal "google.golang.org/adk/internal/artifact"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/webfetchtool"
+)
+
+const testPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title> Test   page </title>
+  <style>body { color: red; }</style>
+  <script>var x = "<p>hidden</p>";</script>
+</head>
+<body>
+  <nav><a href="/">Home</a> | <a href="#top">Top</a></nav>
+  <h1>Main  title</h1>
+  <p>Some <b>bold</b> and <em>emphasized</em>
+     text with a <a href="/docs/guide.html">relative link</a>,
+     an <a href="https://example.com/x">absolute link</a> and <code>code</code>.</p>
+  <ul>
+    <li>First</li>
+    <li>Second
+      <ol><li>Nested one</li><li>Nested two</li></ol>
+    </li>
+  </ul>
+  <pre>func main() {
+	println("hi")
+}</pre>
+  <table>
+    <tr><th>Name</th><th>Value</th></tr>
+    <tr><td>a</td><td>1</td></tr>
+  </table>
+  <div hidden>Hidden text</div>
+  <img src="/logo.png" alt="Logo">
+  <a href="javascript:alert(1)">Script link</a>
+</body>
+</html>`
+
+const wantPageText = `[Home](BASE/) | Top
+
+# Main title
+
+Some **bold** and *emphasized* text with a [relative link](BASE/docs/guide.html), an [absolute link](https://example.com/x) and ` + "`code`" + `.
+
+- First
+- Second
+  1. Nested one
+  2. Nested two
+
+` + "```" + `
+func main() {
+	println("hi")
+}
+` + "```" + `
+
+| Name | Value |
+| a | 1 |
+
+![Logo](BASE/logo.png) Script link`
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(testPage))
+	})
+	mux.HandleFunc("/data.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok": true}`))
+	})
+	mux.HandleFunc("/large.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(strings.Repeat("x", 100)))
+	})
+	mux.HandleFunc("/files/image", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("\x89PNG\r\n\x1a\nimage"))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/data.json", http.StatusFound)
+	})
+	mux.HandleFunc("/redirect_out", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://other.test/", http.StatusFound)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+	})
+	mux.HandleFunc("/user_agent", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(r.UserAgent()))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTool(t *testing.T, cfg webfetchtool.Config) toolinternal.FunctionTool {
+	t.Helper()
+	ft, err := webfetchtool.New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return ft.(toolinternal.FunctionTool)
+}
+
+func createToolContext(t *testing.T, artifacts artifact.Service) tool.Context {
+	t.Helper()
+	params := icontext.InvocationContextParams{}
+	if artifacts != nil {
+		params.Artifacts = &artifactinternal.Artifacts{
+			Service:   artifacts,
+			AppName:   "app",
+			UserID:    "user",
+			SessionID: "session",
+		}
+	}
+	return toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), params), "", nil)
+}
+
+func TestFetchTool_Run(t *testing.T) {
+	server := newServer(t)
+	ft := newTool(t, webfetchtool.Config{
+		AllowedDomains:       []string{"127.0.0.1"},
+		AllowPrivateNetworks: true,
+		MaxTextLength:        50,
+		UserAgent:            "test-agent",
+	})
+
+	tests := []struct {
+		name string
+		path string
+		want map[string]any
+	}{
+		{
+			name: "json",
+			path: "/data.json",
+			want: map[string]any{"url": server.URL + "/data.json", "status_code": 200, "content_type": "application/json", "content": `{"ok": true}`},
+		},
+		{
+			name: "redirect",
+			path: "/redirect",
+			want: map[string]any{"url": server.URL + "/data.json", "status_code": 200, "content_type": "application/json", "content": `{"ok": true}`},
+		},
+		{
+			name: "truncated",
+			path: "/large.txt",
+			want: map[string]any{"url": server.URL + "/large.txt", "status_code": 200, "content_type": "text/plain", "content": strings.Repeat("x", 50), "truncated": true},
+		},
+		{
+			name: "user agent",
+			path: "/user_agent",
+			want: map[string]any{"url": server.URL + "/user_agent", "status_code": 200, "content_type": "text/plain", "content": "test-agent"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ft.Run(createToolContext(t, nil), map[string]any{"url": server.URL + tt.path})
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFetchTool_HTML(t *testing.T) {
+	server := newServer(t)
+	ft := newTool(t, webfetchtool.Config{AllowPrivateNetworks: true})
+
+	got, err := ft.Run(createToolContext(t, nil), map[string]any{"url": server.URL + "/page"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want := map[string]any{
+		"url":          server.URL + "/page",
+		"status_code":  200,
+		"content_type": "text/html",
+		"title":        "Test page",
+		"content":      strings.ReplaceAll(wantPageText, "BASE", server.URL),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFetchTool_Artifact(t *testing.T) {
+	server := newServer(t)
+	ft := newTool(t, webfetchtool.Config{AllowPrivateNetworks: true})
+	artifacts := artifact.InMemoryService()
+
+	got, err := ft.Run(createToolContext(t, artifacts), map[string]any{"url": server.URL + "/files/image"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want := map[string]any{
+		"url":              server.URL + "/files/image",
+		"status_code":      200,
+		"content_type":     "image/png",
+		"artifact":         "image.png",
+		"artifact_version": int64(1),
+		"size":             13,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+	resp, err := artifacts.Load(t.Context(), &artifact.LoadRequest{AppName: "app", UserID: "user", SessionID: "session", FileName: "image.png"})
+	if err != nil {
+		t.Fatalf("failed to load artifact: %v", err)
+	}
+	if got, want := resp.Part.InlineData.MIMEType, "image/png"; got != want {
+		t.Errorf("artifact MIME type = %q, want %q", got, want)
+	}
+
+	if _, err := ft.Run(createToolContext(t, nil), map[string]any{"url": server.URL + "/files/image"}); err == nil {
+		t.Error("Run() without artifact service succeeded, want error")
+	}
+}
+
+func TestFetchTool_Errors(t *testing.T) {
+	server := newServer(t)
+
+	tests := []struct {
+		name string
+		cfg  webfetchtool.Config
+		url  string
+	}{
+		{
+			name: "domain not allowed",
+			cfg:  webfetchtool.Config{AllowedDomains: []string{"example.com"}},
+			url:  server.URL + "/data.json",
+		},
+		{
+			name: "suffix is not a subdomain",
+			cfg:  webfetchtool.Config{AllowedDomains: []string{"ample.com"}},
+			url:  "http://example.com/",
+		},
+		{
+			name: "redirect to domain not allowed",
+			cfg:  webfetchtool.Config{AllowedDomains: []string{"127.0.0.1"}},
+			url:  server.URL + "/redirect_out",
+		},
+		{
+			name: "scheme",
+			url:  "file:///etc/passwd",
+		},
+		{
+			name: "not found",
+			url:  server.URL + "/missing",
+		},
+		{
+			name: "binary too large",
+			cfg:  webfetchtool.Config{MaxSize: 5},
+			url:  server.URL + "/files/image",
+		},
+		{
+			name: "timeout",
+			cfg:  webfetchtool.Config{Timeout: 100 * time.Millisecond},
+			url:  server.URL + "/slow",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.AllowPrivateNetworks = true
+			ft := newTool(t, tt.cfg)
+			if got, err := ft.Run(createToolContext(t, artifact.InMemoryService()), map[string]any{"url": tt.url}); err == nil {
+				t.Errorf("Run() = %v, want error", got)
+			}
+		})
+	}
+}
+
+func TestFetchTool_PrivateNetworks(t *testing.T) {
+	server := newServer(t)
+	dialer := &net.Dialer{}
+	customDial := &http.Client{Transport: &http.Transport{DialContext: dialer.DialContext}}
+
+	tests := []struct {
+		name string
+		cfg  webfetchtool.Config
+		url  string
+	}{
+		{
+			name: "loopback",
+			url:  server.URL + "/data.json",
+		},
+		{
+			name: "loopback of allowed domain",
+			cfg:  webfetchtool.Config{AllowedDomains: []string{"127.0.0.1"}},
+			url:  server.URL + "/data.json",
+		},
+		{
+			name: "metadata server",
+			url:  "http://169.254.169.254/computeMetadata/v1/",
+		},
+		{
+			name: "private network",
+			url:  "http://10.0.0.1/",
+		},
+		{
+			name: "custom dialer",
+			cfg:  webfetchtool.Config{HTTPClient: customDial},
+			url:  server.URL + "/data.json",
+		},
+		{
+			name: "client of the test server",
+			cfg:  webfetchtool.Config{HTTPClient: server.Client()},
+			url:  server.URL + "/data.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := newTool(t, tt.cfg)
+			_, err := ft.Run(createToolContext(t, nil), map[string]any{"url": tt.url})
+			if err == nil || !strings.Contains(err.Error(), "non-public address") {
+				t.Errorf("Run() error = %v, want non-public address error", err)
+			}
+		})
+	}
+
+	t.Run("unknown transport", func(t *testing.T) {
+		client := &http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)}
+		if _, err := webfetchtool.New(webfetchtool.Config{HTTPClient: client}); err == nil {
+			t.Error("New() succeeded, want error")
+		}
+		if _, err := webfetchtool.New(webfetchtool.Config{HTTPClient: client, AllowPrivateNetworks: true}); err != nil {
+			t.Errorf("New() with AllowPrivateNetworks error = %v", err)
+		}
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}